@@ -2,8 +2,10 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"maps"
 	"os"
 	"path/filepath"
 	goruntime "runtime"
@@ -14,9 +16,12 @@ import (
 	"time"
 
 	"github.com/wailsapp/wails/v2/pkg/runtime"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
+	"github.com/flavono123/kattle/internal/config"
+	"github.com/flavono123/kattle/internal/export"
 	"github.com/flavono123/kattle/internal/kube"
 	"github.com/flavono123/kattle/internal/store"
 )
@@ -36,20 +41,73 @@ type App struct {
 	// Watch state
 	watchMu       sync.RWMutex
 	controllers   []*watchController
-	stopChs       []chan struct{}
+	watchStop     chan struct{} // closed by StopWatch, cancels pending reconnects
 	watchDone     chan struct{}
 	resourceCache sync.Map // key: "context/namespace/name" → value: map[string]any
+	// watchBatchWindow batches the events of a context, see eventBatcher
+	watchBatchWindow time.Duration
+
+	connMu    sync.Mutex
+	connected map[string]time.Time // when each context last connected, see ConnectToContexts
+
+	treeMu sync.Mutex
+	tree   *treeWatch // see StartTreeWatch
 }
 
-// watchController wraps a ResourceController with context info
+// watchController wraps an acquired ResourceController with context info,
+// the controller is replaced when the watch of its context reconnects
 type watchController struct {
 	contextName string
-	controller  *kube.ResourceController
+	gvr         schema.GroupVersionResource
+
+	mu         sync.Mutex
+	controller *kube.ResourceController
+}
+
+func (wc *watchController) current() *kube.ResourceController {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+	return wc.controller
+}
+
+// replace swaps in a reconnected controller unless the watch has been stopped meanwhile
+func (wc *watchController) replace(controller *kube.ResourceController, stop <-chan struct{}) bool {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+	select {
+	case <-stop: // StopWatch has already passed this context
+		return false
+	default:
+	}
+	wc.controller = controller
+	return true
+}
+
+// stop releases the controller, tearing the informer down unless shared elsewhere.
+// Safe to call again until replaced
+func (wc *watchController) stop() {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+	wc.controller.Close()
+}
+
+const (
+	// watchMaxRetries caps the reconnects of a context after its watch failed
+	watchMaxRetries   = 5
+	watchRetryBackoff = time.Second
+	watchRetryMaxWait = 30 * time.Second
+)
+
+// WatchErrorEvent is emitted as "watch:error" when the watch of a context fails
+type WatchErrorEvent struct {
+	Context string `json:"context"`
+	Error   string `json:"error"`
+	Attempt int    `json:"attempt"` // the reconnect about to be tried, 0 once retries are exhausted
 }
 
 // NewApp creates a new App application struct
 func NewApp() *App {
-	return &App{}
+	return &App{watchBatchWindow: defaultWatchBatchWindow}
 }
 
 // startup is called when the app starts. The context is saved
@@ -57,6 +115,14 @@ func NewApp() *App {
 func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
 
+	cfg, err := config.Get()
+	if err != nil {
+		log.Printf("failed to load config: %v", err)
+	}
+	if cfg.WatchBatchMs != nil {
+		a.watchBatchWindow = time.Duration(*cfg.WatchBatchMs) * time.Millisecond
+	}
+
 	// Detect dev mode from wails environment
 	env := runtime.Environment(ctx)
 	devMode := env.BuildType == "dev"
@@ -94,6 +160,9 @@ func (a *App) ListContexts() ([]string, error) {
 // RefreshContexts invalidates the kubeconfig cache and reloads contexts
 func (a *App) RefreshContexts() ([]string, error) {
 	kube.InvalidateKubeconfigCache()
+	a.connMu.Lock()
+	a.connected = nil
+	a.connMu.Unlock()
 	return kube.ListContexts()
 }
 
@@ -102,82 +171,126 @@ func (a *App) GetCurrentContext() (string, error) {
 	return kube.GetCurrentContext()
 }
 
+// connectionCacheTTL is how long a successful connection is trusted before ConnectToContexts checks it again
+const connectionCacheTTL = 30 * time.Second
+
 // ContextConnectionResult represents the result of connecting to a context
 type ContextConnectionResult struct {
-	Context string `json:"context"`
-	Success bool   `json:"success"`
-	Error   string `json:"error,omitempty"`
+	Context   string `json:"context"`
+	Success   bool   `json:"success"`
+	Error     string `json:"error,omitempty"`
+	Cached    bool   `json:"cached"`    // an earlier successful result, not checked again
+	CheckedAt string `json:"checkedAt"` // when the server was last reached or failed to be
 }
 
 // ConnectToContexts attempts to create clients for the specified contexts
-// Returns a list of results indicating success or failure for each context
+// Returns a list of results indicating success or failure for each context,
+// contexts connected within connectionCacheTTL are not checked again
 func (a *App) ConnectToContexts(contexts []string) []ContextConnectionResult {
+	return a.connectToContexts(contexts, false)
+}
+
+// RecheckContexts is ConnectToContexts checking every context again
+func (a *App) RecheckContexts(contexts []string) []ContextConnectionResult {
+	return a.connectToContexts(contexts, true)
+}
+
+func (a *App) connectToContexts(contexts []string, force bool) []ContextConnectionResult {
 	results := make([]ContextConnectionResult, 0, len(contexts))
 
 	for _, contextName := range contexts {
-		result := ContextConnectionResult{
-			Context: contextName,
-			Success: false,
+		now := time.Now()
+		a.connMu.Lock()
+		checkedAt, ok := a.connected[contextName]
+		a.connMu.Unlock()
+		if !force && ok && now.Sub(checkedAt) < connectionCacheTTL {
+			results = append(results, ContextConnectionResult{
+				Context:   contextName,
+				Success:   true,
+				Cached:    true,
+				CheckedAt: checkedAt.Format(time.RFC3339),
+			})
+			continue
 		}
 
-		// Try to create a client for this context
-		// This validates the context and ensures we can connect
-		discoveryClient, err := kube.DiscoveryClientForContext(contextName)
-		if err != nil {
-			result.Error = err.Error()
-			results = append(results, result)
-			continue
+		result := checkContext(contextName)
+		result.CheckedAt = now.Format(time.RFC3339)
+
+		a.connMu.Lock()
+		if a.connected == nil {
+			a.connected = make(map[string]time.Time)
+		}
+		if result.Success {
+			a.connected[contextName] = now
+		} else {
+			delete(a.connected, contextName)
 		}
+		a.connMu.Unlock()
 
-		// Actually verify authentication by making a lightweight API call
-		_, err = discoveryClient.ServerVersion()
-		if err != nil {
-			// Check if error is related to tsh authentication
-			if strings.Contains(err.Error(), "tsh") {
-				// Try tsh kube login
-				attempted, loginErr := kube.TryTshKubeLogin(contextName)
-				if attempted {
-					if loginErr != nil {
-						result.Error = fmt.Sprintf("tsh kube login failed: %v", loginErr)
-						results = append(results, result)
-						continue
-					}
+		results = append(results, result)
+	}
 
-					// Login succeeded, invalidate cache and retry
-					kube.InvalidateClientCache(contextName)
+	return results
+}
 
-					// Recreate client and retry
-					discoveryClient, err = kube.DiscoveryClientForContext(contextName)
-					if err != nil {
-						result.Error = err.Error()
-						results = append(results, result)
-						continue
-					}
+// checkContext creates a client for the context and verifies it reaches the server,
+// logging in again with tsh once when its credentials expired
+func checkContext(contextName string) ContextConnectionResult {
+	result := ContextConnectionResult{
+		Context: contextName,
+		Success: false,
+	}
 
-					_, err = discoveryClient.ServerVersion()
-					if err != nil {
-						result.Error = err.Error()
-						results = append(results, result)
-						continue
-					}
+	// Try to create a client for this context
+	// This validates the context and ensures we can connect
+	discoveryClient, err := kube.DiscoveryClientForContext(contextName)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
 
-					// Success after retry
-					result.Success = true
-					results = append(results, result)
-					continue
+	// Actually verify authentication by making a lightweight API call
+	_, err = discoveryClient.ServerVersion()
+	if err != nil {
+		// Check if error is related to tsh authentication
+		if strings.Contains(err.Error(), "tsh") {
+			// Try tsh kube login
+			attempted, loginErr := kube.TryTshKubeLogin(contextName)
+			if attempted {
+				if loginErr != nil {
+					result.Error = fmt.Sprintf("tsh kube login failed: %v", loginErr)
+					return result
 				}
-			}
 
-			// Original error (not relogin or not using tsh)
-			result.Error = err.Error()
-		} else {
-			result.Success = true
+				// Login succeeded, invalidate cache and retry
+				kube.InvalidateClientCache(contextName)
+
+				// Recreate client and retry
+				discoveryClient, err = kube.DiscoveryClientForContext(contextName)
+				if err != nil {
+					result.Error = err.Error()
+					return result
+				}
+
+				_, err = discoveryClient.ServerVersion()
+				if err != nil {
+					result.Error = err.Error()
+					return result
+				}
+
+				// Success after retry
+				result.Success = true
+				return result
+			}
 		}
 
-		results = append(results, result)
+		// Original error (not relogin or not using tsh)
+		result.Error = err.Error()
+	} else {
+		result.Success = true
 	}
 
-	return results
+	return result
 }
 
 // MultiClusterGVK represents a Kubernetes resource (Group/Version/Kind) with context availability
@@ -264,6 +377,20 @@ type TreeNode struct {
 // GetNodeTree retrieves the node tree for a given GVK and contexts
 // Returns a tree structure representing the schema + actual data
 func (a *App) GetNodeTree(gvk MultiClusterGVK, contexts []string) ([]*TreeNode, error) {
+	fields, objs, err := a.nodeTreeSources(gvk, contexts)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Create node tree
+	nodes := kube.CreateNodeTree(fields, objs, []string{})
+
+	// 4. Convert to frontend format (remove UI state, convert to array)
+	return convertNodeTree(nodes), nil
+}
+
+// nodeTreeSources returns the field tree of a GVK and the objects to build its node tree from
+func (a *App) nodeTreeSources(gvk MultiClusterGVK, contexts []string) (map[string]*kube.Field, []*unstructured.Unstructured, error) {
 	// Convert MultiClusterGVK to schema.GroupVersionKind
 	schemaGVK := schema.GroupVersionKind{
 		Group:   gvk.Group,
@@ -281,7 +408,7 @@ func (a *App) GetNodeTree(gvk MultiClusterGVK, contexts []string) ([]*TreeNode,
 		fields, err = kube.CreateFieldTree(schemaGVK)
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to create field tree: %w", err)
+		return nil, nil, fmt.Errorf("failed to create field tree: %w", err)
 	}
 
 	// 2. Get resources - prefer active watch store to avoid duplicate List calls
@@ -290,15 +417,10 @@ func (a *App) GetNodeTree(gvk MultiClusterGVK, contexts []string) ([]*TreeNode,
 		// No active watch, fetch directly (with cleanup)
 		objs, err = a.getResourcesWithCleanup(schemaGVK, contexts)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get resources: %w", err)
+			return nil, nil, fmt.Errorf("failed to get resources: %w", err)
 		}
 	}
-
-	// 3. Create node tree
-	nodes := kube.CreateNodeTree(fields, objs, []string{})
-
-	// 4. Convert to frontend format (remove UI state, convert to array)
-	return convertNodeTree(nodes), nil
+	return fields, objs, nil
 }
 
 // GetDefaultSelectedPaths returns the default fields to select for a GVK.
@@ -330,6 +452,40 @@ func (a *App) GetDefaultSelectedPaths(gvk MultiClusterGVK, contexts []string) []
 	return nil
 }
 
+// GetKubectlCommand returns the `kubectl get -o custom-columns=...` equivalent of the selected paths,
+// resolved against the first context serving the GVK
+func (a *App) GetKubectlCommand(gvk MultiClusterGVK, paths [][]string) (string, error) {
+	schemaGVK := schema.GroupVersionKind{
+		Group:   gvk.Group,
+		Version: gvk.Version,
+		Kind:    gvk.Kind,
+	}
+	contextName := ""
+	if len(gvk.Contexts) > 0 {
+		contextName = gvk.Contexts[0]
+	}
+
+	gvr, err := kube.GetGVRForContext(contextName, schemaGVK)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", schemaGVK.Kind, err)
+	}
+	namespaced, err := kube.IsNamespacedForContext(contextName, schemaGVK)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the scope of %s: %w", schemaGVK.Kind, err)
+	}
+
+	nodes := make([]*kube.Node, 0, len(paths))
+	for _, path := range paths {
+		if node := kube.NewPathNode(path); node != nil {
+			nodes = append(nodes, node)
+		}
+	}
+
+	command := kube.BuildCustomColumns(gvr, nodes, !namespaced)
+	command.Context = contextName
+	return command.String(), nil
+}
+
 // getWatchedResources returns resources from active watch controllers if available
 func (a *App) getWatchedResources() []*unstructured.Unstructured {
 	a.watchMu.RLock()
@@ -341,49 +497,78 @@ func (a *App) getWatchedResources() []*unstructured.Unstructured {
 
 	var allObjs []*unstructured.Unstructured
 	for _, wc := range a.controllers {
-		objs := wc.controller.Objects()
-		allObjs = append(allObjs, objs...)
+		for _, obj := range wc.current().Objects() {
+			allObjs = append(allObjs, withContext(wc.contextName, obj))
+		}
 	}
 	return allObjs
 }
 
 // getResourcesWithCleanup fetches resources and properly cleans up controllers
 func (a *App) getResourcesWithCleanup(gvk schema.GroupVersionKind, contexts []string) ([]*unstructured.Unstructured, error) {
+	allObjs, statuses := a.getResourcesForContexts(gvk, contexts)
+	for _, status := range statuses {
+		if !status.Succeeded {
+			log.Printf("Warning: %s", status.Error)
+		}
+	}
+	return allObjs, nil
+}
+
+// ContextResourcesStatus reports whether a context returned the objects of a kind
+type ContextResourcesStatus struct {
+	Context   string `json:"context"`
+	Succeeded bool   `json:"succeeded"`
+	Error     string `json:"error,omitempty"`
+	Count     int    `json:"count"`
+}
+
+// getResourcesForContexts fetches the objects of gvk in every context concurrently,
+// with a status per context in the order of contexts
+func (a *App) getResourcesForContexts(gvk schema.GroupVersionKind, contexts []string) ([]*unstructured.Unstructured, []ContextResourcesStatus) {
 	var allObjs []*unstructured.Unstructured
 	var mu sync.Mutex
 	var wg sync.WaitGroup
+	statuses := make([]ContextResourcesStatus, len(contexts))
 
-	for _, contextName := range contexts {
+	for i, contextName := range contexts {
 		wg.Add(1)
-		go func(ctx string) {
+		go func(i int, ctx string) {
 			defer wg.Done()
 
-			gvr, err := kube.GetGVRForContext(ctx, gvk)
+			objs, err := fetchResources(ctx, gvk)
 			if err != nil {
-				log.Printf("Warning: failed to get GVR for %s in context %s: %v", gvk.Kind, ctx, err)
+				statuses[i] = ContextResourcesStatus{Context: ctx, Error: err.Error()}
 				return
 			}
-
-			controller := kube.NewResourceControllerForContext(ctx, gvr)
-			stopCh, err := controller.Inform()
-			if err != nil {
-				log.Printf("Warning: failed to start informer for %s in context %s: %v", gvk.Kind, ctx, err)
-				return
-			}
-
-			// Get objects then immediately cleanup
-			objs := controller.Objects()
-			close(stopCh)
-			controller.Close()
+			statuses[i] = ContextResourcesStatus{Context: ctx, Succeeded: true, Count: len(objs)}
 
 			mu.Lock()
-			allObjs = append(allObjs, objs...)
+			for _, obj := range objs {
+				allObjs = append(allObjs, withContext(ctx, obj))
+			}
 			mu.Unlock()
-		}(contextName)
+		}(i, contextName)
 	}
 
 	wg.Wait()
-	return allObjs, nil
+	return allObjs, statuses
+}
+
+// fetchResources returns the objects of gvk in a context, releasing the informer right away
+// so it only stays up while watched
+func fetchResources(ctx string, gvk schema.GroupVersionKind) ([]*unstructured.Unstructured, error) {
+	gvr, err := kube.GetGVRForContext(ctx, gvk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GVR for %s in context %s: %w", gvk.Kind, ctx, err)
+	}
+
+	controller, err := kube.AcquireResourceController(ctx, gvr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start informer for %s in context %s: %w", gvk.Kind, ctx, err)
+	}
+	defer controller.Close()
+	return controller.Objects(), nil
 }
 
 // GetResources returns resources from active watch or fetches them directly
@@ -407,18 +592,42 @@ func (a *App) GetResources(gvk MultiClusterGVK, contexts []string) ([]map[string
 		}
 	}
 
-	// Convert to frontend format with _context field
+	return toResourceMaps(objs), nil
+}
+
+// ResourcesResult is the merged resources of GetResources with the status of every context
+type ResourcesResult struct {
+	Resources []map[string]interface{} `json:"resources"`
+	Contexts  []ContextResourcesStatus `json:"contexts"`
+}
+
+// GetResourcesWithStatus fetches resources like GetResources, reporting which contexts failed
+// to return theirs instead of dropping them silently
+func (a *App) GetResourcesWithStatus(gvk MultiClusterGVK, contexts []string) ResourcesResult {
+	schemaGVK := schema.GroupVersionKind{
+		Group:   gvk.Group,
+		Version: gvk.Version,
+		Kind:    gvk.Kind,
+	}
+	objs, statuses := a.getResourcesForContexts(schemaGVK, contexts)
+	return ResourcesResult{Resources: toResourceMaps(objs), Contexts: statuses}
+}
+
+// toResourceMaps converts objects to the frontend format, their _context set by withContext
+func toResourceMaps(objs []*unstructured.Unstructured) []map[string]interface{} {
 	var allResources []map[string]interface{}
 	for _, obj := range objs {
-		resource := obj.Object
-		// _context should already be set by watch, but ensure it exists
-		if _, ok := resource["_context"]; !ok {
-			resource["_context"] = ""
-		}
-		allResources = append(allResources, resource)
+		allResources = append(allResources, obj.Object)
 	}
+	return allResources
+}
 
-	return allResources, nil
+// withContext returns a shallow copy of obj with the _context field of the frontend.
+// The objects of an informer are shared by every acquired controller and never written
+func withContext(ctx string, obj *unstructured.Unstructured) *unstructured.Unstructured {
+	resource := maps.Clone(obj.Object)
+	resource["_context"] = ctx
+	return &unstructured.Unstructured{Object: resource}
 }
 
 // ResourceEventMeta represents a lightweight watch event (Pull Model)
@@ -434,10 +643,10 @@ func makeResourceKey(context, namespace, name string) string {
 }
 
 // StartWatch starts watching resources for the given GVK across specified contexts
-// Watch events are emitted via Wails runtime events ("resource:update")
+// Watch events are emitted in batches via Wails runtime events ("resource:update")
 func (a *App) StartWatch(gvk MultiClusterGVK, contexts []string) error {
-	// Stop any existing watch first
-	a.StopWatch()
+	// Stop any existing watch first, the tree watch keeps following the new one
+	a.stopWatch()
 
 	a.watchMu.Lock()
 	defer a.watchMu.Unlock()
@@ -449,7 +658,7 @@ func (a *App) StartWatch(gvk MultiClusterGVK, contexts []string) error {
 	}
 
 	a.controllers = make([]*watchController, 0, len(contexts))
-	a.stopChs = make([]chan struct{}, 0, len(contexts))
+	a.watchStop = make(chan struct{})
 	a.watchDone = make(chan struct{})
 
 	var wg sync.WaitGroup
@@ -461,52 +670,25 @@ func (a *App) StartWatch(gvk MultiClusterGVK, contexts []string) error {
 			continue
 		}
 
-		controller := kube.NewResourceControllerForContext(contextName, gvr)
-		stopCh, err := controller.Inform()
+		controller, err := kube.AcquireResourceController(contextName, gvr)
 		if err != nil {
 			log.Printf("Warning: failed to start watch for %s in context %s: %v", schemaGVK.Kind, contextName, err)
 			continue
 		}
 
-		a.controllers = append(a.controllers, &watchController{
+		wc := &watchController{
 			contextName: contextName,
+			gvr:         gvr,
 			controller:  controller,
-		})
-		a.stopChs = append(a.stopChs, stopCh)
+		}
+		a.controllers = append(a.controllers, wc)
 
 		// Start goroutine to forward events to frontend (Pull Model)
 		wg.Add(1)
-		go func(ctx string, ctrl *kube.ResourceController) {
+		go func() {
 			defer wg.Done()
-			for {
-				select {
-				case event := <-ctrl.WatchEvents():
-					if event.Obj == nil {
-						continue // skip invalid events
-					}
-
-					key := makeResourceKey(ctx, event.Obj.GetNamespace(), event.Obj.GetName())
-
-					if string(event.Type) == "DELETED" {
-						// Remove from cache on delete
-						a.resourceCache.Delete(key)
-					} else {
-						// Store in cache for ADDED/MODIFIED
-						obj := event.Obj.Object
-						obj["_context"] = ctx
-						a.resourceCache.Store(key, obj)
-					}
-
-					// Emit only lightweight metadata (no full object via eval)
-					runtime.EventsEmit(a.ctx, "resource:update", ResourceEventMeta{
-						Type: string(event.Type),
-						Key:  key,
-					})
-				case <-ctrl.Done():
-					return
-				}
-			}
-		}(contextName, controller)
+			a.forwardWatch(wc, a.watchStop)
+		}()
 	}
 
 	// Wait for all event forwarders to finish in background
@@ -520,23 +702,169 @@ func (a *App) StartWatch(gvk MultiClusterGVK, contexts []string) error {
 	return nil
 }
 
-// StopWatch stops all active resource watches
+// forwardWatch forwards the events of a context until the watch stops,
+// reconnecting its controller when the watch fails
+func (a *App) forwardWatch(wc *watchController, stop <-chan struct{}) {
+	batch := newEventBatcher(a.watchBatchWindow, a.emitResourceEvents)
+	for {
+		err := a.forwardEvents(wc.contextName, wc.current(), batch)
+		if err == nil || !a.reconnectWatch(wc, err, stop) {
+			return
+		}
+	}
+}
+
+// forwardEvents returns the first fatal watch error, or nil once the controller is closed.
+// Other errors are only logged, see isFatalWatchError.
+// The events are batched, the pending ones are emitted on return
+func (a *App) forwardEvents(ctx string, ctrl *kube.ResourceController, batch *eventBatcher) error {
+	defer batch.flush()
+	for {
+		select {
+		case event, ok := <-ctrl.WatchEvents():
+			if !ok {
+				return nil
+			}
+			if event.Obj == nil {
+				continue // skip invalid events
+			}
+
+			key := makeResourceKey(ctx, event.Obj.GetNamespace(), event.Obj.GetName())
+
+			if string(event.Type) == "DELETED" {
+				// Remove from cache on delete
+				a.resourceCache.Delete(key)
+			} else {
+				// Store in cache for ADDED/MODIFIED
+				a.resourceCache.Store(key, withContext(ctx, event.Obj).Object)
+			}
+
+			// Emit only lightweight metadata (no full object via eval)
+			batch.add(ResourceEventMeta{
+				Type: string(event.Type),
+				Key:  key,
+			})
+		case <-batch.due():
+			batch.flush()
+		case err := <-ctrl.ErrorEmitted():
+			if isFatalWatchError(err) {
+				return err
+			}
+			log.Printf("Notice: watch of %s in context %s: %v", ctrl.GVR().Resource, ctx, err)
+		case <-ctrl.Done():
+			return nil
+		}
+	}
+}
+
+// isFatalWatchError reports whether the controller has to be re-created to recover from err,
+// e.g. expired credentials or a resource gone from the server. The reflector retries
+// transient list/watch failures on its own, and a list limit warning leaves the informer running
+func isFatalWatchError(err error) bool {
+	var limitErr *kube.ListLimitExceededError
+	if errors.As(err, &limitErr) {
+		return false
+	}
+	return apierrors.IsUnauthorized(err) ||
+		apierrors.IsForbidden(err) ||
+		apierrors.IsNotFound(err) ||
+		apierrors.IsMethodNotSupported(err)
+}
+
+// reconnectWatch re-creates the controller of a failed context with backoff,
+// leaving the other contexts alone. It reports false when retries are exhausted or the watch stops.
+func (a *App) reconnectWatch(wc *watchController, cause error, stop <-chan struct{}) bool {
+	log.Printf("Warning: watch of %s in context %s failed, reconnecting: %v", wc.gvr.Resource, wc.contextName, cause)
+	wc.stop()
+
+	wait := watchRetryBackoff
+	for attempt := 1; attempt <= watchMaxRetries; attempt++ {
+		runtime.EventsEmit(a.ctx, "watch:error", WatchErrorEvent{
+			Context: wc.contextName,
+			Error:   cause.Error(),
+			Attempt: attempt,
+		})
+
+		select {
+		case <-time.After(wait):
+		case <-stop:
+			return false
+		}
+		wait = min(wait*2, watchRetryMaxWait)
+
+		controller, err := kube.AcquireResourceController(wc.contextName, wc.gvr)
+		if err != nil {
+			cause = err
+			continue
+		}
+
+		if !wc.replace(controller, stop) {
+			controller.Close()
+			return false
+		}
+		a.resyncContext(wc.contextName, controller)
+		log.Printf("Reconnected watch of %s in context %s", wc.gvr.Resource, wc.contextName)
+		return true
+	}
+
+	runtime.EventsEmit(a.ctx, "watch:error", WatchErrorEvent{
+		Context: wc.contextName,
+		Error:   cause.Error(),
+	})
+	log.Printf("Warning: gave up reconnecting the watch of %s in context %s: %v", wc.gvr.Resource, wc.contextName, cause)
+	return false
+}
+
+// resyncContext emits synthetic deletes for objects of a context gone while its watch was down,
+// the reconnected informer emits the remaining ones as added
+func (a *App) resyncContext(ctx string, ctrl *kube.ResourceController) {
+	alive := map[string]bool{}
+	for _, obj := range ctrl.Objects() {
+		alive[makeResourceKey(ctx, obj.GetNamespace(), obj.GetName())] = true
+	}
+
+	prefix := ctx + "/"
+	var deleted []ResourceEventMeta
+	a.resourceCache.Range(func(k, _ any) bool {
+		key := k.(string)
+		if strings.HasPrefix(key, prefix) && !alive[key] {
+			a.resourceCache.Delete(key)
+			deleted = append(deleted, ResourceEventMeta{
+				Type: string(kube.EventDeleted),
+				Key:  key,
+			})
+		}
+		return true
+	})
+	if len(deleted) > 0 {
+		a.emitResourceEvents(deleted)
+	}
+}
+
+// emitResourceEvents emits a batch of watch events as "resource:update"
+func (a *App) emitResourceEvents(events []ResourceEventMeta) {
+	runtime.EventsEmit(a.ctx, "resource:update", events)
+	a.markTreeStale()
+}
+
+// StopWatch stops all active resource watches and the tree watch
 func (a *App) StopWatch() {
+	a.StopTreeWatch()
+	a.stopWatch()
+}
+
+func (a *App) stopWatch() {
 	a.watchMu.Lock()
 	defer a.watchMu.Unlock()
 
-	if len(a.stopChs) == 0 {
+	if len(a.controllers) == 0 {
 		return
 	}
 
-	// Close all stop channels to stop informers
-	for _, stopCh := range a.stopChs {
-		close(stopCh)
-	}
-
-	// Close all controllers to unblock goroutines and release resources
+	// Cancel pending reconnects, then stop informers and unblock the forwarders
+	close(a.watchStop)
 	for _, wc := range a.controllers {
-		wc.controller.Close()
+		wc.stop()
 	}
 
 	// Wait for event forwarders to finish (with timeout)
@@ -549,7 +877,7 @@ func (a *App) StopWatch() {
 	}
 
 	a.controllers = nil
-	a.stopChs = nil
+	a.watchStop = nil
 	a.watchDone = nil
 
 	// Clear resource cache
@@ -634,7 +962,34 @@ func convertNodeTree(nodes map[string]*kube.Node) []*TreeNode {
 	return result
 }
 
-// SaveFile opens a save file dialog and saves the content to the selected file
+// FormatResources formats the picked fields of the cached resources at keys, in their order,
+// as an export format such as csv, json or yaml
+func (a *App) FormatResources(keys []string, fields [][]string, format string) (string, error) {
+	formatter, err := export.FormatterFor(format)
+	if err != nil {
+		return "", err
+	}
+
+	nodes := make([]*kube.Node, 0, len(fields))
+	for _, path := range fields {
+		if node := kube.NewPathNode(path); node != nil {
+			nodes = append(nodes, node)
+		}
+	}
+	objs := make([]*unstructured.Unstructured, 0, len(keys))
+	for _, obj := range a.GetResourcesByKeys(keys) {
+		objs = append(objs, &unstructured.Unstructured{Object: obj})
+	}
+
+	var buf strings.Builder
+	if err := formatter.Format(nodes, objs, &buf); err != nil {
+		return "", fmt.Errorf("failed to format %s: %w", format, err)
+	}
+	return buf.String(), nil
+}
+
+// SaveFile opens a save file dialog and saves the content to the selected file,
+// keeping the extension of defaultFilename (e.g. .csv)
 // Returns the path where the file was saved, or empty string if cancelled
 func (a *App) SaveFile(defaultFilename string, content string) (string, error) {
 	// Get user's Downloads directory as default location
@@ -644,15 +999,21 @@ func (a *App) SaveFile(defaultFilename string, content string) (string, error) {
 	}
 	defaultDir := filepath.Join(homeDir, "Downloads")
 
+	ext := filepath.Ext(defaultFilename)
+	if ext == "" {
+		ext = ".csv"
+	}
+	format := strings.ToUpper(strings.TrimPrefix(ext, "."))
+
 	// Open save file dialog
 	filePath, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
 		DefaultDirectory: defaultDir,
 		DefaultFilename:  defaultFilename,
-		Title:            "Save CSV File",
+		Title:            "Save " + format + " File",
 		Filters: []runtime.FileFilter{
 			{
-				DisplayName: "CSV Files (*.csv)",
-				Pattern:     "*.csv",
+				DisplayName: format + " Files (*" + ext + ")",
+				Pattern:     "*" + ext,
 			},
 			{
 				DisplayName: "All Files (*.*)",
@@ -670,9 +1031,9 @@ func (a *App) SaveFile(defaultFilename string, content string) (string, error) {
 		return "", nil
 	}
 
-	// Ensure .csv extension
-	if !strings.HasSuffix(filePath, ".csv") {
-		filePath += ".csv"
+	// Ensure the extension
+	if !strings.HasSuffix(filePath, ext) {
+		filePath += ext
 	}
 
 	// Write content to file
@@ -695,11 +1056,16 @@ type FavoriteViewResponse struct {
 	Name      string          `json:"name"`
 	GVK       FavoriteViewGVK `json:"gvk"`
 	Fields    [][]string      `json:"fields"`
+	Tags      []string        `json:"tags"`
 	CreatedAt string          `json:"createdAt"`
 	UpdatedAt string          `json:"updatedAt"`
 }
 
 func favoriteViewToResponse(v *store.FavoriteView) FavoriteViewResponse {
+	tags := v.Tags
+	if tags == nil {
+		tags = []string{} // views saved before tags existed
+	}
 	return FavoriteViewResponse{
 		ID:   v.ID,
 		Name: v.Name,
@@ -709,11 +1075,40 @@ func favoriteViewToResponse(v *store.FavoriteView) FavoriteViewResponse {
 			Kind:    v.GVK.Kind,
 		},
 		Fields:    v.Fields,
+		Tags:      tags,
 		CreatedAt: v.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt: v.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
 }
 
+// ListFavoriteViewsByTag returns favorite views tagged with tag.
+func (a *App) ListFavoriteViewsByTag(tag string) ([]FavoriteViewResponse, error) {
+	if a.favoriteStore == nil {
+		return nil, fmt.Errorf("favorite store not initialized")
+	}
+
+	views := a.favoriteStore.ListByTag(tag)
+	result := make([]FavoriteViewResponse, len(views))
+	for i, v := range views {
+		result[i] = favoriteViewToResponse(&v)
+	}
+	return result, nil
+}
+
+// SearchFavoriteViews returns favorite views whose name or kind matches query, exact names first.
+func (a *App) SearchFavoriteViews(query string) ([]FavoriteViewResponse, error) {
+	if a.favoriteStore == nil {
+		return nil, fmt.Errorf("favorite store not initialized")
+	}
+
+	views := a.favoriteStore.Search(query)
+	result := make([]FavoriteViewResponse, len(views))
+	for i, v := range views {
+		result[i] = favoriteViewToResponse(&v)
+	}
+	return result, nil
+}
+
 // ListFavoriteViews returns all favorite views.
 func (a *App) ListFavoriteViews() ([]FavoriteViewResponse, error) {
 	if a.favoriteStore == nil {
@@ -744,13 +1139,13 @@ func (a *App) GetFavoriteViewsForGVK(group, version, kind string) ([]FavoriteVie
 }
 
 // SaveFavoriteView saves current selection as a favorite.
-func (a *App) SaveFavoriteView(name, group, version, kind string, fields [][]string) (*FavoriteViewResponse, error) {
+func (a *App) SaveFavoriteView(name, group, version, kind string, fields [][]string, tags []string) (*FavoriteViewResponse, error) {
 	if a.favoriteStore == nil {
 		return nil, fmt.Errorf("favorite store not initialized")
 	}
 
 	gvk := store.GVKRef{Group: group, Version: version, Kind: kind}
-	view, err := a.favoriteStore.Create(name, gvk, fields)
+	view, err := a.favoriteStore.Create(name, gvk, fields, tags)
 	if err != nil {
 		return nil, err
 	}
@@ -776,13 +1171,96 @@ func (a *App) DeleteFavoriteView(id string) error {
 	return a.favoriteStore.Save()
 }
 
-// RenameFavoriteView updates the name of a favorite view.
-func (a *App) RenameFavoriteView(id, newName string) (*FavoriteViewResponse, error) {
+// FieldValidation reports whether a favorite's field path still resolves in the schema
+type FieldValidation struct {
+	Path  []string `json:"path"`
+	Valid bool     `json:"valid"`
+	Error string   `json:"error,omitempty"`
+}
+
+// ValidateFavoriteView checks the fields of a favorite view against the schema of its GVK,
+// in the first of contexts serving it, e.g. before applying a view saved before a CRD upgrade.
+func (a *App) ValidateFavoriteView(id string, contexts []string) ([]FieldValidation, error) {
+	if a.favoriteStore == nil {
+		return nil, fmt.Errorf("favorite store not initialized")
+	}
+
+	view, err := a.favoriteStore.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	gvk := schema.GroupVersionKind{
+		Group:   view.GVK.Group,
+		Version: view.GVK.Version,
+		Kind:    view.GVK.Kind,
+	}
+
+	var fields map[string]*kube.Field
+	err = fmt.Errorf("no context to resolve %s", gvk.Kind)
+	for _, contextName := range contexts {
+		if fields, err = kube.CreateFieldTreeForContext(contextName, gvk); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create field tree: %w", err)
+	}
+
+	return validateFields(fields, view.Fields), nil
+}
+
+// validateFields resolves each path in the field tree, numeric and * segments index arrays
+func validateFields(fields map[string]*kube.Field, paths [][]string) []FieldValidation {
+	result := make([]FieldValidation, len(paths))
+	for i, path := range paths {
+		result[i] = FieldValidation{Path: path, Valid: true}
+		if err := kube.CheckFieldPath(fields, path); err != nil {
+			result[i].Valid = false
+			result[i].Error = err.Error()
+		}
+	}
+	return result
+}
+
+// DuplicateFavoriteView copies a favorite view under a new name.
+func (a *App) DuplicateFavoriteView(id, newName string) (*FavoriteViewResponse, error) {
+	if a.favoriteStore == nil {
+		return nil, fmt.Errorf("favorite store not initialized")
+	}
+
+	view, err := a.favoriteStore.Duplicate(id, newName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.favoriteStore.Save(); err != nil {
+		return nil, fmt.Errorf("failed to save: %w", err)
+	}
+
+	result := favoriteViewToResponse(view)
+	return &result, nil
+}
+
+// ReorderFavoriteViews arranges all favorite views in the order of ids.
+func (a *App) ReorderFavoriteViews(ids []string) error {
+	if a.favoriteStore == nil {
+		return fmt.Errorf("favorite store not initialized")
+	}
+
+	if err := a.favoriteStore.Reorder(ids); err != nil {
+		return err
+	}
+
+	return a.favoriteStore.Save()
+}
+
+// RenameFavoriteView updates the name and tags of a favorite view.
+func (a *App) RenameFavoriteView(id, newName string, tags []string) (*FavoriteViewResponse, error) {
 	if a.favoriteStore == nil {
 		return nil, fmt.Errorf("favorite store not initialized")
 	}
 
-	view, err := a.favoriteStore.Rename(id, newName)
+	view, err := a.favoriteStore.Rename(id, newName, tags)
 	if err != nil {
 		return nil, err
 	}
@@ -794,3 +1272,82 @@ func (a *App) RenameFavoriteView(id, newName string) (*FavoriteViewResponse, err
 	result := favoriteViewToResponse(view)
 	return &result, nil
 }
+
+// ExportFavorites opens a save file dialog and writes all favorite views to the selected file
+// Returns the path where the file was saved, or empty string if cancelled
+func (a *App) ExportFavorites() (string, error) {
+	if a.favoriteStore == nil {
+		return "", fmt.Errorf("favorite store not initialized")
+	}
+
+	filePath, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		DefaultFilename: "kupid-favorites.json",
+		Title:           "Export Favorite Views",
+		Filters:         favoritesFileFilters(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to open save dialog: %w", err)
+	}
+	if filePath == "" {
+		return "", nil
+	}
+	if !strings.HasSuffix(filePath, ".json") {
+		filePath += ".json"
+	}
+
+	f, err := os.Create(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	if err := a.favoriteStore.ExportAll(f); err != nil {
+		return "", fmt.Errorf("failed to export favorite views: %w", err)
+	}
+	return filePath, nil
+}
+
+// ImportFavorites adds the favorite views exported to path, skipping names already taken for a GVK
+// An empty path opens a file dialog; returns all favorite views after the import
+func (a *App) ImportFavorites(path string) ([]FavoriteViewResponse, error) {
+	if a.favoriteStore == nil {
+		return nil, fmt.Errorf("favorite store not initialized")
+	}
+
+	if path == "" {
+		selected, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+			Title:   "Import Favorite Views",
+			Filters: favoritesFileFilters(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to open file dialog: %w", err)
+		}
+		if selected == "" {
+			return a.ListFavoriteViews()
+		}
+		path = selected
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer f.Close()
+
+	if err := a.favoriteStore.ImportAll(f, false); err != nil {
+		return nil, err
+	}
+	if err := a.favoriteStore.Save(); err != nil {
+		return nil, fmt.Errorf("failed to save: %w", err)
+	}
+	return a.ListFavoriteViews()
+}
+
+func favoritesFileFilters() []runtime.FileFilter {
+	return []runtime.FileFilter{
+		{
+			DisplayName: "JSON Files (*.json)",
+			Pattern:     "*.json",
+		},
+	}
+}
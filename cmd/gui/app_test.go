@@ -1,7 +1,16 @@
 package main
 
 import (
+	"errors"
+	"fmt"
 	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/flavono123/kattle/internal/kube"
 )
 
 // TestGetNodeTree_ContextSelection tests that GetNodeTree uses the correct context
@@ -105,3 +114,181 @@ func TestCreateFieldTreeForContext_IsExported(t *testing.T) {
 
 	t.Log("CreateFieldTreeForContext is exported and available for use")
 }
+
+// TestWatchControllerReplace_AfterStop tests that a reconnected controller is not swapped in
+// once the watch has been stopped
+func TestWatchControllerReplace_AfterStop(t *testing.T) {
+	wc := &watchController{contextName: "cluster-1"}
+	stop := make(chan struct{})
+
+	if !wc.replace(&kube.ResourceController{}, stop) {
+		t.Fatal("expected the controller to be replaced while the watch runs")
+	}
+
+	close(stop)
+	rejected := &kube.ResourceController{}
+	if wc.replace(rejected, stop) {
+		t.Fatal("expected the controller not to be replaced after the watch stopped")
+	}
+	if wc.controller == rejected {
+		t.Error("the rejected controller was kept")
+	}
+}
+
+// TestIsFatalWatchError tests that only errors the reflector cannot recover from reconnect the watch
+func TestIsFatalWatchError(t *testing.T) {
+	pods := schema.GroupResource{Resource: "pods"}
+	tests := []struct {
+		name  string
+		err   error
+		fatal bool
+	}{
+		{"unauthorized", apierrors.NewUnauthorized("token expired"), true},
+		{"forbidden", apierrors.NewForbidden(pods, "", errors.New("denied")), true},
+		{"resource gone", apierrors.NewNotFound(pods, ""), true},
+		{"wrapped", fmt.Errorf("watch: %w", apierrors.NewUnauthorized("token expired")), true},
+		{"list limit", &kube.ListLimitExceededError{Limit: 2, Count: 3}, false},
+		{"expired continue token", apierrors.NewResourceExpired("too old"), false},
+		{"server unavailable", apierrors.NewServiceUnavailable("etcd"), false},
+		{"connection refused", errors.New("dial tcp: connection refused"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isFatalWatchError(tt.err); got != tt.fatal {
+				t.Errorf("expected fatal %v, got %v", tt.fatal, got)
+			}
+		})
+	}
+}
+
+// TestConnectToContexts_Cache tests that recent successful connections are reused until rechecked
+func TestConnectToContexts_Cache(t *testing.T) {
+	const contextName = "kupid-test-no-such-context"
+	a := NewApp()
+	a.connected = map[string]time.Time{contextName: time.Now()}
+
+	results := a.ConnectToContexts([]string{contextName})
+	if len(results) != 1 || !results[0].Success || !results[0].Cached {
+		t.Fatalf("expected a cached success, got %+v", results)
+	}
+
+	results = a.RecheckContexts([]string{contextName})
+	if len(results) != 1 || results[0].Success || results[0].Cached {
+		t.Fatalf("expected a fresh failure, got %+v", results)
+	}
+	if _, ok := a.connected[contextName]; ok {
+		t.Error("expected the failed context to be dropped from the cache")
+	}
+
+	a.connected[contextName] = time.Now().Add(-connectionCacheTTL)
+	if results := a.ConnectToContexts([]string{contextName}); results[0].Cached {
+		t.Errorf("expected an expired connection to be checked again, got %+v", results[0])
+	}
+}
+
+// TestGetResourcesForContexts_Status tests that failing contexts are reported in order instead of dropped
+func TestGetResourcesForContexts_Status(t *testing.T) {
+	contexts := []string{"kupid-test-no-such-context-1", "kupid-test-no-such-context-2"}
+	objs, statuses := NewApp().getResourcesForContexts(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, contexts)
+
+	if len(objs) != 0 {
+		t.Errorf("expected no objects, got %d", len(objs))
+	}
+	if len(statuses) != len(contexts) {
+		t.Fatalf("expected a status per context, got %+v", statuses)
+	}
+	for i, status := range statuses {
+		if status.Context != contexts[i] || status.Succeeded || status.Error == "" {
+			t.Errorf("expected a failure of %s, got %+v", contexts[i], status)
+		}
+	}
+}
+
+// TestWithContext tests that the context is set on a copy, leaving the shared informer object untouched
+func TestWithContext(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "web"},
+	}}
+
+	resource := withContext("kind-a", obj)
+	if resource.Object["_context"] != "kind-a" || resource.GetName() != "web" {
+		t.Errorf("expected web in kind-a, got %v", resource.Object)
+	}
+	if _, ok := obj.Object["_context"]; ok {
+		t.Errorf("expected the object to be left untouched, got %v", obj.Object)
+	}
+}
+
+// TestValidateFields tests that saved paths are checked against the field tree, indexing arrays
+func TestValidateFields(t *testing.T) {
+	fields := map[string]*kube.Field{
+		"spec": {Name: "spec", Type: "PodSpec", Children: map[string]*kube.Field{
+			"containers": {Name: "containers", Type: "[]Container", Children: map[string]*kube.Field{
+				"image": {Name: "image", Type: "string"},
+			}},
+		}},
+	}
+	paths := [][]string{
+		{"spec", "containers", "0", "image"},
+		{"spec", "containers", "*", "image"},
+		{"spec", "containers", "0", "removed"},
+		{"status", "phase"},
+	}
+
+	got := validateFields(fields, paths)
+	want := []bool{true, true, false, false}
+	for i, v := range got {
+		if v.Valid != want[i] {
+			t.Errorf("%v: expected valid %v, got %+v", paths[i], want[i], v)
+		}
+		if !v.Valid && v.Error == "" {
+			t.Errorf("%v: expected the reason it is invalid", paths[i])
+		}
+	}
+}
+
+// TestFormatResources tests that cached resources are formatted in the order of their keys
+func TestFormatResources(t *testing.T) {
+	a := &App{}
+	for _, name := range []string{"web", "db"} {
+		a.resourceCache.Store(makeResourceKey("ctx", "default", name), map[string]any{
+			"metadata": map[string]any{"name": name, "namespace": "default"},
+			"spec":     map[string]any{"nodeName": name + "-node"},
+			"_context": "ctx",
+		})
+	}
+
+	got, err := a.FormatResources(
+		[]string{"ctx/default/db", "ctx/default/gone", "ctx/default/web"},
+		[][]string{{"_context"}, {"spec", "nodeName"}},
+		"csv",
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := "NAME,_CONTEXT,NODENAME\ndb,ctx,db-node\nweb,ctx,web-node\n"
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	if _, err := a.FormatResources(nil, nil, "xml"); err == nil {
+		t.Error("expected an unknown format to fail")
+	}
+}
+
+// TestFormatResourcesKeepsTimestamps tests that timestamps are exported as they are, not as the ages the table shows
+func TestFormatResourcesKeepsTimestamps(t *testing.T) {
+	a := &App{}
+	a.resourceCache.Store(makeResourceKey("ctx", "default", "web"), map[string]any{
+		"metadata": map[string]any{"name": "web", "namespace": "default", "creationTimestamp": "2025-01-07T08:00:00Z"},
+	})
+
+	got, err := a.FormatResources([]string{"ctx/default/web"}, [][]string{{"metadata", "creationTimestamp"}}, "csv")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := "NAME,CREATIONTIMESTAMP\nweb,2025-01-07T08:00:00Z\n"
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
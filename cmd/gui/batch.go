@@ -0,0 +1,64 @@
+package main
+
+import "time"
+
+// defaultWatchBatchWindow is how long watch events of a context are batched, see eventBatcher
+const defaultWatchBatchWindow = 100 * time.Millisecond
+
+// eventBatcher coalesces the watch events of a context within a window into one emitted batch,
+// keeping the latest event of each object so a delete following an add or modify wins.
+// It is driven by a single forwarder, a window of 0 emits every event on its own
+type eventBatcher struct {
+	window time.Duration
+	emit   func([]ResourceEventMeta)
+
+	events  []ResourceEventMeta
+	pending map[string]int // key → index in events
+	timer   *time.Timer
+}
+
+func newEventBatcher(window time.Duration, emit func([]ResourceEventMeta)) *eventBatcher {
+	return &eventBatcher{window: window, emit: emit, pending: make(map[string]int)}
+}
+
+// add queues an event, starting the window with the first one
+func (b *eventBatcher) add(event ResourceEventMeta) {
+	if i, ok := b.pending[event.Key]; ok {
+		b.events[i].Type = event.Type
+	} else {
+		b.pending[event.Key] = len(b.events)
+		b.events = append(b.events, event)
+	}
+
+	if b.window <= 0 {
+		b.flush()
+		return
+	}
+	if b.timer == nil {
+		b.timer = time.NewTimer(b.window)
+	}
+}
+
+// due fires when the window of the queued events ends, never while none are queued
+func (b *eventBatcher) due() <-chan time.Time {
+	if b.timer == nil {
+		return nil
+	}
+	return b.timer.C
+}
+
+// flush emits the queued events, if any
+func (b *eventBatcher) flush() {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	if len(b.events) == 0 {
+		return
+	}
+
+	events := b.events
+	b.events = nil
+	clear(b.pending)
+	b.emit(events)
+}
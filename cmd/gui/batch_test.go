@@ -0,0 +1,65 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestEventBatcher_Coalesce tests that events within a window are emitted once with the latest event per object
+func TestEventBatcher_Coalesce(t *testing.T) {
+	var emitted [][]ResourceEventMeta
+	b := newEventBatcher(time.Hour, func(events []ResourceEventMeta) {
+		emitted = append(emitted, events)
+	})
+
+	if b.due() != nil {
+		t.Fatal("expected no window without events")
+	}
+	b.add(ResourceEventMeta{Type: "ADDED", Key: "c/default/web"})
+	b.add(ResourceEventMeta{Type: "ADDED", Key: "c/default/db"})
+	b.add(ResourceEventMeta{Type: "MODIFIED", Key: "c/default/web"})
+	b.add(ResourceEventMeta{Type: "DELETED", Key: "c/default/db"})
+	if len(emitted) != 0 {
+		t.Fatalf("expected the events to wait for the window, got %v", emitted)
+	}
+	if b.due() == nil {
+		t.Fatal("expected a window with queued events")
+	}
+
+	b.flush()
+	want := [][]ResourceEventMeta{{
+		{Type: "MODIFIED", Key: "c/default/web"},
+		{Type: "DELETED", Key: "c/default/db"},
+	}}
+	if !reflect.DeepEqual(emitted, want) {
+		t.Errorf("expected %v, got %v", want, emitted)
+	}
+
+	b.flush()
+	if len(emitted) != 1 {
+		t.Errorf("expected no empty batch, got %v", emitted)
+	}
+	if b.due() != nil {
+		t.Error("expected the window to end with the flush")
+	}
+}
+
+// TestEventBatcher_NoWindow tests that a zero window emits every event on its own
+func TestEventBatcher_NoWindow(t *testing.T) {
+	var emitted [][]ResourceEventMeta
+	b := newEventBatcher(0, func(events []ResourceEventMeta) {
+		emitted = append(emitted, events)
+	})
+
+	b.add(ResourceEventMeta{Type: "ADDED", Key: "c/default/web"})
+	b.add(ResourceEventMeta{Type: "DELETED", Key: "c/default/web"})
+
+	want := [][]ResourceEventMeta{
+		{{Type: "ADDED", Key: "c/default/web"}},
+		{{Type: "DELETED", Key: "c/default/web"}},
+	}
+	if !reflect.DeepEqual(emitted, want) {
+		t.Errorf("expected %v, got %v", want, emitted)
+	}
+}
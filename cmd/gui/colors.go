@@ -0,0 +1,46 @@
+package main
+
+import (
+	"hash/fnv"
+	"slices"
+
+	catppuccin "github.com/catppuccin/go"
+)
+
+// contextPalette are the accents contexts are tinted with
+var contextPalette = []catppuccin.Color{
+	catppuccin.Mocha.Blue(), catppuccin.Mocha.Green(), catppuccin.Mocha.Peach(), catppuccin.Mocha.Mauve(),
+	catppuccin.Mocha.Teal(), catppuccin.Mocha.Pink(), catppuccin.Mocha.Yellow(), catppuccin.Mocha.Sapphire(),
+	catppuccin.Mocha.Red(), catppuccin.Mocha.Lavender(), catppuccin.Mocha.Flamingo(), catppuccin.Mocha.Sky(),
+	catppuccin.Mocha.Maroon(), catppuccin.Mocha.Rosewater(),
+}
+
+// GetContextColors returns a hex color per context to tell merged rows apart.
+// A context keeps its color across restarts and contexts of the same set never share one
+// unless there are more contexts than colors
+func (a *App) GetContextColors(contexts []string) map[string]string {
+	return contextColors(contexts)
+}
+
+func contextColors(contexts []string) map[string]string {
+	names := slices.Clone(contexts)
+	slices.Sort(names)
+	names = slices.Compact(names)
+
+	colors := make(map[string]string, len(names))
+	used := make([]bool, len(contextPalette))
+	for i, name := range names {
+		h := fnv.New32a()
+		h.Write([]byte(name))
+		index := int(h.Sum32() % uint32(len(contextPalette)))
+		// probe for a free color, sharing them again once each is taken
+		if i < len(contextPalette) {
+			for used[index] {
+				index = (index + 1) % len(contextPalette)
+			}
+			used[index] = true
+		}
+		colors[name] = contextPalette[index].Hex
+	}
+	return colors
+}
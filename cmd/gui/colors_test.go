@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestContextColors tests that colors depend on the context set only and are distinct within it
+func TestContextColors(t *testing.T) {
+	colors := contextColors([]string{"prod", "staging", "dev", "prod"})
+	if len(colors) != 3 {
+		t.Fatalf("expected 3 colors, got %v", colors)
+	}
+
+	again := contextColors([]string{"dev", "staging", "prod"})
+	for name, color := range colors {
+		if again[name] != color {
+			t.Errorf("%s: expected %s regardless of the order, got %s", name, color, again[name])
+		}
+	}
+
+	var many []string
+	for i := range len(contextPalette) {
+		many = append(many, fmt.Sprintf("cluster-%d", i))
+	}
+	seen := make(map[string]string)
+	for name, color := range contextColors(many) {
+		if other, ok := seen[color]; ok {
+			t.Errorf("%s and %s share %s", name, other, color)
+		}
+		seen[color] = name
+	}
+}
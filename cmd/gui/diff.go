@@ -0,0 +1,142 @@
+package main
+
+import (
+	"cmp"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/flavono123/kattle/internal/kube"
+)
+
+// DiffStatus compares an object, or one of its fields, across two contexts
+type DiffStatus string
+
+const (
+	DiffEqual   DiffStatus = "equal"
+	DiffDiffers DiffStatus = "differs"
+	DiffOnlyInA DiffStatus = "onlyInA"
+	DiffOnlyInB DiffStatus = "onlyInB"
+)
+
+// FieldDiff compares the values of a picked field, empty where it is missing
+type FieldDiff struct {
+	Path   []string   `json:"path"`
+	A      string     `json:"a"`
+	B      string     `json:"b"`
+	Status DiffStatus `json:"status"`
+}
+
+// ResourceDiff compares an object matched by namespace and name across two contexts,
+// differing when any of its fields does
+type ResourceDiff struct {
+	Namespace string      `json:"namespace"`
+	Name      string      `json:"name"`
+	Status    DiffStatus  `json:"status"`
+	Fields    []FieldDiff `json:"fields"`
+}
+
+// DiffResources compares the picked fields of the objects of gvk in contextA and contextB,
+// sorted by namespace and name
+func (a *App) DiffResources(gvk MultiClusterGVK, contextA, contextB string, fields [][]string) ([]ResourceDiff, error) {
+	schemaGVK := schema.GroupVersionKind{
+		Group:   gvk.Group,
+		Version: gvk.Version,
+		Kind:    gvk.Kind,
+	}
+
+	type result struct {
+		objs []*unstructured.Unstructured
+		err  error
+	}
+	resultB := make(chan result, 1)
+	go func() {
+		objs, err := fetchResources(contextB, schemaGVK)
+		resultB <- result{objs, err}
+	}()
+	objsA, err := fetchResources(contextA, schemaGVK)
+	rb := <-resultB
+	if err != nil {
+		return nil, err
+	}
+	if rb.err != nil {
+		return nil, rb.err
+	}
+
+	return diffObjects(objsA, rb.objs, fields), nil
+}
+
+// diffObjects matches objsA and objsB by namespace and name and compares their fields
+func diffObjects(objsA, objsB []*unstructured.Unstructured, fields [][]string) []ResourceDiff {
+	type pair struct{ a, b *unstructured.Unstructured }
+	type key struct{ namespace, name string }
+	pairs := make(map[key]*pair)
+	for _, obj := range objsA {
+		pairs[key{obj.GetNamespace(), obj.GetName()}] = &pair{a: obj}
+	}
+	for _, obj := range objsB {
+		k := key{obj.GetNamespace(), obj.GetName()}
+		if p, ok := pairs[k]; ok {
+			p.b = obj
+		} else {
+			pairs[k] = &pair{b: obj}
+		}
+	}
+
+	nodes := make([]*kube.Node, len(fields))
+	for i, path := range fields {
+		nodes[i] = kube.NewPathNode(path)
+	}
+
+	diffs := make([]ResourceDiff, 0, len(pairs))
+	for k, p := range pairs {
+		diff := ResourceDiff{Namespace: k.namespace, Name: k.name, Status: DiffEqual, Fields: []FieldDiff{}}
+		switch {
+		case p.b == nil:
+			diff.Status = DiffOnlyInA
+		case p.a == nil:
+			diff.Status = DiffOnlyInB
+		}
+		for i, node := range nodes {
+			field := diffField(node, p.a, p.b)
+			field.Path = fields[i]
+			if diff.Status == DiffEqual && field.Status != DiffEqual {
+				diff.Status = DiffDiffers
+			}
+			diff.Fields = append(diff.Fields, field)
+		}
+		diffs = append(diffs, diff)
+	}
+
+	slices.SortFunc(diffs, func(x, y ResourceDiff) int {
+		return cmp.Or(cmp.Compare(x.Namespace, y.Namespace), cmp.Compare(x.Name, y.Name))
+	})
+	return diffs
+}
+
+// diffField compares the value of node in a and b, either of which may be nil
+func diffField(node *kube.Node, a, b *unstructured.Unstructured) FieldDiff {
+	var (
+		field    FieldDiff
+		okA, okB bool
+	)
+	if node != nil && a != nil {
+		field.A, okA = kube.LookupValStr(node, a)
+	}
+	if node != nil && b != nil {
+		field.B, okB = kube.LookupValStr(node, b)
+	}
+
+	switch {
+	case okA && !okB:
+		field.Status = DiffOnlyInA
+	case !okA && okB:
+		field.Status = DiffOnlyInB
+	case field.A != field.B:
+		field.Status = DiffDiffers
+	default:
+		field.Status = DiffEqual
+	}
+	return field
+}
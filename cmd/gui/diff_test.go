@@ -0,0 +1,61 @@
+package main
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newPod(namespace, name string, spec map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"namespace": namespace, "name": name},
+		"spec":     spec,
+	}}
+}
+
+// TestDiffObjects tests that objects are matched by namespace and name and compared per field
+func TestDiffObjects(t *testing.T) {
+	objsA := []*unstructured.Unstructured{
+		newPod("default", "web", map[string]interface{}{"nodeName": "a-1", "priority": int64(1)}),
+		newPod("default", "same", map[string]interface{}{"nodeName": "n"}),
+		newPod("kube-system", "dns", map[string]interface{}{"nodeName": "a-2"}),
+	}
+	objsB := []*unstructured.Unstructured{
+		newPod("default", "web", map[string]interface{}{"nodeName": "b-1"}),
+		newPod("default", "same", map[string]interface{}{"nodeName": "n"}),
+		newPod("other", "dns", map[string]interface{}{"nodeName": "b-2"}),
+	}
+	fields := [][]string{{"spec", "nodeName"}, {"spec", "priority"}}
+
+	diffs := diffObjects(objsA, objsB, fields)
+
+	want := []struct {
+		namespace, name string
+		status          DiffStatus
+		fields          []DiffStatus
+	}{
+		{"default", "same", DiffEqual, []DiffStatus{DiffEqual, DiffEqual}},
+		{"default", "web", DiffDiffers, []DiffStatus{DiffDiffers, DiffOnlyInA}},
+		{"kube-system", "dns", DiffOnlyInA, []DiffStatus{DiffOnlyInA, DiffEqual}},
+		{"other", "dns", DiffOnlyInB, []DiffStatus{DiffOnlyInB, DiffEqual}},
+	}
+	if len(diffs) != len(want) {
+		t.Fatalf("expected %d diffs, got %d: %+v", len(want), len(diffs), diffs)
+	}
+	for i, w := range want {
+		d := diffs[i]
+		if d.Namespace != w.namespace || d.Name != w.name || d.Status != w.status {
+			t.Errorf("diff %d: expected %s/%s %s, got %s/%s %s", i, w.namespace, w.name, w.status, d.Namespace, d.Name, d.Status)
+		}
+		for j, status := range w.fields {
+			if d.Fields[j].Status != status {
+				t.Errorf("%s/%s %v: expected %s, got %s", d.Namespace, d.Name, d.Fields[j].Path, status, d.Fields[j].Status)
+			}
+		}
+	}
+
+	web := diffs[1].Fields[0]
+	if web.A != "a-1" || web.B != "b-1" {
+		t.Errorf("expected a-1 and b-1, got %q and %q", web.A, web.B)
+	}
+}
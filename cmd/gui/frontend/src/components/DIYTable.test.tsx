@@ -55,6 +55,7 @@ vi.mock('@tanstack/react-virtual', () => ({
 // Mock Wails SaveFile function
 vi.mock('../../wailsjs/go/main/App', () => ({
   SaveFile: vi.fn(),
+  FormatResources: vi.fn(() => Promise.resolve('')),
 }));
 
 const defaultGVK = {
@@ -625,35 +625,16 @@ export const DIYTable = forwardRef<DIYTableHandle, DIYTableProps>(({
     }
   }, [focusedRowIndex, rowVirtualizer]);
 
-  // Prepare export data (headers and rows for CSV)
+  // Prepare export data: the keys of the visible rows and the selected fields,
+  // formatted by the backend (the name column comes first there)
   // Excludes preview columns - only exports selected fields
   const exportData = useMemo(() => {
-    const allHeaders = table.getHeaderGroups()[0]?.headers || [];
-    // Filter out preview columns (id starts with _preview.)
-    const exportHeaders = allHeaders.filter(h => !h.column.id.startsWith('_preview.'));
-
-    const headers = exportHeaders.map((header) => {
-      return typeof header.column.columnDef.header === 'string'
-        ? header.column.columnDef.header
-        : String(header.column.columnDef.header);
-    });
-
-    const exportRows = rows.map((row) => {
-      const allCells = row.getVisibleCells();
-      // Filter out preview columns
-      const exportCells = allCells.filter(cell => !cell.column.id.startsWith('_preview.'));
-      return exportCells.map((cell) => {
-        const value = cell.getValue();
-        // Handle objects/arrays - convert to JSON string for CSV
-        if (typeof value === 'object' && value !== null) {
-          return JSON.stringify(value);
-        }
-        return value;
-      });
-    });
-
-    return { headers, rows: exportRows };
-  }, [table, rows, selectedFields, connectedContexts]);
+    const keys = rows.map((row) => row.id);
+    const fields = connectedContexts.length === 1
+      ? selectedFields
+      : [['_context'], ...selectedFields];
+    return { keys, fields };
+  }, [rows, selectedFields, connectedContexts]);
 
   return (
     <div className="flex flex-col h-full">
@@ -664,8 +645,8 @@ export const DIYTable = forwardRef<DIYTableHandle, DIYTableProps>(({
         onGlobalFilterChange={setGlobalFilter}
         filteredRowCount={rows.length}
         totalRowCount={data.length}
-        headers={exportData.headers}
-        rows={exportData.rows}
+        exportKeys={exportData.keys}
+        exportFields={exportData.fields}
         resourceKind={selectedGVK?.kind || 'resources'}
         onSearchFocusChange={handleSearchFocusChange}
         onBeforeExport={onPreviewClear}
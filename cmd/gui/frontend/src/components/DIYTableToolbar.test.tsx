@@ -14,6 +14,7 @@ import { DIYTableToolbar } from './DIYTableToolbar';
 // Mock Wails SaveFile function
 vi.mock('../../wailsjs/go/main/App', () => ({
   SaveFile: vi.fn(),
+  FormatResources: vi.fn(() => Promise.resolve('')),
 }));
 
 const defaultProps = {
@@ -21,8 +22,8 @@ const defaultProps = {
   onGlobalFilterChange: vi.fn(),
   filteredRowCount: 10,
   totalRowCount: 100,
-  headers: ['name', 'namespace'],
-  rows: [['pod-1', 'default'], ['pod-2', 'kube-system']],
+  exportKeys: ['ctx/default/pod-1', 'ctx/kube-system/pod-2'],
+  exportFields: [['metadata', 'namespace']],
   resourceKind: 'Pod',
 };
 
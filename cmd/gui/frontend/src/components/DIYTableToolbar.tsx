@@ -9,18 +9,18 @@ import {
 import { Download, Clipboard, FileDown, AlertCircle } from 'lucide-react';
 import { useState, forwardRef, useRef, useImperativeHandle, useCallback } from 'react';
 import { Kbd } from './ui/kbd';
-import { convertToCSV, copyToClipboard, downloadCSV } from '@/lib/csv-export';
+import { copyToClipboard } from '@/lib/csv-export';
 import { pluralize } from '@/lib/utils';
-import { SaveFile } from '../../wailsjs/go/main/App';
+import { FormatResources, SaveFile } from '../../wailsjs/go/main/App';
 
 interface DIYTableToolbarProps {
   globalFilter: string;
   onGlobalFilterChange: (value: string) => void;
   filteredRowCount: number;
   totalRowCount: number;
-  // Export data
-  headers: string[];
-  rows: any[][];
+  // Export data, formatted by the backend
+  exportKeys: string[];      // resource keys of the rows, in order
+  exportFields: string[][];  // field paths of the columns after the name
   resourceKind?: string; // For filename generation
   onSearchFocusChange?: (focused: boolean) => void;
   onBeforeExport?: () => void; // Called before export (e.g., to clear preview)
@@ -40,8 +40,8 @@ export const DIYTableToolbar = forwardRef<DIYTableToolbarHandle, DIYTableToolbar
   onGlobalFilterChange,
   filteredRowCount,
   totalRowCount,
-  headers,
-  rows,
+  exportKeys,
+  exportFields,
   resourceKind = 'resources',
   onSearchFocusChange,
   onBeforeExport,
@@ -55,7 +55,7 @@ export const DIYTableToolbar = forwardRef<DIYTableToolbarHandle, DIYTableToolbar
     onBeforeExport?.();
     try {
       setExporting(true);
-      const csvContent = convertToCSV(headers, rows);
+      const csvContent = await FormatResources(exportKeys, exportFields, 'csv');
       await copyToClipboard(csvContent);
       setExportStatus('copied');
       setTimeout(() => setExportStatus('idle'), 1000);
@@ -66,21 +66,22 @@ export const DIYTableToolbar = forwardRef<DIYTableToolbarHandle, DIYTableToolbar
     } finally {
       setExporting(false);
     }
-  }, [headers, rows, onBeforeExport]);
+  }, [exportKeys, exportFields, onBeforeExport]);
 
-  const handleExportToFile = useCallback(async () => {
+  // format is an export format of the backend and the file extension, e.g. csv or md
+  const handleExportToFile = useCallback(async (format: 'csv' | 'md' = 'csv') => {
     onBeforeExport?.();
     try {
       setExporting(true);
-      const csvContent = convertToCSV(headers, rows);
+      const content = await FormatResources(exportKeys, exportFields, format);
       const now = new Date();
       const timestamp = now.toISOString()
         .replace(/T/, '_')
         .replace(/:/g, '-')
         .replace(/\.\d+Z$/, ''); // YYYY-MM-DD_HH-MM-SS
-      const filename = `${resourceKind}-${timestamp}.csv`;
+      const filename = `${resourceKind}-${timestamp}.${format}`;
 
-      const savedPath = await downloadCSV(csvContent, filename, SaveFile);
+      const savedPath = await SaveFile(filename, content);
 
       if (savedPath) {
         // File was saved successfully
@@ -99,7 +100,7 @@ export const DIYTableToolbar = forwardRef<DIYTableToolbarHandle, DIYTableToolbar
     } finally {
       setExporting(false);
     }
-  }, [headers, rows, resourceKind, onBeforeExport]);
+  }, [exportKeys, exportFields, resourceKind, onBeforeExport]);
 
   // Expose methods via ref
   useImperativeHandle(ref, () => ({
@@ -110,7 +111,7 @@ export const DIYTableToolbar = forwardRef<DIYTableToolbarHandle, DIYTableToolbar
       return document.activeElement === searchInputRef.current;
     },
     exportToClipboard: handleExportToClipboard,
-    exportToFile: handleExportToFile,
+    exportToFile: () => handleExportToFile(),
   }), [handleExportToClipboard, handleExportToFile]);
 
   return (
@@ -147,7 +148,7 @@ export const DIYTableToolbar = forwardRef<DIYTableToolbarHandle, DIYTableToolbar
             <Button
               variant="ghost"
               size="sm"
-              disabled={exporting || rows.length === 0}
+              disabled={exporting || exportKeys.length === 0}
               className="gap-2 h-6 py-0 text-sm"
             >
               {exportStatus === 'idle' && <Download className="h-4 w-4" />}
@@ -177,13 +178,17 @@ export const DIYTableToolbar = forwardRef<DIYTableToolbarHandle, DIYTableToolbar
                 <Kbd>⌘</Kbd><Kbd>⇧</Kbd><Kbd>C</Kbd>
               </span>
             </DropdownMenuItem>
-            <DropdownMenuItem onClick={handleExportToFile}>
+            <DropdownMenuItem onClick={() => handleExportToFile()}>
               <FileDown className="mr-2 h-4 w-4" />
               <span className="flex-1">Download as File</span>
               <span className="ml-4 flex items-center gap-0.5">
                 <Kbd>⌘</Kbd><Kbd>⇧</Kbd><Kbd>S</Kbd>
               </span>
             </DropdownMenuItem>
+            <DropdownMenuItem onClick={() => handleExportToFile('md')}>
+              <FileDown className="mr-2 h-4 w-4" />
+              <span className="flex-1">Download as Markdown</span>
+            </DropdownMenuItem>
           </DropdownMenuContent>
         </DropdownMenu>
       </div>
@@ -9,6 +9,8 @@ import * as App from '../../wailsjs/go/main/App';
 vi.mock('../../wailsjs/go/main/App', () => ({
   GetNodeTree: vi.fn(),
   GetDefaultSelectedPaths: vi.fn(() => Promise.resolve(null)),
+  StartTreeWatch: vi.fn(() => Promise.resolve()),
+  StopTreeWatch: vi.fn(() => Promise.resolve()),
 }));
 
 // Mock the Wails runtime (for EventsOn used by watch)
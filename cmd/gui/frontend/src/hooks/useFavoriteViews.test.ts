@@ -35,6 +35,7 @@ const createFavorite = (id: string, name: string, fields: string[][]): main.Favo
     name,
     gvk: mockGVK,
     fields,
+    tags: [],
     createdAt: new Date().toISOString(),
     updatedAt: new Date().toISOString(),
   });
@@ -151,7 +152,8 @@ describe('useFavoriteViews - Order-Sensitive Matching', () => {
       [
         ['metadata', 'name'],
         ['metadata', 'namespace'],
-      ]
+      ],
+      []
     );
 
     // After saving, the new favorite should be active
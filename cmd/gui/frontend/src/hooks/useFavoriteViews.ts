@@ -121,7 +121,7 @@ export function useFavoriteViews({
 
   // Save current selection as favorite
   const saveFavorite = useCallback(
-    async (name: string) => {
+    async (name: string, tags: string[] = []) => {
       if (!currentGVK) {
         throw new Error("No GVK selected");
       }
@@ -134,7 +134,8 @@ export function useFavoriteViews({
         currentGVK.group,
         currentGVK.version,
         currentGVK.kind,
-        selectedPathsArray
+        selectedPathsArray,
+        tags
       );
 
       setAllFavorites((prev) => [...prev, view]);
@@ -171,10 +172,19 @@ export function useFavoriteViews({
     [activeFavoriteId]
   );
 
-  const renameFavorite = useCallback(async (id: string, newName: string) => {
-    const updated = await RenameFavoriteView(id, newName);
-    setAllFavorites((prev) => prev.map((f) => (f.id === id ? updated : f)));
-  }, []);
+  // Tags are kept unless given
+  const renameFavorite = useCallback(
+    async (id: string, newName: string, tags?: string[]) => {
+      const current = allFavorites.find((f) => f.id === id);
+      const updated = await RenameFavoriteView(
+        id,
+        newName,
+        tags ?? current?.tags ?? []
+      );
+      setAllFavorites((prev) => prev.map((f) => (f.id === id ? updated : f)));
+    },
+    [allFavorites]
+  );
 
   return {
     allFavorites,
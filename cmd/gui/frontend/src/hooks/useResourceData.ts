@@ -132,19 +132,23 @@ export function useResourceData(
     watchOperationRef.current = startOperation;
 
     // Subscribe to lightweight events (Pull Model)
-    const unsubscribe = EventsOn('resource:update', (event: ResourceEventMeta) => {
+    // The backend batches them per context, keeping the latest event of each resource
+    const unsubscribe = EventsOn('resource:update', (events: ResourceEventMeta[]) => {
       if (watchGen !== watchGenRef.current) return;
 
       // Mark that we've received at least one event (for timeout decision)
       hasReceivedAnyEvent.current = true;
 
-      if (event.type === 'DELETED') {
-        // Collect deletes separately
-        pendingDeletes.current.add(event.key);
-        pendingKeys.current.delete(event.key); // No need to fetch deleted resources
-      } else {
-        // ADDED or MODIFIED - collect key for batch fetch
-        pendingKeys.current.add(event.key);
+      for (const event of events) {
+        if (event.type === 'DELETED') {
+          // Collect deletes separately
+          pendingDeletes.current.add(event.key);
+          pendingKeys.current.delete(event.key); // No need to fetch deleted resources
+        } else {
+          // ADDED or MODIFIED - collect key for batch fetch, a re-created resource is no longer deleted
+          pendingKeys.current.add(event.key);
+          pendingDeletes.current.delete(event.key);
+        }
       }
     });
 
@@ -1,9 +1,8 @@
 import { useReducer, useEffect, useMemo, useCallback, useRef } from 'react';
 import { EventsOn } from '../../wailsjs/runtime/runtime';
-import { GetNodeTree, GetDefaultSelectedPaths } from '../../wailsjs/go/main/App';
+import { GetNodeTree, GetDefaultSelectedPaths, StartTreeWatch, StopTreeWatch } from '../../wailsjs/go/main/App';
 import { main } from '../../wailsjs/go/models';
 import { useFuzzySearch } from './useFuzzySearch';
-import type { ResourceEventMeta } from '../lib/resource-utils';
 
 // Use null character as path delimiter to avoid conflicts with field names containing '/'
 // (e.g., Kubernetes annotations like "karpenter.sh/node-hash-version")
@@ -400,8 +399,6 @@ export interface UseTreeOptions {
   onReady?: () => void;
   /** Enable real-time tree updates via watch events (default: false) */
   watch?: boolean;
-  /** Debounce interval for tree refresh on watch events (default: 100ms) */
-  watchDebounceMs?: number;
   /** Skip fetching and applying default selected paths (used when favorite will be applied) */
   skipDefaultPaths?: boolean;
 }
@@ -412,7 +409,6 @@ export function useTree({
   onFieldsSelected,
   onReady,
   watch = false,
-  watchDebounceMs = 100,
   skipDefaultPaths = false,
 }: UseTreeOptions) {
   const [state, dispatch] = useReducer(treeReducer, initialState);
@@ -527,42 +523,28 @@ export function useTree({
   }, [skipDefaultPaths, loading, nodeTree.length, selectedGVK, connectedContexts]);
 
   // Watch subscription for real-time tree updates
+  // The backend rebuilds the tree once watch events settle, adding new keys/indices
+  // and removing nodes whose field only existed in deleted resources
   useEffect(() => {
     if (!watch || !selectedGVK || connectedContexts.length === 0) {
       return;
     }
 
-    let debounceTimer: ReturnType<typeof setTimeout> | null = null;
-    let pendingRefresh = false;
-
-    const handleEvent = (_event: ResourceEventMeta) => {
-      // Refresh tree on any event type:
-      // - ADDED/MODIFIED: may add new keys/indices to tree
-      // - DELETED: may remove nodes if field only existed in deleted resource
-      pendingRefresh = true;
-
-      // Debounce: wait for events to settle before refreshing
-      if (debounceTimer) {
-        clearTimeout(debounceTimer);
-      }
-      debounceTimer = setTimeout(() => {
-        if (pendingRefresh) {
-          console.log('useTree: refreshing tree due to watch events');
-          fetchNodeTree(true);  // silent refresh - no loading indicator
-          pendingRefresh = false;
-        }
-      }, watchDebounceMs);
-    };
-
-    const unsubscribe = EventsOn('resource:update', handleEvent);
+    let active = true;
+    const unsubscribe = EventsOn('tree:update', (nodes: TreeNode[]) => {
+      if (!active) return;
+      dispatch({ type: 'SET_NODE_TREE', nodeTree: nodes || [] });
+    });
+    StartTreeWatch(selectedGVK, connectedContexts).catch((error) => {
+      console.error('Failed to start tree watch:', error);
+    });
 
     return () => {
+      active = false;
       unsubscribe();
-      if (debounceTimer) {
-        clearTimeout(debounceTimer);
-      }
+      StopTreeWatch().catch(() => {});
     };
-  }, [watch, selectedGVK, connectedContexts, watchDebounceMs, fetchNodeTree]);
+  }, [watch, selectedGVK, connectedContexts]);
 
   // Notify parent when loading completes
   useEffect(() => {
@@ -6,6 +6,16 @@ export function ConnectToContexts(arg1:Array<string>):Promise<Array<main.Context
 
 export function DeleteFavoriteView(arg1:string):Promise<void>;
 
+export function DiffResources(arg1:main.MultiClusterGVK,arg2:string,arg3:string,arg4:Array<any>):Promise<Array<main.ResourceDiff>>;
+
+export function DuplicateFavoriteView(arg1:string,arg2:string):Promise<main.FavoriteViewResponse>;
+
+export function ExportFavorites():Promise<string>;
+
+export function FormatResources(arg1:Array<string>,arg2:Array<Array<string>>,arg3:string):Promise<string>;
+
+export function GetContextColors(arg1:Array<string>):Promise<Record<string, string>>;
+
 export function GetCurrentContext():Promise<string>;
 
 export function GetDefaultSelectedPaths(arg1:main.MultiClusterGVK,arg2:Array<string>):Promise<Array<any>>;
@@ -14,26 +24,46 @@ export function GetFavoriteViewsForGVK(arg1:string,arg2:string,arg3:string):Prom
 
 export function GetGVKs(arg1:Array<string>):Promise<Array<main.MultiClusterGVK>>;
 
+export function GetKubectlCommand(arg1:main.MultiClusterGVK,arg2:Array<any>):Promise<string>;
+
 export function GetNodeTree(arg1:main.MultiClusterGVK,arg2:Array<string>):Promise<Array<main.TreeNode>>;
 
 export function GetResources(arg1:main.MultiClusterGVK,arg2:Array<string>):Promise<Array<Record<string, any>>>;
 
 export function GetResourcesByKeys(arg1:Array<string>):Promise<Array<Record<string, any>>>;
 
+export function GetResourcesWithStatus(arg1:main.MultiClusterGVK,arg2:Array<string>):Promise<main.ResourcesResult>;
+
 export function Greet(arg1:string):Promise<string>;
 
+export function ImportFavorites(arg1:string):Promise<Array<main.FavoriteViewResponse>>;
+
 export function ListContexts():Promise<Array<string>>;
 
 export function ListFavoriteViews():Promise<Array<main.FavoriteViewResponse>>;
 
+export function ListFavoriteViewsByTag(arg1:string):Promise<Array<main.FavoriteViewResponse>>;
+
+export function RecheckContexts(arg1:Array<string>):Promise<Array<main.ContextConnectionResult>>;
+
 export function RefreshContexts():Promise<Array<string>>;
 
-export function RenameFavoriteView(arg1:string,arg2:string):Promise<main.FavoriteViewResponse>;
+export function RenameFavoriteView(arg1:string,arg2:string,arg3:Array<string>):Promise<main.FavoriteViewResponse>;
 
-export function SaveFavoriteView(arg1:string,arg2:string,arg3:string,arg4:string,arg5:Array<any>):Promise<main.FavoriteViewResponse>;
+export function ReorderFavoriteViews(arg1:Array<string>):Promise<void>;
+
+export function SaveFavoriteView(arg1:string,arg2:string,arg3:string,arg4:string,arg5:Array<any>,arg6:Array<string>):Promise<main.FavoriteViewResponse>;
 
 export function SaveFile(arg1:string,arg2:string):Promise<string>;
 
+export function SearchFavoriteViews(arg1:string):Promise<Array<main.FavoriteViewResponse>>;
+
+export function StartTreeWatch(arg1:main.MultiClusterGVK,arg2:Array<string>):Promise<void>;
+
 export function StartWatch(arg1:main.MultiClusterGVK,arg2:Array<string>):Promise<void>;
 
+export function StopTreeWatch():Promise<void>;
+
 export function StopWatch():Promise<void>;
+
+export function ValidateFavoriteView(arg1:string,arg2:Array<string>):Promise<Array<main.FieldValidation>>;
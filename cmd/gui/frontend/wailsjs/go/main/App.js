@@ -10,6 +10,26 @@ export function DeleteFavoriteView(arg1) {
   return window['go']['main']['App']['DeleteFavoriteView'](arg1);
 }
 
+export function DiffResources(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['DiffResources'](arg1, arg2, arg3, arg4);
+}
+
+export function DuplicateFavoriteView(arg1, arg2) {
+  return window['go']['main']['App']['DuplicateFavoriteView'](arg1, arg2);
+}
+
+export function ExportFavorites() {
+  return window['go']['main']['App']['ExportFavorites']();
+}
+
+export function FormatResources(arg1, arg2, arg3) {
+  return window['go']['main']['App']['FormatResources'](arg1, arg2, arg3);
+}
+
+export function GetContextColors(arg1) {
+  return window['go']['main']['App']['GetContextColors'](arg1);
+}
+
 export function GetCurrentContext() {
   return window['go']['main']['App']['GetCurrentContext']();
 }
@@ -26,6 +46,10 @@ export function GetGVKs(arg1) {
   return window['go']['main']['App']['GetGVKs'](arg1);
 }
 
+export function GetKubectlCommand(arg1, arg2) {
+  return window['go']['main']['App']['GetKubectlCommand'](arg1, arg2);
+}
+
 export function GetNodeTree(arg1, arg2) {
   return window['go']['main']['App']['GetNodeTree'](arg1, arg2);
 }
@@ -38,10 +62,18 @@ export function GetResourcesByKeys(arg1) {
   return window['go']['main']['App']['GetResourcesByKeys'](arg1);
 }
 
+export function GetResourcesWithStatus(arg1, arg2) {
+  return window['go']['main']['App']['GetResourcesWithStatus'](arg1, arg2);
+}
+
 export function Greet(arg1) {
   return window['go']['main']['App']['Greet'](arg1);
 }
 
+export function ImportFavorites(arg1) {
+  return window['go']['main']['App']['ImportFavorites'](arg1);
+}
+
 export function ListContexts() {
   return window['go']['main']['App']['ListContexts']();
 }
@@ -50,26 +82,54 @@ export function ListFavoriteViews() {
   return window['go']['main']['App']['ListFavoriteViews']();
 }
 
+export function ListFavoriteViewsByTag(arg1) {
+  return window['go']['main']['App']['ListFavoriteViewsByTag'](arg1);
+}
+
+export function RecheckContexts(arg1) {
+  return window['go']['main']['App']['RecheckContexts'](arg1);
+}
+
 export function RefreshContexts() {
   return window['go']['main']['App']['RefreshContexts']();
 }
 
-export function RenameFavoriteView(arg1, arg2) {
-  return window['go']['main']['App']['RenameFavoriteView'](arg1, arg2);
+export function RenameFavoriteView(arg1, arg2, arg3) {
+  return window['go']['main']['App']['RenameFavoriteView'](arg1, arg2, arg3);
+}
+
+export function ReorderFavoriteViews(arg1) {
+  return window['go']['main']['App']['ReorderFavoriteViews'](arg1);
 }
 
-export function SaveFavoriteView(arg1, arg2, arg3, arg4, arg5) {
-  return window['go']['main']['App']['SaveFavoriteView'](arg1, arg2, arg3, arg4, arg5);
+export function SaveFavoriteView(arg1, arg2, arg3, arg4, arg5, arg6) {
+  return window['go']['main']['App']['SaveFavoriteView'](arg1, arg2, arg3, arg4, arg5, arg6);
 }
 
 export function SaveFile(arg1, arg2) {
   return window['go']['main']['App']['SaveFile'](arg1, arg2);
 }
 
+export function SearchFavoriteViews(arg1) {
+  return window['go']['main']['App']['SearchFavoriteViews'](arg1);
+}
+
+export function StartTreeWatch(arg1, arg2) {
+  return window['go']['main']['App']['StartTreeWatch'](arg1, arg2);
+}
+
 export function StartWatch(arg1, arg2) {
   return window['go']['main']['App']['StartWatch'](arg1, arg2);
 }
 
+export function StopTreeWatch() {
+  return window['go']['main']['App']['StopTreeWatch']();
+}
+
 export function StopWatch() {
   return window['go']['main']['App']['StopWatch']();
 }
+
+export function ValidateFavoriteView(arg1, arg2) {
+  return window['go']['main']['App']['ValidateFavoriteView'](arg1, arg2);
+}
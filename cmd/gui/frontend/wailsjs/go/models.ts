@@ -4,6 +4,8 @@ export namespace main {
 	    context: string;
 	    success: boolean;
 	    error?: string;
+	    cached: boolean;
+	    checkedAt: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new ContextConnectionResult(source);
@@ -14,6 +16,26 @@ export namespace main {
 	        this.context = source["context"];
 	        this.success = source["success"];
 	        this.error = source["error"];
+	        this.cached = source["cached"];
+	        this.checkedAt = source["checkedAt"];
+	    }
+	}
+	export class ContextResourcesStatus {
+	    context: string;
+	    succeeded: boolean;
+	    error?: string;
+	    count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ContextResourcesStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.context = source["context"];
+	        this.succeeded = source["succeeded"];
+	        this.error = source["error"];
+	        this.count = source["count"];
 	    }
 	}
 	export class FavoriteViewGVK {
@@ -37,6 +59,7 @@ export namespace main {
 	    name: string;
 	    gvk: FavoriteViewGVK;
 	    fields: string[][];
+	    tags: string[];
 	    createdAt: string;
 	    updatedAt: string;
 	
@@ -50,6 +73,7 @@ export namespace main {
 	        this.name = source["name"];
 	        this.gvk = this.convertValues(source["gvk"], FavoriteViewGVK);
 	        this.fields = source["fields"];
+	        this.tags = source["tags"];
 	        this.createdAt = source["createdAt"];
 	        this.updatedAt = source["updatedAt"];
 	    }
@@ -72,6 +96,40 @@ export namespace main {
 		    return a;
 		}
 	}
+	export class FieldDiff {
+	    path: string[];
+	    a: string;
+	    b: string;
+	    status: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new FieldDiff(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.a = source["a"];
+	        this.b = source["b"];
+	        this.status = source["status"];
+	    }
+	}
+	export class FieldValidation {
+	    path: string[];
+	    valid: boolean;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new FieldValidation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.valid = source["valid"];
+	        this.error = source["error"];
+	    }
+	}
 	export class MultiClusterGVK {
 	    group: string;
 	    version: string;
@@ -94,6 +152,74 @@ export namespace main {
 	        this.allCount = source["allCount"];
 	    }
 	}
+	export class ResourceDiff {
+	    namespace: string;
+	    name: string;
+	    status: string;
+	    fields: FieldDiff[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ResourceDiff(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.namespace = source["namespace"];
+	        this.name = source["name"];
+	        this.status = source["status"];
+	        this.fields = this.convertValues(source["fields"], FieldDiff);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ResourcesResult {
+	    resources: any[];
+	    contexts: ContextResourcesStatus[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ResourcesResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.resources = source["resources"];
+	        this.contexts = this.convertValues(source["contexts"], ContextResourcesStatus);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class TreeNode {
 	    name: string;
 	    type: string;
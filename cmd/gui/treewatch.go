@@ -0,0 +1,128 @@
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/flavono123/kattle/internal/kube"
+)
+
+// treeWatchDebounce is how long the tree watch lets a burst of watch events settle before rebuilding
+const treeWatchDebounce = 200 * time.Millisecond
+
+// treeWatch rebuilds the node tree of the watched kind while watch events arrive,
+// so new array indices and map keys show up in the tree
+type treeWatch struct {
+	fields map[string]*kube.Field
+	nodes  map[string]*kube.Node
+
+	objects func() []*unstructured.Unstructured
+	emit    func([]*TreeNode)
+
+	stale chan struct{} // buffered, set by markStale
+	stop  chan struct{}
+	done  chan struct{}
+}
+
+func newTreeWatch(fields map[string]*kube.Field, objs []*unstructured.Unstructured,
+	objects func() []*unstructured.Unstructured, emit func([]*TreeNode)) *treeWatch {
+	return &treeWatch{
+		fields:  fields,
+		nodes:   kube.CreateNodeTree(fields, objs, []string{}),
+		objects: objects,
+		emit:    emit,
+		stale:   make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// markStale schedules a rebuild without blocking, events of a pending rebuild coalesce into it
+func (tw *treeWatch) markStale() {
+	select {
+	case tw.stale <- struct{}{}:
+	default:
+	}
+}
+
+// run rebuilds and emits the tree once per debounce window with events until stopped
+func (tw *treeWatch) run(debounce time.Duration) {
+	defer close(tw.done)
+	for {
+		select {
+		case <-tw.stale:
+		case <-tw.stop:
+			return
+		}
+
+		select {
+		case <-time.After(debounce):
+		case <-tw.stop:
+			return
+		}
+		// the rebuild below covers the events of the window
+		select {
+		case <-tw.stale:
+		default:
+		}
+
+		tw.nodes = kube.UpdateNodeTree(tw.nodes, tw.fields, tw.objects(), []string{})
+		tw.emit(convertNodeTree(tw.nodes))
+	}
+}
+
+// StartTreeWatch emits the node tree of a GVK as "tree:update" whenever watch events change it,
+// following the objects of the active watch, see StartWatch. StopWatch stops it as well
+func (a *App) StartTreeWatch(gvk MultiClusterGVK, contexts []string) error {
+	a.StopTreeWatch()
+
+	fields, objs, err := a.nodeTreeSources(gvk, contexts)
+	if err != nil {
+		return err
+	}
+	tw := newTreeWatch(fields, objs, a.getWatchedResources, func(nodes []*TreeNode) {
+		runtime.EventsEmit(a.ctx, "tree:update", nodes)
+	})
+
+	a.treeMu.Lock()
+	defer a.treeMu.Unlock()
+	if a.tree != nil {
+		// started concurrently, keep the latest call
+		close(a.tree.stop)
+	}
+	a.tree = tw
+	go tw.run(treeWatchDebounce)
+
+	log.Printf("Started watching the tree of %s/%s/%s", gvk.Group, gvk.Version, gvk.Kind)
+	return nil
+}
+
+// StopTreeWatch stops the tree watch, if any
+func (a *App) StopTreeWatch() {
+	a.treeMu.Lock()
+	tw := a.tree
+	a.tree = nil
+	a.treeMu.Unlock()
+	if tw == nil {
+		return
+	}
+
+	close(tw.stop)
+	select {
+	case <-tw.done:
+	case <-time.After(2 * time.Second):
+		log.Printf("Warning: tree watch cleanup timed out")
+	}
+}
+
+// markTreeStale schedules a rebuild of the watched tree, if any
+func (a *App) markTreeStale() {
+	a.treeMu.Lock()
+	defer a.treeMu.Unlock()
+	if a.tree != nil {
+		a.tree.markStale()
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/flavono123/kattle/internal/kube"
+)
+
+// TestTreeWatch_Debounce tests that a burst of watch events emits a single rebuilt tree
+func TestTreeWatch_Debounce(t *testing.T) {
+	fields := map[string]*kube.Field{
+		"spec": {Name: "spec", Type: "PodSpec", Children: map[string]*kube.Field{
+			"containers": {Name: "containers", Type: "[]Container", Prefix: []string{"spec"}, Children: map[string]*kube.Field{
+				"image": {Name: "image", Type: "string", Prefix: []string{"spec", "containers"}},
+			}},
+		}},
+	}
+	podWith := func(images ...string) *unstructured.Unstructured {
+		containers := []interface{}{}
+		for _, image := range images {
+			containers = append(containers, map[string]interface{}{"image": image})
+		}
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{"containers": containers},
+		}}
+	}
+
+	var listed atomic.Int32
+	objects := func() []*unstructured.Unstructured {
+		listed.Add(1)
+		return []*unstructured.Unstructured{podWith("nginx", "sidecar")}
+	}
+	emitted := make(chan []*TreeNode, 4)
+	tw := newTreeWatch(fields, []*unstructured.Unstructured{podWith("nginx")}, objects, func(nodes []*TreeNode) {
+		emitted <- nodes
+	})
+	go tw.run(20 * time.Millisecond)
+
+	for range 10 {
+		tw.markStale()
+	}
+
+	select {
+	case nodes := <-emitted:
+		containers := nodes[0].Children[0]
+		names := []string{}
+		for _, child := range containers.Children {
+			names = append(names, child.Name)
+		}
+		if !slices.Contains(names, "1") {
+			t.Errorf("expected the grown index 1 in %v", names)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the tree to be emitted")
+	}
+
+	close(tw.stop)
+	<-tw.done
+	if n := listed.Load(); n != 1 {
+		t.Errorf("expected a single rebuild, got %d", n)
+	}
+	if len(emitted) != 0 {
+		t.Errorf("expected a single emitted tree, got %d more", len(emitted))
+	}
+}
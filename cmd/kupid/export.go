@@ -0,0 +1,123 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/flavono123/kattle/internal/config"
+	"github.com/flavono123/kattle/internal/export"
+	"github.com/flavono123/kattle/internal/kube"
+)
+
+// runExport prints the fields of every object of a kind to w without the TUI, e.g.
+// kupid export --kind Pod --fields metadata.name,status.phase --format csv
+func runExport(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	kind := fs.String("kind", "", "kind to export, as for the positional kind argument (required)")
+	fieldList := fs.String("fields", "", "comma separated field paths, e.g. metadata.name,spec.containers[*].image (required)")
+	format := fs.String("format", "csv", "output format: "+strings.Join(export.Formats(), ", "))
+	contextName := fs.String("context", "", "kubeconfig context, the current one when empty")
+	namespace := fs.String("namespace", "", "namespace to export, all namespaces when empty")
+	timeout := fs.Duration("timeout", 30*time.Second, "how long to wait for the objects to be listed")
+	missing := fs.String("missing", kube.MissingValue(), `value of fields an object does not set, e.g. "" for empty cells`)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	kube.SetMissingValue(*missing)
+
+	formatter, err := export.FormatterFor(*format)
+	if err != nil {
+		return err
+	}
+	if *kind == "" || *fieldList == "" {
+		return errors.New("--kind and --fields are required")
+	}
+	if err := checkContext(*contextName); err != nil {
+		return err
+	}
+
+	gvk, err := kube.ResolveKindForContext(*contextName, *kind)
+	if err != nil {
+		return err
+	}
+	fields, err := kube.CreateFieldTreeForContext(*contextName, gvk)
+	if err != nil {
+		return fmt.Errorf("failed to create field tree: %w", err)
+	}
+	var nodes []*kube.Node
+	for _, s := range strings.Split(*fieldList, ",") {
+		path, err := kube.ParseFieldPath(strings.TrimSpace(s))
+		if err != nil {
+			return err
+		}
+		if err := kube.CheckFieldPath(fields, path); err != nil {
+			return fmt.Errorf("%s: %w", gvk.Kind, err)
+		}
+		nodes = append(nodes, kube.NewPathNode(path))
+	}
+
+	gvr, err := kube.GetGVRForContext(*contextName, gvk)
+	if err != nil {
+		return err
+	}
+	ns := *namespace
+	if namespaced, err := kube.IsNamespacedForContext(*contextName, gvk); err == nil && !namespaced {
+		ns = ""
+	}
+	controller := kube.NewResourceControllerForContext(*contextName, gvr, kube.WithNamespace(ns))
+	defer controller.Close()
+	objs, err := syncObjects(controller, *timeout)
+	if err != nil {
+		return err
+	}
+
+	return formatter.Format(nodes, objs, w)
+}
+
+// syncObjects informs until the objects are listed, failing on the first list error
+// since the informer would retry it forever
+func syncObjects(controller *kube.ResourceController, timeout time.Duration) ([]*unstructured.Unstructured, error) {
+	type result struct {
+		stop chan struct{}
+		err  error
+	}
+	synced := make(chan result, 1)
+	go func() {
+		stop, err := controller.Inform()
+		synced <- result{stop, err}
+	}()
+
+	select {
+	case r := <-synced:
+		if r.err != nil {
+			return nil, r.err
+		}
+		close(r.stop)
+		return controller.Objects(), nil
+	case err := <-controller.ErrorEmitted():
+		return nil, fmt.Errorf("failed to list %s: %w", controller.GVR().Resource, err)
+	case <-time.After(timeout):
+		return nil, fmt.Errorf("timed out listing %s after %s", controller.GVR().Resource, timeout)
+	}
+}
+
+func exportMain(args []string) {
+	// the configured missing value is the default of --missing
+	if cfg, err := config.Get(); err == nil {
+		applyMissingValue(cfg)
+	}
+	if err := runExport(args, os.Stdout); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		fmt.Fprintf(os.Stderr, "%s export: %v\n", os.Args[0], err)
+		os.Exit(1)
+	}
+}
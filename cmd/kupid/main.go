@@ -1,22 +1,174 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"io"
 	"log"
 	"os"
+	"slices"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
+	"github.com/flavono123/kattle/internal/config"
+	"github.com/flavono123/kattle/internal/kube"
+	"github.com/flavono123/kattle/internal/logging"
+	"github.com/flavono123/kattle/internal/store"
 	"github.com/flavono123/kattle/internal/ui"
+	"github.com/flavono123/kattle/internal/ui/keybind"
+	"github.com/flavono123/kattle/internal/ui/theme"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		exportMain(os.Args[2:])
+		return
+	}
+
+	allowMutations := flag.Bool("allow-mutations", false, "enable actions that send (dry-run) write requests to the cluster")
+	contextName := flag.String("context", "", "kubeconfig context to browse, the current one when empty")
+	namespace := flag.String("namespace", "", "namespace to inform first, all namespaces when empty")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [kind]\n       %s export --kind <kind> --fields <paths> [--format csv|json|yaml]\n", os.Args[0], os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// fail before the alt screen takes over, not in a broken TUI
+	if err := checkContext(*contextName); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", os.Args[0], err)
+		os.Exit(1)
+	}
+	gvk, err := resolveKindArg(*contextName, flag.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", os.Args[0], err)
+		os.Exit(1)
+	}
+
+	// log to a file before anything logs, the terminal belongs to the TUI
+	cfg, cfgErr := config.Get()
+	logCloser := setupLogging(cfg, cfgErr)
+	applyTheme(cfg)
+	applyMissingValue(cfg)
+	keybind.Apply(cfg.Keys)
+	model := ui.NewModel(ui.Options{
+		AllowMutations: *allowMutations,
+		ConfirmQuit:    cfg.ConfirmQuit,
+		Favorites:      loadFavorites(),
+		Recents:        loadRecents(),
+		ListLimit:      cfg.ListLimit,
+		GVK:            gvk,
+		Context:        *contextName,
+		Namespace:      *namespace,
+	})
+
 	program := tea.NewProgram(
-		ui.NewModel(),
+		model,
 		tea.WithAltScreen(),
 	)
 
-	if _, err := program.Run(); err != nil {
+	_, err = program.Run()
+	logCloser.Close()
+	log.SetOutput(os.Stderr)
+	if err != nil {
 		log.Fatalf("failed to run program: %v", err)
-		os.Exit(1)
 	}
 }
+
+// resolveKindArg resolves the kind to open first, e.g. pod or apps/v1/Deployment, empty when not given
+func resolveKindArg(contextName, arg string) (schema.GroupVersionKind, error) {
+	if arg == "" {
+		return schema.GroupVersionKind{}, nil
+	}
+	return kube.ResolveKindForContext(contextName, arg)
+}
+
+// checkContext reports a context missing in the kubeconfig with the available ones, empty is the current one
+func checkContext(contextName string) error {
+	if contextName == "" {
+		return nil
+	}
+	contexts, err := kube.ListContexts()
+	if err != nil {
+		return err
+	}
+	if slices.Contains(contexts, contextName) {
+		return nil
+	}
+	slices.Sort(contexts)
+	return fmt.Errorf("unknown context %q, available: %s", contextName, strings.Join(contexts, ", "))
+}
+
+// applyMissingValue sets the configured token for fields without value, if any
+func applyMissingValue(cfg *config.Config) {
+	if cfg.MissingValue != nil {
+		kube.SetMissingValue(*cfg.MissingValue)
+	}
+}
+
+// themeEnv picks the flavour over the configured one, e.g. KUPID_THEME=latte on a light terminal
+const themeEnv = "KUPID_THEME"
+
+// applyTheme must run before the model builds its styles
+func applyTheme(cfg *config.Config) {
+	if err := theme.Apply(cfg.Theme); err != nil {
+		log.Printf("[WARN] using default theme: %v", err)
+	}
+	switch flavour := os.Getenv(themeEnv); {
+	case flavour != "":
+		if err := theme.SetFlavour(flavour); err != nil {
+			log.Printf("[WARN] ignoring %s: %v", themeEnv, err)
+		}
+	case cfg.Theme.Flavour == "" && !lipgloss.HasDarkBackground():
+		// guessed only without an explicit flavour, dark (mocha) when the terminal doesn't answer
+		if err := theme.SetFlavour("latte"); err != nil {
+			log.Printf("[WARN] using default theme: %v", err)
+		}
+	}
+}
+
+// loadFavorites opens the favorite views shared with the GUI, nil when unavailable
+func loadFavorites() *store.Store {
+	s, err := store.NewStore()
+	if err != nil {
+		log.Printf("[WARN] favorite views disabled: %v", err)
+		return nil
+	}
+	if err := s.Load(); err != nil {
+		log.Printf("[WARN] favorite views disabled: %v", err)
+		return nil
+	}
+	return s
+}
+
+// loadRecents opens the recently picked kinds, nil when unavailable
+func loadRecents() *store.Recents {
+	r, err := store.NewRecents()
+	if err != nil {
+		log.Printf("[WARN] recent kinds disabled: %v", err)
+		return nil
+	}
+	if err := r.Load(); err != nil {
+		log.Printf("[WARN] recent kinds disabled: %v", err)
+		return nil
+	}
+	return r
+}
+
+// setupLogging must run before anything logs, falling back to warnings on stderr
+// when the log file is unavailable
+func setupLogging(cfg *config.Config, cfgErr error) io.Closer {
+	closer, err := logging.Setup(cfg.Log)
+	if err != nil {
+		logging.SetupStderr(logging.Warn)
+		log.Printf("[WARN] logging to stderr: %v", err)
+		closer = io.NopCloser(nil)
+	}
+	if cfgErr != nil {
+		log.Printf("[WARN] using default config: %v", cfgErr)
+	}
+	return closer
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const configFileName = "config.json"
+
+// Config is the user configuration read from config.json under Dir()
+type Config struct {
+	Log   LogConfig   `json:"log"`
+	Theme ThemeConfig `json:"theme"`
+	// ConfirmQuit asks before quitting with picked fields not saved as a favorite
+	ConfirmQuit bool `json:"confirmQuit"`
+	// Keys rebinds keys of the TUI panes
+	Keys KeysConfig `json:"keys"`
+	// ListLimit caps the objects of a kind shown in the TUI, 0 (default) for unlimited
+	ListLimit int64 `json:"listLimit"`
+	// MissingValue replaces the "-" rendered for fields without value, e.g. "" or "<none>"
+	MissingValue *string `json:"missingValue"`
+	// WatchBatchMs batches the watch events of the GUI for this many milliseconds, 100 when unset, 0 emits each event
+	WatchBatchMs *int `json:"watchBatchMs"`
+}
+
+// KeysConfig maps a pane (global, schema, result, table or kbar) to
+// binding names (e.g. "tabView") and the keys replacing their defaults
+type KeysConfig map[string]map[string][]string
+
+// LogConfig configures the log file of the TUI
+type LogConfig struct {
+	// Path of the log file, defaults to kupid.log under Dir()
+	Path string `json:"path"`
+	// Level is the minimum level written: debug, info, warn or error
+	Level string `json:"level"`
+	// MaxSizeMB is the size a log file grows to before it is rotated
+	MaxSizeMB int `json:"maxSizeMB"`
+	// MaxBackups is the number of rotated files kept
+	MaxBackups int `json:"maxBackups"`
+}
+
+// ThemeConfig customizes the TUI colors
+type ThemeConfig struct {
+	// Flavour is a catppuccin preset: latte, frappe, macchiato or mocha (default)
+	Flavour string `json:"flavour"`
+	// Colors overrides palette roles (e.g. "blue", "surface0") with #rrggbb colors
+	Colors map[string]string `json:"colors"`
+	// Gradient overrides the width-limit progress bar endpoints
+	Gradient GradientConfig `json:"gradient"`
+}
+
+// GradientConfig holds the endpoints of a color gradient
+type GradientConfig struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+var (
+	loadOnce  sync.Once
+	loaded    *Config
+	loadedErr error
+)
+
+// Dir returns the directory holding config and data files, creating it if needed
+func Dir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user config dir: %w", err)
+	}
+
+	dir := filepath.Join(configDir, AppID)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create config dir %s: %w", dir, err)
+	}
+	return dir, nil
+}
+
+// Get loads the config once and returns it (singleton)
+// A missing or unreadable file yields the defaults along with the error
+func Get() (*Config, error) {
+	loadOnce.Do(func() {
+		loaded, loadedErr = load()
+	})
+	return loaded, loadedErr
+}
+
+func load() (*Config, error) {
+	dir, err := Dir()
+	if err != nil {
+		return Default(), err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, configFileName))
+	if os.IsNotExist(err) {
+		return Default(), nil
+	}
+	if err != nil {
+		return Default(), fmt.Errorf("failed to read config: %w", err)
+	}
+
+	return Parse(data)
+}
+
+// Parse decodes data over the defaults
+func Parse(data []byte) (*Config, error) {
+	cfg := Default()
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return Default(), fmt.Errorf("failed to parse config: %w", err)
+	}
+	return cfg, nil
+}
+
+// Default returns the config used when no file exists
+func Default() *Config {
+	return &Config{
+		ConfirmQuit: true,
+		Log: LogConfig{
+			Level:      "warn",
+			MaxSizeMB:  10,
+			MaxBackups: 3,
+		},
+	}
+}
@@ -0,0 +1,152 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/flavono123/kattle/internal/kube"
+)
+
+// Table is a view to export: the picked nodes as columns over the objects as rows
+type Table struct {
+	Nodes []*kube.Node
+	Objs  []*unstructured.Unstructured
+}
+
+// WriteYAML writes each object as a map of the picked field paths to their values
+func WriteYAML(w io.Writer, t Table) error {
+	data, err := yaml.Marshal(entries(t))
+	if err != nil {
+		return fmt.Errorf("failed to marshal yaml: %w", err)
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// WriteJSON writes the entries of WriteYAML as an indented JSON array
+func WriteJSON(w io.Writer, t Table) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(entries(t)); err != nil {
+		return fmt.Errorf("failed to marshal json: %w", err)
+	}
+	return nil
+}
+
+// entries maps the picked field paths to their values per object, with the name and namespace
+func entries(t Table) []map[string]string {
+	entries := make([]map[string]string, 0, len(t.Objs))
+	for _, obj := range t.Objs {
+		entry := map[string]string{"metadata.name": obj.GetName()}
+		if obj.GetNamespace() != "" {
+			entry["metadata.namespace"] = obj.GetNamespace()
+		}
+		for _, node := range t.Nodes {
+			entry[fieldKey(node)] = kube.ValStr(node, obj)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// WriteCSV mirrors the table: a NAME column, then a column per picked node
+func WriteCSV(w io.Writer, t Table) error {
+	cw := csv.NewWriter(w)
+
+	header := []string{"NAME"}
+	for _, node := range t.Nodes {
+		header = append(header, node.HeaderName())
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, obj := range t.Objs {
+		record := []string{obj.GetName()}
+		for _, node := range t.Nodes {
+			record = append(record, kube.ValStr(node, obj))
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteMarkdown writes the columns of WriteCSV as a GitHub-flavored markdown table
+func WriteMarkdown(w io.Writer, t Table) error {
+	header := []string{"NAME"}
+	for _, node := range t.Nodes {
+		header = append(header, node.HeaderName())
+	}
+	separator := make([]string, len(header))
+	for i := range separator {
+		separator[i] = "---"
+	}
+	rows := [][]string{header, separator}
+
+	for _, obj := range t.Objs {
+		row := []string{obj.GetName()}
+		for _, node := range t.Nodes {
+			row = append(row, kube.ValStr(node, obj))
+		}
+		rows = append(rows, row)
+	}
+
+	for i, row := range rows {
+		if i != 1 { // the separator is not a value
+			for j, cell := range row {
+				row[j] = markdownCell(cell)
+			}
+		}
+		if _, err := fmt.Fprintf(w, "| %s |\n", strings.Join(row, " | ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// markdownCell keeps a value in its cell, escaping pipes and collapsing newlines to spaces
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", " ")
+	s = strings.ReplaceAll(s, "\n", " ")
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// WriteFile creates kupid-export-<timestamp>.<ext> in dir and fills it with write
+func WriteFile(dir, ext string, write func(io.Writer) error) (string, error) {
+	path := filepath.Join(dir, fmt.Sprintf("kupid-export-%s.%s", time.Now().Format("20060102-150405"), ext))
+	f, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to create export file: %w", err)
+	}
+
+	if err := write(f); err != nil {
+		f.Close()
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("failed to close %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// fieldKey is the node's JSONPath without the leading dot, e.g. spec.containers[0].image,
+// or the expression of a JSONPath column
+func fieldKey(node *kube.Node) string {
+	if expr, ok := node.Expression(); ok {
+		return expr
+	}
+	return strings.TrimPrefix(kube.FieldJSONPath(node.NodeFullPath()), ".")
+}
@@ -0,0 +1,132 @@
+package export
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/flavono123/kattle/internal/kube"
+)
+
+func newTestTable() Table {
+	objs := []*unstructured.Unstructured{
+		{Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "web", "namespace": "default"},
+			"status":   map[string]interface{}{"phase": "Running"},
+		}},
+		{Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "node-1"},
+		}},
+	}
+	fields := map[string]*kube.Field{
+		"status": {
+			Name: "status",
+			Type: "PodStatus",
+			Children: map[string]*kube.Field{
+				"phase": {Name: "phase", Type: "string", Prefix: []string{"status"}},
+			},
+		},
+	}
+	phase := kube.CreateNodeTree(fields, objs, nil)["status"].Children()["phase"]
+	return Table{Nodes: []*kube.Node{phase}, Objs: objs}
+}
+
+func TestWriteYAML(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteYAML(&buf, newTestTable()))
+
+	expected := `- metadata.name: web
+  metadata.namespace: default
+  status.phase: Running
+- metadata.name: node-1
+  status.phase: '-'
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteJSON(&buf, newTestTable()))
+
+	expected := `[
+  {
+    "metadata.name": "web",
+    "metadata.namespace": "default",
+    "status.phase": "Running"
+  },
+  {
+    "metadata.name": "node-1",
+    "status.phase": "-"
+  }
+]
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestWriteCSV(t *testing.T) {
+	table := newTestTable()
+	table.Objs = append(table.Objs, &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "quoted"},
+		"status":   map[string]interface{}{"phase": `Failed, "OOM"`},
+	}})
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, table))
+
+	expected := `NAME,PHASE
+web,Running
+node-1,-
+quoted,"Failed, ""OOM"""
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestWriteCSVMissingValue(t *testing.T) {
+	kube.SetMissingValue("")
+	t.Cleanup(func() { kube.SetMissingValue(kube.DefaultMissingValue) })
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, newTestTable()))
+
+	assert.Equal(t, "NAME,PHASE\nweb,Running\nnode-1,\n", buf.String())
+}
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+
+	path, err := WriteFile(dir, "yaml", func(w io.Writer) error {
+		return WriteYAML(w, newTestTable())
+	})
+	require.NoError(t, err)
+	assert.Equal(t, dir, filepath.Dir(path))
+	assert.Regexp(t, `^kupid-export-\d{8}-\d{6}\.yaml$`, filepath.Base(path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "status.phase: Running")
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	table := newTestTable()
+	table.Objs = append(table.Objs, &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "escaped"},
+		"status":   map[string]interface{}{"phase": "a|b\nc"},
+	}})
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteMarkdown(&buf, table))
+
+	expected := `| NAME | PHASE |
+| --- | --- |
+| web | Running |
+| node-1 | - |
+| escaped | a\|b c |
+`
+	assert.Equal(t, expected, buf.String())
+}
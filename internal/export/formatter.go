@@ -0,0 +1,68 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/flavono123/kattle/internal/kube"
+)
+
+// Formatter writes the picked nodes as columns over the objects as rows in an export format
+type Formatter interface {
+	Format(nodes []*kube.Node, objs []*unstructured.Unstructured, w io.Writer) error
+}
+
+// CSV formats as WriteCSV
+type CSV struct{}
+
+func (CSV) Format(nodes []*kube.Node, objs []*unstructured.Unstructured, w io.Writer) error {
+	return WriteCSV(w, Table{Nodes: nodes, Objs: objs})
+}
+
+// JSON formats as WriteJSON
+type JSON struct{}
+
+func (JSON) Format(nodes []*kube.Node, objs []*unstructured.Unstructured, w io.Writer) error {
+	return WriteJSON(w, Table{Nodes: nodes, Objs: objs})
+}
+
+// YAML formats as WriteYAML
+type YAML struct{}
+
+func (YAML) Format(nodes []*kube.Node, objs []*unstructured.Unstructured, w io.Writer) error {
+	return WriteYAML(w, Table{Nodes: nodes, Objs: objs})
+}
+
+// Markdown formats as WriteMarkdown
+type Markdown struct{}
+
+func (Markdown) Format(nodes []*kube.Node, objs []*unstructured.Unstructured, w io.Writer) error {
+	return WriteMarkdown(w, Table{Nodes: nodes, Objs: objs})
+}
+
+// formatters by format name, which is also the file extension
+var formatters = map[string]Formatter{
+	"csv":  CSV{},
+	"json": JSON{},
+	"md":   Markdown{},
+	"yaml": YAML{},
+}
+
+// Formats returns the format names, sorted
+func Formats() []string {
+	return slices.Sorted(maps.Keys(formatters))
+}
+
+// FormatterFor returns the formatter of a format name, e.g. csv
+func FormatterFor(format string) (Formatter, error) {
+	f, ok := formatters[format]
+	if !ok {
+		return nil, fmt.Errorf("unknown format %q, use %s", format, strings.Join(Formats(), ", "))
+	}
+	return f, nil
+}
@@ -0,0 +1,40 @@
+package export
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatters(t *testing.T) {
+	table := newTestTable()
+	for _, tc := range []struct {
+		format string
+		write  func(io.Writer, Table) error
+	}{
+		{"csv", WriteCSV},
+		{"json", WriteJSON},
+		{"md", WriteMarkdown},
+		{"yaml", WriteYAML},
+	} {
+		t.Run(tc.format, func(t *testing.T) {
+			f, err := FormatterFor(tc.format)
+			require.NoError(t, err)
+
+			var got, want bytes.Buffer
+			require.NoError(t, f.Format(table.Nodes, table.Objs, &got))
+			require.NoError(t, tc.write(&want, table))
+			assert.Equal(t, want.String(), got.String())
+		})
+	}
+}
+
+func TestFormatterFor(t *testing.T) {
+	assert.Equal(t, []string{"csv", "json", "md", "yaml"}, Formats())
+
+	_, err := FormatterFor("xml")
+	assert.EqualError(t, err, `unknown format "xml", use csv, json, md, yaml`)
+}
@@ -24,6 +24,10 @@ var (
 	dynamicClientsMu sync.RWMutex
 	dynamicClients   = make(map[string]dynamic.Interface)
 
+	// server versions cached per context, see ServerVersionForContext
+	serverVersionsMu sync.RWMutex
+	serverVersions   = make(map[string]string)
+
 	// Cache kubeconfig (singleton)
 	kubeConfigOnce sync.Once
 	rawConfig      *api.Config
@@ -197,6 +201,30 @@ func DiscoveryClientForContext(contextName string) (discovery.DiscoveryInterface
 	return cs.Discovery(), nil
 }
 
+// ServerVersionForContext returns the git version of the API server, e.g. v1.31.2, fetched once per context
+// If contextName is empty, uses the current context
+func ServerVersionForContext(contextName string) (string, error) {
+	serverVersionsMu.RLock()
+	version, ok := serverVersions[contextName]
+	serverVersionsMu.RUnlock()
+	if ok {
+		return version, nil
+	}
+
+	discoveryClient, err := DiscoveryClientForContext(contextName)
+	if err != nil {
+		return "", err
+	}
+	info, err := discoveryClient.ServerVersion()
+	if err != nil {
+		return "", fmt.Errorf("failed to get server version: %w", err)
+	}
+	serverVersionsMu.Lock()
+	serverVersions[contextName] = info.GitVersion
+	serverVersionsMu.Unlock()
+	return info.GitVersion, nil
+}
+
 // TryTshKubeLogin attempts to run tsh kube login for a context
 // Returns true if login was attempted (regardless of success), false if context doesn't use tsh
 func TryTshKubeLogin(contextName string) (bool, error) {
@@ -247,6 +275,12 @@ func InvalidateClientCache(contextName string) {
 	dynamicClientsMu.Lock()
 	delete(dynamicClients, contextName)
 	dynamicClientsMu.Unlock()
+
+	serverVersionsMu.Lock()
+	delete(serverVersions, contextName)
+	serverVersionsMu.Unlock()
+
+	InvalidateDocumentCache(contextName)
 }
 
 // InvalidateKubeconfigCache clears the cached kubeconfig
@@ -265,4 +299,12 @@ func InvalidateKubeconfigCache() {
 	dynamicClientsMu.Lock()
 	dynamicClients = make(map[string]dynamic.Interface)
 	dynamicClientsMu.Unlock()
+
+	serverVersionsMu.Lock()
+	serverVersions = make(map[string]string)
+	serverVersionsMu.Unlock()
+
+	// schemas may differ once contexts point elsewhere
+	invalidateDocuments()
+	InvalidateFieldTreeCache()
 }
@@ -0,0 +1,76 @@
+package kube
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+func writeKubeconfig(t *testing.T, path, currentContext string) {
+	t.Helper()
+	data := `apiVersion: v1
+kind: Config
+clusters:
+- name: c
+  cluster: {server: "https://127.0.0.1:6443"}
+users:
+- name: u
+contexts:
+- name: kind-a
+  context: {cluster: c, user: u}
+- name: kind-b
+  context: {cluster: c, user: u}
+current-context: ` + currentContext + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(data), 0600))
+}
+
+func TestInvalidateKubeconfigCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	t.Setenv("KUBECONFIG", path)
+	writeKubeconfig(t, path, "kind-a")
+	InvalidateKubeconfigCache()
+	t.Cleanup(InvalidateKubeconfigCache)
+
+	current, err := GetCurrentContext()
+	require.NoError(t, err)
+	assert.Equal(t, "kind-a", current)
+
+	writeKubeconfig(t, path, "kind-b")
+	current, err = GetCurrentContext()
+	require.NoError(t, err)
+	assert.Equal(t, "kind-a", current, "the kubeconfig is cached until invalidated")
+
+	InvalidateKubeconfigCache()
+	current, err = GetCurrentContext()
+	require.NoError(t, err)
+	assert.Equal(t, "kind-b", current)
+}
+
+func TestInvalidateClientCache(t *testing.T) {
+	t.Cleanup(InvalidateKubeconfigCache)
+	clientSets["kind-a"] = &kubernetes.Clientset{}
+	clientSets["kind-b"] = &kubernetes.Clientset{}
+	dynamicClients["kind-a"] = &dynamic.DynamicClient{}
+	serverVersions["kind-a"] = "v1.31.2"
+
+	InvalidateClientCache("kind-a")
+
+	assert.NotContains(t, clientSets, "kind-a")
+	assert.NotContains(t, serverVersions, "kind-a")
+	assert.NotContains(t, dynamicClients, "kind-a")
+	assert.Contains(t, clientSets, "kind-b")
+}
+
+func TestServerVersionForContext(t *testing.T) {
+	t.Cleanup(InvalidateKubeconfigCache)
+	serverVersions["cached"] = "v1.31.2"
+
+	version, err := ServerVersionForContext("cached")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.31.2", version)
+}
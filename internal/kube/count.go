@@ -0,0 +1,41 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// CountForContext counts the objects of a GVK in all namespaces without listing them all
+// If contextName is empty, uses the current context
+func CountForContext(contextName string, gvk schema.GroupVersionKind) (int64, error) {
+	gvr, err := GetGVRForContext(contextName, gvk)
+	if err != nil {
+		return 0, err
+	}
+	client, err := DynamicClientForContext(contextName)
+	if err != nil {
+		return 0, err
+	}
+	return countObjects(client, gvr)
+}
+
+// countObjects lists a single item and adds the remaining count the server reports with it
+func countObjects(client dynamic.Interface, gvr schema.GroupVersionResource) (int64, error) {
+	list, err := client.Resource(gvr).List(context.Background(), metav1.ListOptions{Limit: 1})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list %s: %w", gvr.String(), err)
+	}
+
+	count := int64(len(list.Items))
+	if remaining := list.GetRemainingItemCount(); remaining != nil {
+		return count + *remaining, nil
+	}
+	if list.GetContinue() != "" {
+		return 0, fmt.Errorf("the remaining count of %s is not reported", gvr.String())
+	}
+	return count, nil
+}
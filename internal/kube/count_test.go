@@ -0,0 +1,78 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestCountObjects(t *testing.T) {
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	newClient := func() *dynamicfake.FakeDynamicClient {
+		return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+			runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{gvr: "PodList"},
+		)
+	}
+	listReactor := func(list *unstructured.UnstructuredList) k8stesting.ReactionFunc {
+		return func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, list, nil
+		}
+	}
+	page := func(items int) *unstructured.UnstructuredList {
+		list := &unstructured.UnstructuredList{}
+		for i := 0; i < items; i++ {
+			list.Items = append(list.Items, unstructured.Unstructured{})
+		}
+		return list
+	}
+
+	t.Run("adds the remaining item count", func(t *testing.T) {
+		client := newClient()
+		list := page(1)
+		remaining := int64(41)
+		list.SetRemainingItemCount(&remaining)
+		list.SetContinue("next")
+		client.PrependReactor("list", "pods", listReactor(list))
+
+		count, err := countObjects(client, gvr)
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), count)
+	})
+
+	t.Run("a single page is the whole count", func(t *testing.T) {
+		client := newClient()
+		client.PrependReactor("list", "pods", listReactor(page(0)))
+
+		count, err := countObjects(client, gvr)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+
+	t.Run("fails without the remaining count", func(t *testing.T) {
+		client := newClient()
+		list := page(1)
+		list.SetContinue("next")
+		client.PrependReactor("list", "pods", listReactor(list))
+
+		_, err := countObjects(client, gvr)
+		assert.Error(t, err)
+	})
+
+	t.Run("fails when the list is forbidden", func(t *testing.T) {
+		client := newClient()
+		client.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewForbidden(gvr.GroupResource(), "", nil)
+		})
+
+		_, err := countObjects(client, gvr)
+		assert.Error(t, err)
+	})
+}
@@ -1,6 +1,10 @@
 package kube
 
-import "strings"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 // field is a struct parsed from schema doc
 // array, object types are indented to have children with two levels difference
@@ -13,6 +17,7 @@ type Field struct {
 	Required bool
 	// optional
 	Enum     []string
+	Default  interface{}
 	Children map[string]*Field
 }
 
@@ -31,3 +36,20 @@ func (f *Field) IsObject() bool {
 func (f *Field) IsPrimitive() bool {
 	return !f.IsArray() && !f.IsMap() && !f.IsObject()
 }
+
+// DefaultStr formats the schema default of the field, only when it is a scalar
+// since struct defaults would overflow the schema pane
+func (f *Field) DefaultStr() (string, bool) {
+	switch v := f.Default.(type) {
+	case string:
+		if v == "" {
+			return `""`, true
+		}
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool, int, int64:
+		return fmt.Sprint(v), true
+	}
+	return "", false
+}
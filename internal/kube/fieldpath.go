@@ -0,0 +1,73 @@
+package kube
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseFieldPath splits a field path the way FieldJSONPath writes it, e.g. spec.containers[0].image
+// or metadata.labels.app\.kubernetes\.io/name. The leading dot is optional.
+func ParseFieldPath(s string) ([]string, error) {
+	var (
+		path    []string
+		segment strings.Builder
+	)
+	s = strings.TrimPrefix(s, ".")
+	for i := 0; i < len(s); i++ {
+		switch ch := s[i]; {
+		case ch == '\\' && i+1 < len(s) && s[i+1] == '.':
+			segment.WriteByte('.')
+			i++
+		case ch == '.':
+			if segment.Len() == 0 {
+				return nil, fmt.Errorf("empty segment in field path %q", s)
+			}
+			path = append(path, segment.String())
+			segment.Reset()
+		case ch == '[':
+			if segment.Len() > 0 {
+				path = append(path, segment.String())
+				segment.Reset()
+			}
+			end := strings.IndexByte(s[i:], ']')
+			if end < 2 {
+				return nil, fmt.Errorf("unclosed or empty index in field path %q", s)
+			}
+			path = append(path, s[i+1:i+end])
+			i += end
+			if i+1 < len(s) && s[i+1] == '.' {
+				i++ // the dot after an index
+			}
+		default:
+			segment.WriteByte(ch)
+		}
+	}
+	if segment.Len() > 0 {
+		path = append(path, segment.String())
+	}
+	if len(path) == 0 {
+		return nil, fmt.Errorf("empty field path")
+	}
+	return path, nil
+}
+
+// CheckFieldPath reports the first segment of path the schema has no field for,
+// array indices (or *) and map keys are free
+func CheckFieldPath(fields map[string]*Field, path []string) error {
+	current := fields
+	for i := 0; i < len(path); i++ {
+		field, ok := current[path[i]]
+		if !ok {
+			return fmt.Errorf("no field %q in %q", path[i], FieldJSONPath(path[:i+1]))
+		}
+		if (field.IsArray() || field.IsMap()) && i+1 < len(path) {
+			i++
+			if _, err := strconv.Atoi(path[i]); field.IsArray() && err != nil && path[i] != "*" {
+				return fmt.Errorf("%q is not an index of %q", path[i], FieldJSONPath(path[:i]))
+			}
+		}
+		current = field.Children
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package kube
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFieldPath(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"metadata.name", []string{"metadata", "name"}},
+		{".spec.containers[0].image", []string{"spec", "containers", "0", "image"}},
+		{"status.conditions[*].type", []string{"status", "conditions", "*", "type"}},
+		{`metadata.labels.app\.kubernetes\.io/name`, []string{"metadata", "labels", "app.kubernetes.io/name"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			path, err := ParseFieldPath(tt.in)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, path)
+			assert.Equal(t, "."+strings.TrimPrefix(tt.in, "."), FieldJSONPath(path)) // round trip
+		})
+	}
+
+	for _, in := range []string{"", "spec..replicas", "spec.containers[0", "spec.containers[]"} {
+		t.Run("invalid "+in, func(t *testing.T) {
+			_, err := ParseFieldPath(in)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCheckFieldPath(t *testing.T) {
+	fields := map[string]*Field{
+		"metadata": {Name: "metadata", Type: "ObjectMeta", Children: map[string]*Field{
+			"labels": {Name: "labels", Type: "map[string]string"},
+		}},
+		"spec": {Name: "spec", Type: "PodSpec", Children: map[string]*Field{
+			"containers": {Name: "containers", Type: "[]Container", Children: map[string]*Field{
+				"image": {Name: "image", Type: "string"},
+			}},
+		}},
+	}
+
+	assert.NoError(t, CheckFieldPath(fields, []string{"spec", "containers", "0", "image"}))
+	assert.NoError(t, CheckFieldPath(fields, []string{"spec", "containers", "*", "image"}))
+	assert.NoError(t, CheckFieldPath(fields, []string{"spec", "containers"}))
+	assert.NoError(t, CheckFieldPath(fields, []string{"metadata", "labels", "app"}))
+	assert.EqualError(t, CheckFieldPath(fields, []string{"spec", "replicas"}), `no field "replicas" in ".spec.replicas"`)
+	assert.EqualError(t, CheckFieldPath(fields, []string{"spec", "containers", "image"}), `"image" is not an index of ".spec.containers"`)
+	assert.Error(t, CheckFieldPath(fields, []string{"metadata", "labels", "app", "x"}))
+}
@@ -0,0 +1,61 @@
+package kube
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// ValueFormatter renders a raw field value for display, see FormatValStr,
+// reporting false to fall back to the default rendering
+type ValueFormatter func(val interface{}) (string, bool)
+
+var (
+	formattersMu sync.RWMutex
+	// formatters keyed by the last segment of a field path, so values are only reformatted where opted in
+	formatters = map[string]ValueFormatter{
+		"creationTimestamp":  FormatAge,
+		"deletionTimestamp":  FormatAge,
+		"lastTransitionTime": FormatAge,
+	}
+)
+
+// now is replaced in tests
+var now = time.Now
+
+// RegisterFormatter displays values of fields whose path ends with suffix through f, nil to unregister
+func RegisterFormatter(suffix string, f ValueFormatter) {
+	formattersMu.Lock()
+	defer formattersMu.Unlock()
+
+	if f == nil {
+		delete(formatters, suffix)
+		return
+	}
+	formatters[suffix] = f
+}
+
+func formatterFor(path []string) (ValueFormatter, bool) {
+	if len(path) == 0 {
+		return nil, false
+	}
+
+	formattersMu.RLock()
+	defer formattersMu.RUnlock()
+	f, ok := formatters[path[len(path)-1]]
+	return f, ok
+}
+
+// FormatAge renders an RFC3339 timestamp as its age, e.g. 3d4h
+func FormatAge(val interface{}) (string, bool) {
+	str, ok := val.(string)
+	if !ok {
+		return "", false
+	}
+	t, err := time.Parse(time.RFC3339, str)
+	if err != nil {
+		return "", false
+	}
+	return duration.HumanDuration(now().Sub(t)), true
+}
@@ -0,0 +1,91 @@
+package kube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestFormatValStrFormatsAge(t *testing.T) {
+	fixed := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return fixed }
+	t.Cleanup(func() { now = time.Now })
+
+	created := &Node{name: "creationTimestamp", ancestors: []string{"metadata"}}
+	message := &Node{name: "message", ancestors: []string{"status"}}
+
+	tests := []struct {
+		name     string
+		node     *Node
+		obj      map[string]interface{}
+		expected string
+	}{
+		{
+			name:     "valid timestamp",
+			node:     created,
+			obj:      map[string]interface{}{"metadata": map[string]interface{}{"creationTimestamp": "2025-01-07T08:00:00Z"}},
+			expected: "3d4h",
+		},
+		{
+			name:     "non-timestamp string under a registered suffix",
+			node:     created,
+			obj:      map[string]interface{}{"metadata": map[string]interface{}{"creationTimestamp": "yesterday"}},
+			expected: "yesterday",
+		},
+		{
+			name:     "timestamp under an unregistered field",
+			node:     message,
+			obj:      map[string]interface{}{"status": map[string]interface{}{"message": "2025-01-07T08:00:00Z"}},
+			expected: "2025-01-07T08:00:00Z",
+		},
+		{
+			name:     "nil value",
+			node:     created,
+			obj:      map[string]interface{}{"metadata": map[string]interface{}{"creationTimestamp": nil}},
+			expected: DefaultMissingValue,
+		},
+		{
+			name:     "missing value",
+			node:     created,
+			obj:      map[string]interface{}{},
+			expected: DefaultMissingValue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FormatValStr(tt.node, &unstructured.Unstructured{Object: tt.obj}))
+		})
+	}
+}
+
+func TestValStrKeepsRawValue(t *testing.T) {
+	created := &Node{name: "creationTimestamp", ancestors: []string{"metadata"}}
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"creationTimestamp": "2025-01-07T08:00:00Z"},
+	}}
+
+	assert.Equal(t, "2025-01-07T08:00:00Z", ValStr(created, obj))
+	val, ok := LookupValStr(created, obj)
+	assert.True(t, ok)
+	assert.Equal(t, "2025-01-07T08:00:00Z", val)
+}
+
+func TestRegisterFormatter(t *testing.T) {
+	t.Cleanup(func() { RegisterFormatter("message", nil) })
+
+	message := &Node{name: "message", ancestors: []string{"status"}}
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{"message": "ok"},
+	}}
+
+	RegisterFormatter("message", func(val interface{}) (string, bool) {
+		return "formatted", true
+	})
+	assert.Equal(t, "formatted", FormatValStr(message, obj))
+
+	RegisterFormatter("message", nil)
+	assert.Equal(t, "ok", FormatValStr(message, obj))
+}
@@ -3,14 +3,21 @@ package kube
 import (
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/restmapper"
 )
 
-// GVKInfo contains GVK information along with short names for search
+// GVKInfo contains GVK information along with short names and categories for search
 type GVKInfo struct {
 	schema.GroupVersionKind
+	Resource   string // plural name, e.g. pods
+	Namespaced bool   // the scope the REST mapping is built from, taken along to not query it again
 	ShortNames []string
+	Categories []string // e.g. "all" for Pod
+	Preferred  bool     // the version of the kind to list by default, one per GroupKind
 }
 
 // GetGVKs returns all available GVKs from the current context (legacy, kept for TUI compatibility)
@@ -35,8 +42,6 @@ func GetGVKsForContext(contextName string) ([]schema.GroupVersionKind, error) {
 // GetGVKInfosForContext returns all available GVK infos (including short names) from the specified context
 // If contextName is empty, uses the current context
 func GetGVKInfosForContext(contextName string) ([]GVKInfo, error) {
-	var result []GVKInfo
-
 	discoveryClient, err := DiscoveryClientForContext(contextName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get discovery client: %w", err)
@@ -47,6 +52,90 @@ func GetGVKInfosForContext(contextName string) ([]GVKInfo, error) {
 		return nil, fmt.Errorf("failed to get server preferred resources: %w", err)
 	}
 
+	infos, err := gvkInfosFromResourceLists(apiResourceList)
+	if err != nil {
+		return nil, err
+	}
+	for i := range infos {
+		infos[i].Preferred = true
+	}
+	return infos, nil
+}
+
+// GetAllGVKs returns every discovered version of each kind from the current context
+// Unlike GetGVKs, non-preferred versions (e.g. v1beta1 next to v1) are included
+func GetAllGVKs() ([]schema.GroupVersionKind, error) {
+	infos, err := GetAllGVKInfosForContext("")
+	if err != nil {
+		return nil, err
+	}
+	result := make([]schema.GroupVersionKind, len(infos))
+	for i, info := range infos {
+		result[i] = info.GroupVersionKind
+	}
+	return result, nil
+}
+
+// GetAllGVKInfosForContext returns GVK infos for all served versions from the specified context
+// If contextName is empty, uses the current context
+func GetAllGVKInfosForContext(contextName string) ([]GVKInfo, error) {
+	discoveryClient, err := DiscoveryClientForContext(contextName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get discovery client: %w", err)
+	}
+
+	return allGVKInfosFromDiscovery(discoveryClient)
+}
+
+func allGVKInfosFromDiscovery(discoveryClient discovery.DiscoveryInterface) ([]GVKInfo, error) {
+	groups, apiResourceList, err := discoveryClient.ServerGroupsAndResources()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server groups and resources: %w", err)
+	}
+
+	infos, err := gvkInfosFromResourceLists(apiResourceList)
+	if err != nil {
+		return nil, err
+	}
+	markPreferred(groups, infos)
+	return infos, nil
+}
+
+// markPreferred marks a version of each kind as ServerPreferredResources picks it:
+// the preferred version of its group, else the first serving it in the group's priority order
+func markPreferred(groups []*metav1.APIGroup, infos []GVKInfo) {
+	rank := func(gvk schema.GroupVersionKind) int {
+		for _, group := range groups {
+			if group.Name != gvk.Group {
+				continue
+			}
+			if group.PreferredVersion.Version == gvk.Version {
+				return -1
+			}
+			for i, version := range group.Versions {
+				if version.Version == gvk.Version {
+					return i
+				}
+			}
+		}
+		return len(infos) // not discovered in a group, last
+	}
+
+	preferred := make(map[schema.GroupKind]int)
+	for i, info := range infos {
+		j, ok := preferred[info.GroupKind()]
+		if !ok || rank(info.GroupVersionKind) < rank(infos[j].GroupVersionKind) {
+			preferred[info.GroupKind()] = i
+		}
+	}
+	for _, i := range preferred {
+		infos[i].Preferred = true
+	}
+}
+
+func gvkInfosFromResourceLists(apiResourceList []*metav1.APIResourceList) ([]GVKInfo, error) {
+	var result []GVKInfo
+
 	for _, apiResource := range apiResourceList {
 		for _, r := range apiResource.APIResources {
 			// Filter: only include resources that support "list" verb
@@ -61,7 +150,10 @@ func GetGVKInfosForContext(contextName string) ([]GVKInfo, error) {
 			}
 			info := GVKInfo{
 				GroupVersionKind: gv.WithKind(r.Kind),
+				Resource:         r.Name,
+				Namespaced:       r.Namespaced,
 				ShortNames:       r.ShortNames,
+				Categories:       r.Categories,
 			}
 			result = append(result, info)
 		}
@@ -81,18 +173,48 @@ func GetGVRForContext(contextName string, gvk schema.GroupVersionKind) (schema.G
 	if err != nil {
 		return schema.GroupVersionResource{}, fmt.Errorf("failed to get discovery client: %w", err)
 	}
+
+	return gvrFromDiscovery(discoveryClient, gvk)
+}
+
+// gvrFromDiscovery maps the exact version of gvk, not the preferred one, to its GVR
+func gvrFromDiscovery(discoveryClient discovery.DiscoveryInterface, gvk schema.GroupVersionKind) (schema.GroupVersionResource, error) {
+	mapping, err := restMappingFromDiscovery(discoveryClient, gvk)
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+
+	return mapping.Resource, nil
+}
+
+// IsNamespacedForContext reports whether objects of gvk live in a namespace
+// If contextName is empty, uses the current context
+func IsNamespacedForContext(contextName string, gvk schema.GroupVersionKind) (bool, error) {
+	discoveryClient, err := DiscoveryClientForContext(contextName)
+	if err != nil {
+		return false, fmt.Errorf("failed to get discovery client: %w", err)
+	}
+
+	mapping, err := restMappingFromDiscovery(discoveryClient, gvk)
+	if err != nil {
+		return false, err
+	}
+	return mapping.Scope.Name() == meta.RESTScopeNameNamespace, nil
+}
+
+func restMappingFromDiscovery(discoveryClient discovery.DiscoveryInterface, gvk schema.GroupVersionKind) (*meta.RESTMapping, error) {
 	groupResources, err := restmapper.GetAPIGroupResources(discoveryClient)
 	if err != nil {
-		return schema.GroupVersionResource{}, fmt.Errorf("failed to get API group resources: %w", err)
+		return nil, fmt.Errorf("failed to get API group resources: %w", err)
 	}
 
 	mapper := restmapper.NewDiscoveryRESTMapper(groupResources)
 	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
 	if err != nil {
-		return schema.GroupVersionResource{}, fmt.Errorf("failed to get REST mapping for %s: %w", gvk.String(), err)
+		return nil, fmt.Errorf("failed to get REST mapping for %s: %w", gvk.String(), err)
 	}
 
-	return mapping.Resource, nil
+	return mapping, nil
 }
 
 // supportsVerb checks if a verb is in the list of supported verbs
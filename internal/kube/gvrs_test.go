@@ -1,7 +1,13 @@
 package kube
 
 import (
+	"slices"
 	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func TestSupportsVerb(t *testing.T) {
@@ -106,3 +112,92 @@ func TestSupportsVerb(t *testing.T) {
 		})
 	}
 }
+
+func newHPAFakeDiscovery() *fakediscovery.FakeDiscovery {
+	return &fakediscovery.FakeDiscovery{
+		Fake: &k8stesting.Fake{
+			Resources: []*metav1.APIResourceList{
+				{
+					GroupVersion: "autoscaling/v2",
+					APIResources: []metav1.APIResource{
+						{Name: "horizontalpodautoscalers", Kind: "HorizontalPodAutoscaler", Namespaced: true, Verbs: []string{"get", "list", "watch"}, ShortNames: []string{"hpa"}, Categories: []string{"all"}},
+					},
+				},
+				{
+					GroupVersion: "autoscaling/v1",
+					APIResources: []metav1.APIResource{
+						{Name: "horizontalpodautoscalers", Kind: "HorizontalPodAutoscaler", Namespaced: true, Verbs: []string{"get", "list", "watch"}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestAllGVKInfosFromDiscovery(t *testing.T) {
+	infos, err := allGVKInfosFromDiscovery(newHPAFakeDiscovery())
+	if err != nil {
+		t.Fatalf("allGVKInfosFromDiscovery failed: %v", err)
+	}
+
+	versions := []string{}
+	for _, info := range infos {
+		versions = append(versions, info.Version)
+	}
+	if len(versions) != 2 || versions[0] != "v2" || versions[1] != "v1" {
+		t.Errorf("expected versions [v2 v1], got %v", versions)
+	}
+	if len(infos[0].ShortNames) != 1 || infos[0].ShortNames[0] != "hpa" {
+		t.Errorf("expected short names [hpa], got %v", infos[0].ShortNames)
+	}
+	if len(infos[0].Categories) != 1 || infos[0].Categories[0] != "all" {
+		t.Errorf("expected categories [all], got %v", infos[0].Categories)
+	}
+	if !infos[0].Namespaced || infos[0].Resource != "horizontalpodautoscalers" {
+		t.Errorf("expected namespaced horizontalpodautoscalers, got %+v", infos[0])
+	}
+	if !infos[0].Preferred || infos[1].Preferred {
+		t.Errorf("expected only v2 preferred, got %v and %v", infos[0].Preferred, infos[1].Preferred)
+	}
+}
+
+func TestMarkPreferred(t *testing.T) {
+	groups := []*metav1.APIGroup{{
+		Name:             "batch",
+		PreferredVersion: metav1.GroupVersionForDiscovery{Version: "v1"},
+		Versions:         []metav1.GroupVersionForDiscovery{{Version: "v1"}, {Version: "v1beta1"}, {Version: "v1alpha1"}},
+	}}
+	infos := []GVKInfo{
+		{GroupVersionKind: schema.GroupVersionKind{Group: "batch", Version: "v1alpha1", Kind: "CronJob"}},
+		{GroupVersionKind: schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "CronJob"}},
+		// not served in the preferred version
+		{GroupVersionKind: schema.GroupVersionKind{Group: "batch", Version: "v1alpha1", Kind: "Schedule"}},
+		{GroupVersionKind: schema.GroupVersionKind{Group: "batch", Version: "v1beta1", Kind: "Schedule"}},
+	}
+
+	markPreferred(groups, infos)
+
+	preferred := []bool{}
+	for _, info := range infos {
+		preferred = append(preferred, info.Preferred)
+	}
+	expected := []bool{false, true, false, true}
+	if !slices.Equal(preferred, expected) {
+		t.Errorf("expected preferred %v, got %v", expected, preferred)
+	}
+}
+
+func TestGVRFromDiscovery_NonPreferredVersion(t *testing.T) {
+	// v2 is preferred (listed first), but v1 was explicitly picked
+	gvk := schema.GroupVersionKind{Group: "autoscaling", Version: "v1", Kind: "HorizontalPodAutoscaler"}
+
+	gvr, err := gvrFromDiscovery(newHPAFakeDiscovery(), gvk)
+	if err != nil {
+		t.Fatalf("gvrFromDiscovery failed: %v", err)
+	}
+
+	expected := schema.GroupVersionResource{Group: "autoscaling", Version: "v1", Resource: "horizontalpodautoscalers"}
+	if gvr != expected {
+		t.Errorf("gvrFromDiscovery(%v) = %v, want %v", gvk, gvr, expected)
+	}
+}
@@ -0,0 +1,170 @@
+package kube
+
+import (
+	"slices"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	// informers shares a synced informer per context and GVR, see AcquireResourceController
+	informersMu sync.Mutex
+	informers   = make(map[string]*sharedInformer)
+)
+
+// sharedInformer is an informing controller fanning its events out to the acquired controllers,
+// torn down once the last of them is closed
+type sharedInformer struct {
+	key   string
+	owner *ResourceController // informs, never handed out
+	stop  chan struct{}
+	ready chan struct{} // closed once the first Inform returns
+	err   error
+
+	mu   sync.RWMutex // guards subs, held while replaying to a new one so events keep their order
+	subs []*ResourceController
+}
+
+// AcquireResourceController returns a synced controller of every object of gvr in the context,
+// sharing the list and watch with the other acquired controllers of the same context and GVR.
+// Objects already informed are replayed as added events. Inform must not be called on it,
+// Close releases it and the informer stops when the last acquired controller is closed.
+// An informer failing to list or watch is no longer shared, acquiring again informs anew
+// If contextName is empty, uses the current context
+func AcquireResourceController(contextName string, gvr schema.GroupVersionResource) (*ResourceController, error) {
+	if contextName == "" {
+		contextName, _ = GetCurrentContext()
+	}
+	client, err := DynamicClientForContext(contextName)
+	if err != nil {
+		return nil, err
+	}
+
+	return acquire(contextName+"/"+gvr.String(), func() *ResourceController {
+		controller := newResourceController(contextName, gvr)
+		controller.client = client
+		return controller
+	})
+}
+
+func acquire(key string, newOwner func() *ResourceController) (*ResourceController, error) {
+	for {
+		shared, err := sharedInformerFor(key, newOwner)
+		if err != nil {
+			return nil, err
+		}
+		if controller, ok := shared.subscribe(); ok {
+			return controller, nil
+		}
+		// released by its last controller meanwhile, inform again
+	}
+}
+
+// sharedInformerFor returns the synced informer of key, informing with a new owner when there is none
+func sharedInformerFor(key string, newOwner func() *ResourceController) (*sharedInformer, error) {
+	informersMu.Lock()
+	shared, ok := informers[key]
+	if !ok {
+		shared = &sharedInformer{key: key, owner: newOwner(), ready: make(chan struct{})}
+		shared.owner.relay = shared
+		informers[key] = shared
+	}
+	informersMu.Unlock()
+
+	if !ok {
+		shared.stop, shared.err = shared.owner.Inform()
+		if shared.err != nil {
+			informersMu.Lock()
+			delete(informers, key)
+			informersMu.Unlock()
+		}
+		close(shared.ready)
+	}
+	<-shared.ready
+	return shared, shared.err
+}
+
+// subscribe hands out a controller reading the informer, unless it has been released
+func (s *sharedInformer) subscribe() (*ResourceController, bool) {
+	informersMu.Lock()
+	defer informersMu.Unlock()
+	if informers[s.key] != s {
+		return nil, false
+	}
+
+	controller := newResourceController(s.owner.contextName, s.owner.gvr)
+	controller.client = s.owner.client
+	controller.store = s.owner.store
+	controller.shared = s
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, obj := range s.owner.Objects() {
+		controller.trySend(emitMsg{Type: EventAdded, Obj: obj})
+	}
+	s.subs = append(s.subs, controller)
+	return controller, true
+}
+
+func (s *sharedInformer) send(msg emitMsg) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, sub := range s.subs {
+		sub.trySend(msg)
+	}
+}
+
+func (s *sharedInformer) sendErr(err error) {
+	s.evict()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, sub := range s.subs {
+		sub.trySendErr(err)
+	}
+}
+
+// evict drops an informer failing to list or watch from the registry, so the controllers
+// reconnecting on the error acquire a fresh one. The failing one stops with its last controller
+func (s *sharedInformer) evict() {
+	select {
+	case <-s.ready:
+	default:
+		return // still informing, Inform reports its own failure
+	}
+
+	informersMu.Lock()
+	defer informersMu.Unlock()
+	if informers[s.key] != s {
+		return
+	}
+	delete(informers, s.key)
+
+	s.mu.RLock()
+	idle := len(s.subs) == 0 // nothing left to release it
+	s.mu.RUnlock()
+	if idle {
+		close(s.stop)
+		s.owner.Close()
+	}
+}
+
+// release drops a closed controller, stopping the informer when it was the last one
+func (s *sharedInformer) release(controller *ResourceController) {
+	informersMu.Lock()
+	defer informersMu.Unlock()
+
+	s.mu.Lock()
+	s.subs = slices.DeleteFunc(s.subs, func(sub *ResourceController) bool { return sub == controller })
+	last := len(s.subs) == 0
+	s.mu.Unlock()
+	if !last {
+		return
+	}
+
+	if informers[s.key] == s {
+		delete(informers, s.key)
+	}
+	close(s.stop)
+	s.owner.Close()
+}
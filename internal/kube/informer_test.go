@@ -0,0 +1,135 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var _ = Describe("Shared informers", func() {
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	key := "shared/" + gvr.String()
+
+	newPod := func(name string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("Pod")
+		obj.SetNamespace("default")
+		obj.SetName(name)
+		return obj
+	}
+
+	var (
+		client *dynamicfake.FakeDynamicClient
+		lists  atomic.Int32
+	)
+	newOwner := func() *ResourceController {
+		controller := newResourceController("shared", gvr)
+		controller.client = client
+		return controller
+	}
+
+	BeforeEach(func() {
+		lists.Store(0)
+		client = dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+			runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{gvr: "PodList"},
+			newPod("web"),
+		)
+		client.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+			lists.Add(1)
+			return false, nil, nil
+		})
+	})
+
+	It("should list once for every acquired controller", func() {
+		first, err := acquire(key, newOwner)
+		Expect(err).NotTo(HaveOccurred())
+		second, err := acquire(key, newOwner)
+		Expect(err).NotTo(HaveOccurred())
+		defer first.Close()
+		defer second.Close()
+
+		Expect(lists.Load()).To(BeEquivalentTo(1))
+		Expect(second.Objects()).To(HaveLen(1))
+	})
+
+	It("should replay the informed objects and fan new events out", func() {
+		first, err := acquire(key, newOwner)
+		Expect(err).NotTo(HaveOccurred())
+		defer first.Close()
+		second, err := acquire(key, newOwner)
+		Expect(err).NotTo(HaveOccurred())
+		defer second.Close()
+
+		for _, controller := range []*ResourceController{first, second} {
+			event := <-controller.WatchEvents()
+			Expect(event.Type).To(Equal(EventAdded))
+			Expect(event.Obj.GetName()).To(Equal("web"))
+		}
+
+		_, err = client.Resource(gvr).Namespace("default").Create(context.Background(), newPod("api"), metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		for _, controller := range []*ResourceController{first, second} {
+			Eventually(controller.WatchEvents(), time.Second).Should(Receive(HaveField("Obj.GetName()", "api")))
+		}
+	})
+
+	It("should stop the informer when the last controller is closed", func() {
+		first, err := acquire(key, newOwner)
+		Expect(err).NotTo(HaveOccurred())
+		second, err := acquire(key, newOwner)
+		Expect(err).NotTo(HaveOccurred())
+
+		first.Close()
+		informersMu.Lock()
+		Expect(informers).To(HaveKey(key))
+		informersMu.Unlock()
+
+		second.Close()
+		second.Close() // no-op
+		informersMu.Lock()
+		Expect(informers).NotTo(HaveKey(key))
+		informersMu.Unlock()
+
+		third, err := acquire(key, newOwner)
+		Expect(err).NotTo(HaveOccurred())
+		defer third.Close()
+		Expect(lists.Load()).To(BeEquivalentTo(2))
+	})
+
+	It("should inform anew for controllers acquired after a watch error", func() {
+		registered := func() bool {
+			informersMu.Lock()
+			defer informersMu.Unlock()
+			_, ok := informers[key]
+			return ok
+		}
+		failing, err := acquire(key, newOwner)
+		Expect(err).NotTo(HaveOccurred())
+		defer failing.Close()
+
+		failing.shared.owner.trySendErr(errors.New("watch failed"))
+		Expect(failing.ErrorEmitted()).To(Receive())
+		Expect(registered()).To(BeFalse())
+
+		reconnected, err := acquire(key, newOwner)
+		Expect(err).NotTo(HaveOccurred())
+		defer reconnected.Close()
+		Expect(reconnected.shared).NotTo(BeIdenticalTo(failing.shared))
+		Expect(lists.Load()).To(BeEquivalentTo(2))
+
+		failing.Close()
+		Expect(registered()).To(BeTrue(), "the failing informer stops without the fresh one")
+	})
+})
@@ -0,0 +1,71 @@
+package kube
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// NewJSONPathNode is a synthetic column computed by a JSONPath expression, e.g.
+// .spec.containers[?(@.name=="app")].image, for values the schema tree can't pick
+func NewJSONPathNode(expr string) (*Node, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, fmt.Errorf("empty JSONPath")
+	}
+	template := expr
+	if !strings.HasPrefix(template, "{") {
+		template = "{" + template + "}"
+	}
+	jp := jsonpath.New(expr).AllowMissingKeys(true)
+	if err := jp.Parse(template); err != nil {
+		return nil, fmt.Errorf("invalid JSONPath %q: %w", expr, err)
+	}
+	return &Node{name: expr, jsonPath: jp}, nil
+}
+
+// Expression returns the JSONPath of a column made by NewJSONPathNode
+func (n *Node) Expression() (string, bool) {
+	if n.jsonPath == nil {
+		return "", false
+	}
+	return n.name, true
+}
+
+// lookupJSONPath joins the results of jp in obj as the values under a wildcard are,
+// missing when nothing matches
+func lookupJSONPath(jp *jsonpath.JSONPath, obj *unstructured.Unstructured) (string, bool) {
+	results, err := jp.FindResults(obj.Object)
+	if err != nil {
+		return "", false
+	}
+
+	var strs []string
+	for _, result := range results {
+		for _, v := range result {
+			if !v.IsValid() || (v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer) && v.IsNil() {
+				continue
+			}
+			strs = append(strs, jsonPathValStr(v.Interface()))
+		}
+	}
+	if len(strs) == 0 {
+		return "", false
+	}
+	return strings.Join(strs, WildcardSeparator), true
+}
+
+// jsonPathValStr renders objects and lists as JSON, as kubectl does for a JSONPath
+func jsonPathValStr(val interface{}) string {
+	switch val.(type) {
+	case map[string]interface{}, []interface{}:
+		if b, err := json.Marshal(val); err == nil {
+			return string(b)
+		}
+	}
+	return valStr(nil, val, false)
+}
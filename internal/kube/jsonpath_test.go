@@ -0,0 +1,65 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestNewJSONPathNode(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"labels": map[string]interface{}{"app": "web"}},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "app", "image": "nginx"},
+				map[string]interface{}{"name": "sidecar", "image": "envoy", "args": []interface{}{}},
+			},
+		},
+	}}
+
+	tests := []struct {
+		expr string
+		want string
+		ok   bool
+	}{
+		{`.spec.containers[?(@.name=="app")].image`, "nginx", true},
+		{`{.spec.containers[*].image}`, "nginx,envoy", true},
+		{`.metadata.labels`, `{"app":"web"}`, true},
+		{`.spec.containers[?(@.name=="db")].image`, "", false},
+		{`.status.phase`, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			node, err := NewJSONPathNode(tt.expr)
+			require.NoError(t, err)
+
+			got, ok := LookupValStr(node, obj)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("is named by the expression", func(t *testing.T) {
+		node, err := NewJSONPathNode(" .metadata.labels['app.kubernetes.io/name'] ")
+		require.NoError(t, err)
+		expr, ok := node.Expression()
+		assert.True(t, ok)
+		assert.Equal(t, ".metadata.labels['app.kubernetes.io/name']", expr)
+		assert.Equal(t, expr, node.HeaderName())
+		assert.Equal(t, MissingValue(), ValStr(node, obj))
+	})
+
+	t.Run("rejects invalid expressions", func(t *testing.T) {
+		for _, expr := range []string{"", ".spec.containers[", "{.spec"} {
+			_, err := NewJSONPathNode(expr)
+			assert.Error(t, err, expr)
+		}
+	})
+
+	t.Run("schema nodes have no expression", func(t *testing.T) {
+		_, ok := NewPathNode([]string{"spec"}).Expression()
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,122 @@
+package kube
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// KubectlGetCommand describes the `kubectl get` invocation equivalent to a view
+type KubectlGetCommand struct {
+	GVR     schema.GroupVersionResource
+	Context string
+	// Namespace scopes the command, empty for all namespaces
+	Namespace     string
+	LabelSelector string
+	FieldSelector string
+	Nodes         []*Node
+	// ClusterScoped resources take no namespace flag
+	ClusterScoped bool
+}
+
+// BuildCustomColumns returns the `kubectl get` with a custom column per node,
+// to be narrowed by the context, namespace and selectors of the view
+func BuildCustomColumns(gvr schema.GroupVersionResource, nodes []*Node, clusterScoped bool) KubectlGetCommand {
+	return KubectlGetCommand{GVR: gvr, Nodes: nodes, ClusterScoped: clusterScoped}
+}
+
+// String renders the command, shell-quoting arguments where needed
+func (c KubectlGetCommand) String() string {
+	args := []string{"kubectl", "get", kubectlResource(c.GVR)}
+
+	switch {
+	case c.ClusterScoped:
+	case c.Namespace != "":
+		args = append(args, "-n", c.Namespace)
+	default:
+		args = append(args, "-A")
+	}
+	if c.Context != "" {
+		args = append(args, "--context", shellQuote(c.Context))
+	}
+	if c.LabelSelector != "" {
+		args = append(args, "-l", shellQuote(c.LabelSelector))
+	}
+	if c.FieldSelector != "" {
+		args = append(args, "--field-selector", shellQuote(c.FieldSelector))
+	}
+	args = append(args, "-o", shellQuote("custom-columns="+customColumns(c.Nodes)))
+
+	return strings.Join(args, " ")
+}
+
+// kubectlResource fully qualifies the resource so the exact picked version is used
+func kubectlResource(gvr schema.GroupVersionResource) string {
+	if gvr.Group == "" {
+		return gvr.Resource
+	}
+	return strings.Join([]string{gvr.Resource, gvr.Version, gvr.Group}, ".")
+}
+
+// customColumns numbers repeated headers, e.g. NAME_2 for a label ending with /name,
+// so every column can be told apart in the output
+func customColumns(nodes []*Node) string {
+	used := map[string]bool{"NAME": true}
+	unique := func(header string) string {
+		name := header
+		for n := 2; used[name]; n++ {
+			name = fmt.Sprintf("%s_%d", header, n)
+		}
+		used[name] = true
+		return name
+	}
+
+	columns := []string{"NAME:.metadata.name"}
+	for _, node := range nodes {
+		if expr, ok := node.Expression(); ok {
+			columns = append(columns, unique("JSONPATH")+":"+expr) // the expression may not be a header
+			continue
+		}
+		columns = append(columns, fmt.Sprintf("%s:%s", unique(node.HeaderName()), FieldJSONPath(wildcardIndices(node.NodeFullPath()))))
+	}
+	return strings.Join(columns, ",")
+}
+
+// wildcardIndices widens array indices to `*`, a column lists the values of every element
+func wildcardIndices(path []string) []string {
+	result := make([]string, len(path))
+	for i, segment := range path {
+		if _, err := strconv.Atoi(segment); err == nil {
+			segment = "*"
+		}
+		result[i] = segment
+	}
+	return result
+}
+
+// FieldJSONPath converts a node path to kubectl's JSONPath notation
+// e.g. [spec containers * image] -> .spec.containers[*].image
+func FieldJSONPath(path []string) string {
+	var b strings.Builder
+	for _, segment := range path {
+		if _, err := strconv.Atoi(segment); err == nil || segment == "*" {
+			b.WriteString("[" + segment + "]")
+			continue
+		}
+		// keys like app.kubernetes.io/name must not be split on dots
+		b.WriteString("." + strings.ReplaceAll(segment, ".", `\.`))
+	}
+	return b.String()
+}
+
+func shellQuote(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r == '-' || r == '_' || r == '.' || r == '/' || r == '=' || r == ',' || r == ':' ||
+			('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9'))
+	}) < 0 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,95 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestFieldJSONPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     []string
+		expected string
+	}{
+		{"simple", []string{"status", "phase"}, ".status.phase"},
+		{"index", []string{"spec", "containers", "0", "image"}, ".spec.containers[0].image"},
+		{"wildcard", []string{"spec", "containers", "*", "image"}, ".spec.containers[*].image"},
+		{"dotted key", []string{"metadata", "labels", "app.kubernetes.io/name"}, `.metadata.labels.app\.kubernetes\.io/name`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FieldJSONPath(tt.path))
+		})
+	}
+}
+
+func TestKubectlGetCommand(t *testing.T) {
+	phase := &Node{name: "phase", ancestors: []string{"status"}}
+	image := &Node{name: "image", ancestors: []string{"spec", "containers", "*"}}
+
+	tests := []struct {
+		name     string
+		cmd      KubectlGetCommand
+		expected string
+	}{
+		{
+			name: "core resource across namespaces",
+			cmd: KubectlGetCommand{
+				GVR:     schema.GroupVersionResource{Version: "v1", Resource: "pods"},
+				Context: "kind-kind",
+				Nodes:   []*Node{phase, image},
+			},
+			expected: "kubectl get pods -A --context kind-kind -o 'custom-columns=NAME:.metadata.name,PHASE:.status.phase,IMAGE:.spec.containers[*].image'",
+		},
+		{
+			name: "grouped resource in a namespace with a selector",
+			cmd: KubectlGetCommand{
+				GVR:           schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"},
+				Namespace:     "default",
+				LabelSelector: "app in (web, api)",
+			},
+			expected: "kubectl get deployments.v1.apps -n default -l 'app in (web, api)' -o custom-columns=NAME:.metadata.name",
+		},
+		{
+			name: "cluster-scoped resource",
+			cmd: KubectlGetCommand{
+				GVR:           schema.GroupVersionResource{Version: "v1", Resource: "nodes"},
+				ClusterScoped: true,
+			},
+			expected: "kubectl get nodes -o custom-columns=NAME:.metadata.name",
+		},
+		{
+			name: "field selector",
+			cmd: KubectlGetCommand{
+				GVR:           schema.GroupVersionResource{Version: "v1", Resource: "pods"},
+				FieldSelector: "status.phase=Running",
+			},
+			expected: "kubectl get pods -A --field-selector status.phase=Running -o custom-columns=NAME:.metadata.name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.cmd.String())
+		})
+	}
+}
+
+func TestBuildCustomColumns(t *testing.T) {
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	nodes := []*Node{
+		NewPathNode([]string{"spec", "containers", "0", "image"}),
+		NewPathNode([]string{"metadata", "labels", "app.kubernetes.io/name"}),
+		NewPathNode([]string{"metadata", "annotations", "example.com/name"}),
+	}
+
+	assert.Equal(t,
+		`kubectl get pods -A -o 'custom-columns=NAME:.metadata.name,IMAGE:.spec.containers[*].image,NAME_2:.metadata.labels.app\.kubernetes\.io/name,NAME_3:.metadata.annotations.example\.com/name'`,
+		BuildCustomColumns(gvr, nodes, false).String())
+	assert.Equal(t,
+		"kubectl get nodes -o custom-columns=NAME:.metadata.name",
+		BuildCustomColumns(schema.GroupVersionResource{Version: "v1", Resource: "nodes"}, nil, true).String())
+}
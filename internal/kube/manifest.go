@@ -0,0 +1,162 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	skeletonName      = "kupid-dry-run"
+	skeletonNamespace = "default"
+)
+
+// BuildSkeleton constructs a minimal manifest for gvk from the required fields
+// and the selected nodes, filling each leaf with a zero value of its type.
+// Required fields are only followed while their ancestors are required too.
+func BuildSkeleton(gvk schema.GroupVersionKind, fields map[string]*Field, nodes []*Node) *unstructured.Unstructured {
+	obj := map[string]interface{}{}
+	addRequiredFields(obj, fields)
+
+	for _, node := range nodes {
+		setSkeletonValue(obj, node.NodeFullPath(), zeroValue(node.Type()))
+	}
+
+	skeleton := &unstructured.Unstructured{Object: obj}
+	skeleton.SetAPIVersion(gvk.GroupVersion().String())
+	skeleton.SetKind(gvk.Kind)
+	if skeleton.GetName() == "" {
+		skeleton.SetName(skeletonName)
+	}
+
+	return skeleton
+}
+
+// DryRunCreate submits obj to the API server with dry-run=All,
+// so admission and schema validation run without persisting anything.
+// Namespaced kinds are validated in the default namespace unless obj sets one.
+func DryRunCreate(contextName string, gvk schema.GroupVersionKind, obj *unstructured.Unstructured) error {
+	discoveryClient, err := DiscoveryClientForContext(contextName)
+	if err != nil {
+		return fmt.Errorf("failed to get discovery client: %w", err)
+	}
+	mapping, err := restMappingFromDiscovery(discoveryClient, gvk)
+	if err != nil {
+		return err
+	}
+
+	client, err := DynamicClientForContext(contextName)
+	if err != nil {
+		return err
+	}
+
+	resource := client.Resource(mapping.Resource)
+	opts := metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}}
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		namespace := obj.GetNamespace()
+		if namespace == "" {
+			namespace = skeletonNamespace
+		}
+		_, err = resource.Namespace(namespace).Create(context.Background(), obj, opts)
+	} else {
+		_, err = resource.Create(context.Background(), obj, opts)
+	}
+	if err != nil {
+		return fmt.Errorf("dry-run create of %s failed: %w", gvk.Kind, err)
+	}
+
+	return nil
+}
+
+func addRequiredFields(obj map[string]interface{}, fields map[string]*Field) {
+	for name, field := range fields {
+		if !field.Required || name == "apiVersion" || name == "kind" {
+			continue
+		}
+
+		if field.IsObject() && !field.IsArray() && !field.IsMap() {
+			child, ok := obj[name].(map[string]interface{})
+			if !ok {
+				child = map[string]interface{}{}
+				obj[name] = child
+			}
+			addRequiredFields(child, field.Children)
+			continue
+		}
+
+		if _, exists := obj[name]; !exists {
+			obj[name] = zeroValue(field.Type)
+		}
+	}
+}
+
+// setSkeletonValue sets val at path, creating intermediate maps and
+// single-element arrays for index (`0`, `*`) segments on the way.
+func setSkeletonValue(obj map[string]interface{}, path []string, val interface{}) {
+	if len(path) == 0 {
+		return
+	}
+
+	key := path[0]
+	if len(path) == 1 {
+		obj[key] = val
+		return
+	}
+
+	if isIndexSegment(path[1]) {
+		arr, _ := obj[key].([]interface{})
+		if len(arr) == 0 {
+			arr = []interface{}{map[string]interface{}{}}
+		}
+		if len(path) == 2 {
+			arr[0] = val
+		} else {
+			elem, ok := arr[0].(map[string]interface{})
+			if !ok {
+				elem = map[string]interface{}{}
+				arr[0] = elem
+			}
+			setSkeletonValue(elem, path[2:], val)
+		}
+		obj[key] = arr
+		return
+	}
+
+	child, ok := obj[key].(map[string]interface{})
+	if !ok {
+		child = map[string]interface{}{}
+		obj[key] = child
+	}
+	setSkeletonValue(child, path[1:], val)
+}
+
+func isIndexSegment(segment string) bool {
+	if segment == "*" {
+		return true
+	}
+	_, err := strconv.Atoi(segment)
+	return err == nil
+}
+
+func zeroValue(typ string) interface{} {
+	switch {
+	case strings.HasPrefix(typ, "[]"):
+		return []interface{}{}
+	case strings.HasPrefix(typ, "map[string]"):
+		return map[string]interface{}{}
+	case strings.HasPrefix(typ, "integer"), strings.HasPrefix(typ, "number"):
+		return int64(0)
+	case strings.HasPrefix(typ, "boolean"):
+		return false
+	case typ == "" || strings.HasPrefix(typ, "string"):
+		return ""
+	default:
+		return map[string]interface{}{}
+	}
+}
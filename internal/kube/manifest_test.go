@@ -0,0 +1,94 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestBuildSkeleton(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	fields := map[string]*Field{
+		"spec": {
+			Name:     "spec",
+			Type:     "DeploymentSpec",
+			Required: true,
+			Children: map[string]*Field{
+				"selector": {Name: "selector", Prefix: []string{"spec"}, Type: "LabelSelector", Required: true, Children: map[string]*Field{}},
+				"replicas": {Name: "replicas", Prefix: []string{"spec"}, Type: "integer"},
+				"paused":   {Name: "paused", Prefix: []string{"spec"}, Type: "boolean", Required: true},
+			},
+		},
+		"status": {
+			Name: "status",
+			Type: "DeploymentStatus",
+			Children: map[string]*Field{
+				"replicas": {Name: "replicas", Prefix: []string{"status"}, Type: "integer", Required: true},
+			},
+		},
+	}
+	replicas := &Node{field: fields["spec"].Children["replicas"], name: "replicas", ancestors: []string{"spec"}}
+	image := &Node{name: "image", ancestors: []string{"spec", "template", "spec", "containers", "0"}}
+
+	skeleton := BuildSkeleton(gvk, fields, []*Node{replicas, image})
+
+	t.Run("sets type meta and a name", func(t *testing.T) {
+		assert.Equal(t, "apps/v1", skeleton.GetAPIVersion())
+		assert.Equal(t, "Deployment", skeleton.GetKind())
+		assert.Equal(t, skeletonName, skeleton.GetName())
+	})
+
+	t.Run("includes required fields under required ancestors", func(t *testing.T) {
+		paused, found, err := unstructured.NestedBool(skeleton.Object, "spec", "paused")
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.False(t, paused)
+
+		_, found, _ = unstructured.NestedMap(skeleton.Object, "spec", "selector")
+		assert.True(t, found)
+	})
+
+	t.Run("skips required fields under optional ancestors", func(t *testing.T) {
+		_, found := skeleton.Object["status"]
+		assert.False(t, found)
+	})
+
+	t.Run("sets zero values for selected nodes", func(t *testing.T) {
+		val, found, err := unstructured.NestedInt64(skeleton.Object, "spec", "replicas")
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, int64(0), val)
+	})
+
+	t.Run("creates arrays for index segments", func(t *testing.T) {
+		containers, found, err := unstructured.NestedSlice(skeleton.Object, "spec", "template", "spec", "containers")
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, []interface{}{map[string]interface{}{"image": ""}}, containers)
+	})
+}
+
+func TestZeroValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		typ      string
+		expected interface{}
+	}{
+		{"string", "string", ""},
+		{"untyped", "", ""},
+		{"integer", "integer", int64(0)},
+		{"number", "number", int64(0)},
+		{"boolean", "boolean", false},
+		{"array", "[]string", []interface{}{}},
+		{"map", "map[string]string", map[string]interface{}{}},
+		{"object ref", "ObjectMeta", map[string]interface{}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, zeroValue(tt.typ))
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ListNamespacesForContext returns the namespace names sorted
+// If contextName is empty, uses the current context
+func ListNamespacesForContext(contextName string) ([]string, error) {
+	cs, err := clientSetForContext(contextName)
+	if err != nil {
+		return nil, err
+	}
+	return listNamespaces(cs)
+}
+
+func listNamespaces(cs kubernetes.Interface) ([]string, error) {
+	list, err := cs.CoreV1().Namespaces().List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	names := make([]string, 0, len(list.Items))
+	for _, ns := range list.Items {
+		names = append(names, ns.Name)
+	}
+	slices.Sort(names)
+	return names, nil
+}
@@ -0,0 +1,37 @@
+package kube
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestListNamespaces(t *testing.T) {
+	t.Run("sorts the names", func(t *testing.T) {
+		cs := fake.NewSimpleClientset(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		)
+
+		names, err := listNamespaces(cs)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"default", "kube-system"}, names)
+	})
+
+	t.Run("wraps the list error", func(t *testing.T) {
+		cs := fake.NewSimpleClientset()
+		cs.PrependReactor("list", "namespaces", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("forbidden")
+		})
+
+		_, err := listNamespaces(cs)
+		assert.ErrorContains(t, err, "failed to list namespaces: forbidden")
+	})
+}
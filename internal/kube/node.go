@@ -2,15 +2,21 @@ package kube
 
 import (
 	"fmt"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/jsonpath"
 )
 
 type Node struct {
 	Expanded bool
 	Selected bool
+	// Aggregated arrays hide the index children, their values are joined in a cell
+	Aggregated bool
 	// TODO: new field to represent the values of node are all nil
 	// reversed this would be a Line's Essential field(tbd), to reduce of schema context
 
@@ -19,6 +25,16 @@ type Node struct {
 	ancestors []string
 	level     int
 	children  map[string]*Node
+	joined    bool               // under an aggregated array, `*` collects every element
+	jsonPath  *jsonpath.JSONPath // computes the value instead of the path, see NewJSONPathNode
+}
+
+// NewPathNode is a bare node at path, for frontends holding paths instead of the node tree
+func NewPathNode(path []string) *Node {
+	if len(path) == 0 {
+		return nil
+	}
+	return &Node{name: path[len(path)-1], ancestors: path[:len(path)-1]}
 }
 
 // line things
@@ -33,7 +49,12 @@ func (n *Node) SetExpanded(expanded bool) {
 }
 
 func (n *Node) Renderable(objs []*unstructured.Unstructured) bool {
-	return n.Foldable() || n.Pickable(objs)
+	return n.renderable(func() bool { return !n.allNil(objs) })
+}
+
+// Defaulted reports whether the leaf has no value in any of objs but a scalar default to show
+func (n *Node) Defaulted(objs []*unstructured.Unstructured) bool {
+	return n.defaulted(func() bool { return !n.allNil(objs) })
 }
 
 func (n *Node) Foldable() bool {
@@ -41,20 +62,38 @@ func (n *Node) Foldable() bool {
 }
 
 func (n *Node) Pickable(objs []*unstructured.Unstructured) bool {
+	return n.pickable(func() bool { return !n.allNil(objs) })
+}
+
+// renderable, defaulted and pickable call hasValue only when the node's shape leaves it to the values,
+// see ValueIndex to memoize it
+func (n *Node) renderable(hasValue func() bool) bool {
+	return n.Foldable() || n.pickable(hasValue) || n.defaulted(hasValue)
+}
+
+func (n *Node) defaulted(hasValue func() bool) bool {
 	if n.hasChildren() {
 		return false
 	}
+	_, ok := n.Default()
+	return ok && !hasValue()
+}
 
-	if n.field == nil {
-		return !n.allNil(objs)
+func (n *Node) pickable(hasValue func() bool) bool {
+	if n.hasChildren() {
+		return false
+	}
+
+	if n.field == nil || n.Aggregated {
+		return hasValue()
 	}
 
-	return n.field.IsPrimitive() && !n.allNil(objs)
+	return n.field.IsPrimitive() && hasValue()
 }
 
 func (n *Node) allNil(objs []*unstructured.Unstructured) bool {
 	for _, obj := range objs {
-		if ValStr(n, obj) != "-" {
+		if _, ok := LookupValStr(n, obj); ok {
 			return false
 		}
 	}
@@ -62,15 +101,45 @@ func (n *Node) allNil(objs []*unstructured.Unstructured) bool {
 }
 
 func (n *Node) hasChildren() bool {
-	return len(n.children) > 0
+	return len(n.Children()) > 0
 }
 
 // line things end
 
+// Children of an aggregated array are only the wildcard subtree, if the elements are objects
 func (n *Node) Children() map[string]*Node {
+	if n.Aggregated {
+		if wildcard, ok := n.children["*"]; ok {
+			return map[string]*Node{"*": wildcard}
+		}
+		return nil
+	}
 	return n.children
 }
 
+// ToggleAggregate switches an array between indexed children and joined values
+func (n *Node) ToggleAggregate() bool {
+	if !n.IsArray() {
+		return false
+	}
+	n.setAggregated(!n.Aggregated)
+	return true
+}
+
+func (n *Node) setAggregated(aggregated bool) {
+	n.Aggregated = aggregated
+	if wildcard, ok := n.children["*"]; ok {
+		wildcard.setJoined(aggregated || n.joined)
+	}
+}
+
+func (n *Node) setJoined(joined bool) {
+	n.joined = joined
+	for name, child := range n.children {
+		child.setJoined(joined || (n.Aggregated && name == "*")) // nested aggregated arrays stay joined
+	}
+}
+
 func (n *Node) Name() string {
 	if n.field == nil {
 		return n.name
@@ -79,6 +148,9 @@ func (n *Node) Name() string {
 }
 
 func (n *Node) HeaderName() string {
+	if expr, ok := n.Expression(); ok {
+		return expr // not split, slashes may be in a key
+	}
 	// uppercase all char of last part of split by '/'
 	parts := strings.Split(n.name, "/")
 	headerName := strings.ToUpper(parts[len(parts)-1])
@@ -107,6 +179,32 @@ func (n *Node) Type() string {
 	return n.field.Type
 }
 
+// Enum returns the allowed values of the field, if the schema restricts them
+func (n *Node) Enum() []string {
+	if n.field == nil {
+		return nil
+	}
+	return n.field.Enum
+}
+
+// Default returns the scalar schema default of the field, e.g. Always for restartPolicy
+func (n *Node) Default() (string, bool) {
+	if n.field == nil {
+		return "", false
+	}
+	return n.field.DefaultStr()
+}
+
+// IsArray reports whether the node is an array field such as containers
+func (n *Node) IsArray() bool {
+	return n.field != nil && n.field.IsArray()
+}
+
+// IsMap reports whether the node is a map field such as labels
+func (n *Node) IsMap() bool {
+	return n.field != nil && n.field.IsMap()
+}
+
 func (n *Node) Required() bool {
 	if n.field == nil {
 		return false
@@ -145,7 +243,9 @@ func CreateNodeTree(fieldTree map[string]*Field, objs []*unstructured.Unstructur
 
 		if field.IsArray() {
 			maxLength := getMaxLength(childPrefix, objs)
-			children = make(map[string]*Node)
+			if maxLength > 0 { // an array empty in every object is neither foldable nor pickable
+				children = make(map[string]*Node)
+			}
 
 			// Add wildcard node for non-empty arrays (before creating index nodes)
 			if maxLength > 0 && field.Children != nil {
@@ -220,20 +320,20 @@ func CreateNodeTree(fieldTree map[string]*Field, objs []*unstructured.Unstructur
 	return result
 }
 
+// getNestedValue digs the value at paths, where `*` iterates all elements of an array
+// and the values found under them are collected in a slice
 func getNestedValue(obj map[string]interface{}, paths ...string) (interface{}, bool, error) {
-	var current interface{} = obj
+	return nestedValue(obj, paths)
+}
 
+func nestedValue(current interface{}, paths []string) (interface{}, bool, error) {
 	for i, path := range paths {
-		// Handle wildcard: use first index (0) for querying actual data
 		if path == "*" {
-			if slice, ok := current.([]interface{}); ok {
-				if len(slice) == 0 {
-					return nil, false, nil
-				}
-				current = slice[0]
-			} else {
+			slice, ok := current.([]interface{})
+			if !ok {
 				return nil, false, fmt.Errorf("expected array for wildcard, got %T", current)
 			}
+			return collectElements(slice, paths[i+1:]), true, nil
 		} else if index, err := strconv.Atoi(path); err == nil {
 			// for array nodes
 			if slice, ok := current.([]interface{}); ok {
@@ -256,20 +356,129 @@ func getNestedValue(obj map[string]interface{}, paths ...string) (interface{}, b
 				return nil, false, fmt.Errorf("expected map, got %T", current)
 			}
 		}
+	}
 
-		// 마지막 필드면 현재 값 반환
-		if i == len(paths)-1 {
-			return current, true, nil
+	return current, true, nil
+}
+
+// collectElements digs rest in each element, skipping the ones without value;
+// values under nested wildcards are flattened
+func collectElements(slice []interface{}, rest []string) []interface{} {
+	result := []interface{}{}
+	for _, elem := range slice {
+		val, found, err := nestedValue(elem, rest)
+		if err != nil || !found || val == nil {
+			continue
 		}
+		if slices.Contains(rest, "*") {
+			result = append(result, val.([]interface{})...)
+		} else {
+			result = append(result, val)
+		}
+	}
+	return result
+}
+
+// nestedValues lists the values at path, one per array element for wildcard paths
+func nestedValues(obj map[string]interface{}, path []string) []interface{} {
+	val, found, err := getNestedValue(obj, path...)
+	if err != nil || !found {
+		return nil
 	}
+	if slices.Contains(path, "*") {
+		return val.([]interface{})
+	}
+	return []interface{}{val}
+}
 
-	return current, true, nil
+// DefaultMissingValue is rendered for a node without value in an object unless configured
+const DefaultMissingValue = "-"
+
+var (
+	missingValueMu sync.RWMutex
+	missingValue   = DefaultMissingValue
+)
+
+// MissingValue returns the token rendered for a node without value in an object
+func MissingValue() string {
+	missingValueMu.RLock()
+	defer missingValueMu.RUnlock()
+	return missingValue
+}
+
+// SetMissingValue changes the token for missing values, e.g. "" or "<none>" as kubectl
+func SetMissingValue(token string) {
+	missingValueMu.Lock()
+	defer missingValueMu.Unlock()
+	missingValue = token
 }
 
+const (
+	// WildcardSeparator joins the values under a `*` segment in a cell
+	WildcardSeparator = ","
+	// AggregateSeparator joins the values of an aggregated array in a cell
+	AggregateSeparator = ", "
+)
+
 func ValStr(node *Node, obj *unstructured.Unstructured) string {
-	val, found, err := getNestedValue(obj.Object, node.NodeFullPath()...)
-	if err != nil || !found {
-		return "-"
+	if str, ok := LookupValStr(node, obj); ok {
+		return str
+	}
+	return MissingValue()
+}
+
+// FormatValStr renders ValStr for display, through the formatter registered for the field,
+// e.g. a timestamp as its age. Sorting, copying and exports keep the raw ValStr
+func FormatValStr(node *Node, obj *unstructured.Unstructured) string {
+	if str, ok := lookupValStr(node, obj, true); ok {
+		return str
+	}
+	return MissingValue()
+}
+
+// LookupValStr renders the value of node in obj as ValStr, reporting false instead when it is missing
+func LookupValStr(node *Node, obj *unstructured.Unstructured) (string, bool) {
+	return lookupValStr(node, obj, false)
+}
+
+func lookupValStr(node *Node, obj *unstructured.Unstructured, format bool) (string, bool) {
+	if node.jsonPath != nil {
+		return lookupJSONPath(node.jsonPath, obj)
+	}
+	path := node.NodeFullPath()
+	sep := WildcardSeparator
+	if node.Aggregated {
+		path = append(path, "*")
+	}
+	if node.Aggregated || node.joined {
+		sep = AggregateSeparator
+	}
+
+	val, found, err := getNestedValue(obj.Object, path...)
+	if err != nil || !found || val == nil { // explicit nulls are missing too
+		return "", false
+	}
+
+	if vals, ok := val.([]interface{}); ok && slices.Contains(path, "*") {
+		if len(vals) == 0 {
+			return "", false
+		}
+		strs := make([]string, 0, len(vals))
+		for _, v := range vals {
+			strs = append(strs, valStr(path, v, format))
+		}
+		return strings.Join(strs, sep), true
+	}
+	return valStr(path, val, format), true
+}
+
+func valStr(path []string, val interface{}, format bool) string {
+	if format {
+		if f, ok := formatterFor(path); ok {
+			if str, ok := f(val); ok {
+				return str
+			}
+		}
 	}
 
 	if str, ok := val.(string); ok && len(str) == 0 { // edge case `""`
@@ -280,15 +489,17 @@ func ValStr(node *Node, obj *unstructured.Unstructured) string {
 }
 
 func getMaxLength(arrayPath []string, objs []*unstructured.Unstructured) int {
-	maxLength := 0 // if no array, return 1 to render only fields
+	maxLength := 0 // no index children for arrays empty or missing in every object
 	for _, obj := range objs {
-		val, found, err := getNestedValue(obj.Object, arrayPath...)
-		if err != nil || !found {
-			continue
-		}
-		arr := val.([]interface{})
-		if len(arr) > maxLength {
-			maxLength = len(arr)
+		for _, val := range nestedValues(obj.Object, arrayPath) {
+			// loosely validated CRDs may hold a non-array value despite the schema
+			arr, ok := val.([]interface{})
+			if !ok {
+				continue
+			}
+			if len(arr) > maxLength {
+				maxLength = len(arr)
+			}
 		}
 	}
 	return maxLength
@@ -313,15 +524,16 @@ func getDistinctKeys(mapPath []string, objs []*unstructured.Unstructured) []stri
 	exists := map[string]struct{}{}
 
 	for _, obj := range objs {
-		val, found, err := getNestedValue(obj.Object, mapPath...)
-		if err != nil || !found {
-			continue
-		}
-		mapString := val.(map[string]interface{})
-		for k := range mapString {
-			if _, ok := exists[k]; !ok {
-				exists[k] = struct{}{}
-				keys = append(keys, k)
+		for _, val := range nestedValues(obj.Object, mapPath) {
+			mapString, ok := val.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for k := range mapString {
+				if _, ok := exists[k]; !ok {
+					exists[k] = struct{}{}
+					keys = append(keys, k)
+				}
 			}
 		}
 	}
@@ -329,6 +541,41 @@ func getDistinctKeys(mapPath []string, objs []*unstructured.Unstructured) []stri
 	return keys
 }
 
+// KeyCount is a distinct key of a map field with the number of objects having it
+type KeyCount struct {
+	Key   string
+	Count int
+}
+
+// CountKeys counts the objects having each key of the map node,
+// the most common keys first and ties ordered by key
+func CountKeys(node *Node, objs []*unstructured.Unstructured) []KeyCount {
+	counts := map[string]int{}
+	for _, obj := range objs {
+		for _, val := range nestedValues(obj.Object, node.NodeFullPath()) {
+			mapString, ok := val.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for k := range mapString {
+				counts[k]++
+			}
+		}
+	}
+
+	result := make([]KeyCount, 0, len(counts))
+	for k, count := range counts {
+		result = append(result, KeyCount{Key: k, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
 // TODO: refactor, pull up traverse with create to function
 // TODO: besides, expandedNodes should be a state of the schemaModel(ideally expand would not be a state of node)
 func UpdateNodeTree(existing map[string]*Node, fieldTree map[string]*Field, objs []*unstructured.Unstructured, nodePrefix []string) map[string]*Node {
@@ -349,7 +596,9 @@ func UpdateNodeTree(existing map[string]*Node, fieldTree map[string]*Field, objs
 
 		if field.IsArray() {
 			maxLength := getMaxLength(childPrefix, objs)
-			children = make(map[string]*Node)
+			if maxLength > 0 {
+				children = make(map[string]*Node)
+			}
 
 			// Add wildcard node for non-empty arrays (before creating index nodes)
 			if maxLength > 0 && field.Children != nil {
@@ -447,6 +696,9 @@ func UpdateNodeTree(existing map[string]*Node, fieldTree map[string]*Field, objs
 			Expanded:  expanded,
 			Selected:  selected,
 		}
+		if exists && existingNode.Aggregated {
+			result[key].setAggregated(true)
+		}
 	}
 
 	return result
@@ -64,4 +64,316 @@ var _ = Describe("Node", func() {
 			Expect(node.Pickable(objs)).To(BeFalse())
 		})
 	})
+
+	Describe("Missing value", func() {
+		AfterEach(func() {
+			SetMissingValue(DefaultMissingValue)
+		})
+
+		node := &Node{name: "foo", field: &Field{Type: "string"}}
+		missing := &unstructured.Unstructured{Object: map[string]interface{}{}}
+
+		It("should render the configured token", func() {
+			SetMissingValue("<none>")
+			Expect(ValStr(node, missing)).To(Equal("<none>"))
+		})
+
+		It("should decide pickability by the value, not the token", func() {
+			SetMissingValue("")
+			Expect(node.Pickable([]*unstructured.Unstructured{missing})).To(BeFalse())
+
+			SetMissingValue("<none>")
+			set := &unstructured.Unstructured{Object: map[string]interface{}{"foo": "<none>"}}
+			Expect(node.Pickable([]*unstructured.Unstructured{set})).To(BeTrue())
+		})
+	})
+
+	Describe("Defaulted", func() {
+		objs := []*unstructured.Unstructured{
+			{Object: map[string]interface{}{"other": "value"}},
+		}
+
+		It("should render a leaf without value by its scalar default", func() {
+			node := &Node{
+				name:  "restartPolicy",
+				field: &Field{Type: "string", Default: "Always"},
+			}
+			Expect(node.Defaulted(objs)).To(BeTrue())
+			Expect(node.Renderable(objs)).To(BeTrue())
+			Expect(node.Pickable(objs)).To(BeFalse())
+			value, _ := node.Default()
+			Expect(value).To(Equal("Always"))
+		})
+
+		It("should not show a default once a value is set", func() {
+			node := &Node{
+				name:  "other",
+				field: &Field{Type: "string", Default: "fallback"},
+			}
+			Expect(node.Defaulted(objs)).To(BeFalse())
+		})
+
+		It("should ignore struct defaults", func() {
+			node := &Node{
+				name:  "strategy",
+				field: &Field{Type: "Object", Default: map[string]interface{}{"type": "RollingUpdate"}},
+			}
+			Expect(node.Defaulted(objs)).To(BeFalse())
+		})
+
+		It("should format numbers without exponents", func() {
+			field := &Field{Default: float64(1000000)}
+			value, ok := field.DefaultStr()
+			Expect(ok).To(BeTrue())
+			Expect(value).To(Equal("1000000"))
+		})
+	})
+
+	Describe("type-mismatched values", func() {
+		objs := []*unstructured.Unstructured{
+			{Object: map[string]interface{}{
+				"items":  []interface{}{"a", "b"},
+				"labels": map[string]interface{}{"app": "web"},
+			}},
+			{Object: map[string]interface{}{
+				"items":  "not-an-array",
+				"labels": "not-a-map",
+			}},
+		}
+
+		It("should skip non-array values when measuring arrays", func() {
+			Expect(getMaxLength([]string{"items"}, objs)).To(Equal(2))
+		})
+
+		It("should skip non-map values when collecting keys", func() {
+			Expect(getDistinctKeys([]string{"labels"}, objs)).To(Equal([]string{"app"}))
+		})
+
+		It("should build the node tree without panicking", func() {
+			fields := map[string]*Field{
+				"items":  {Name: "items", Type: "[]string"},
+				"labels": {Name: "labels", Type: "map[string]string"},
+			}
+			var nodes map[string]*Node
+			Expect(func() { nodes = CreateNodeTree(fields, objs, []string{}) }).NotTo(Panic())
+			Expect(nodes["items"].children).To(HaveKey("0"))
+			Expect(nodes["labels"].children).To(HaveKey("app"))
+		})
+	})
+
+	Describe("CountKeys", func() {
+		It("should count objects per key, most common first", func() {
+			objs := []*unstructured.Unstructured{
+				{Object: map[string]interface{}{"metadata": map[string]interface{}{
+					"labels": map[string]interface{}{"app": "web", "tier": "front"},
+				}}},
+				{Object: map[string]interface{}{"metadata": map[string]interface{}{
+					"labels": map[string]interface{}{"app": "api", "team": "core"},
+				}}},
+				{Object: map[string]interface{}{"metadata": map[string]interface{}{
+					"labels": "not-a-map",
+				}}},
+				{Object: map[string]interface{}{}},
+			}
+			node := &Node{name: "labels", ancestors: []string{"metadata"}}
+
+			Expect(CountKeys(node, objs)).To(Equal([]KeyCount{
+				{Key: "app", Count: 2},
+				{Key: "team", Count: 1},
+				{Key: "tier", Count: 1},
+			}))
+		})
+	})
+
+	Describe("Aggregate", func() {
+		objs := []*unstructured.Unstructured{
+			{Object: map[string]interface{}{"spec": map[string]interface{}{
+				"containers": []interface{}{
+					map[string]interface{}{"name": "web", "image": "nginx", "args": []interface{}{"-v"}},
+					map[string]interface{}{"name": "log", "image": "sidecar"},
+				},
+			}}},
+			{Object: map[string]interface{}{"spec": map[string]interface{}{}}},
+		}
+		fieldTree := map[string]*Field{
+			"spec": {Name: "spec", Type: "PodSpec", Children: map[string]*Field{
+				"containers": {Name: "containers", Type: "[]Container", Prefix: []string{"spec"}, Children: map[string]*Field{
+					"image": {Name: "image", Type: "string", Prefix: []string{"spec", "containers"}},
+					"args":  {Name: "args", Type: "[]string", Prefix: []string{"spec", "containers"}},
+				}},
+			}},
+		}
+
+		var containers *Node
+		BeforeEach(func() {
+			containers = CreateNodeTree(fieldTree, objs, nil)["spec"].Children()["containers"]
+		})
+
+		It("should keep only the wildcard subtree and join its values", func() {
+			Expect(containers.ToggleAggregate()).To(BeTrue())
+			Expect(containers.Children()).To(HaveLen(1))
+
+			image := containers.Children()["*"].Children()["image"]
+			Expect(ValStr(image, objs[0])).To(Equal("nginx, sidecar"))
+			Expect(ValStr(image, objs[1])).To(Equal(MissingValue()))
+
+			Expect(containers.ToggleAggregate()).To(BeTrue())
+			Expect(containers.Children()).To(HaveLen(3))
+			Expect(ValStr(image, objs[0])).To(Equal("nginx,sidecar"))
+		})
+
+		It("should make an array of primitives a pickable leaf", func() {
+			args := containers.Children()["0"].Children()["args"]
+			Expect(args.Pickable(objs)).To(BeFalse())
+
+			args.ToggleAggregate()
+			Expect(args.Foldable()).To(BeFalse())
+			Expect(args.Pickable(objs)).To(BeTrue())
+			Expect(ValStr(args, objs[0])).To(Equal("-v"))
+		})
+
+		It("should not toggle non-array fields", func() {
+			image := containers.Children()["*"].Children()["image"]
+			Expect(image.ToggleAggregate()).To(BeFalse())
+		})
+
+		It("should keep the mode when objects are updated", func() {
+			nodes := CreateNodeTree(fieldTree, objs, nil)
+			nodes["spec"].Children()["containers"].ToggleAggregate()
+
+			nodes = UpdateNodeTree(nodes, fieldTree, objs, nil)
+			containers := nodes["spec"].Children()["containers"]
+			Expect(containers.Aggregated).To(BeTrue())
+			Expect(ValStr(containers.Children()["*"].Children()["image"], objs[0])).To(Equal("nginx, sidecar"))
+		})
+	})
+
+	Describe("UpdateNodeTree", func() {
+		fieldTree := map[string]*Field{
+			"spec": {Name: "spec", Type: "PodSpec", Children: map[string]*Field{
+				"containers": {Name: "containers", Type: "[]Container", Prefix: []string{"spec"}, Children: map[string]*Field{
+					"image": {Name: "image", Type: "string", Prefix: []string{"spec", "containers"}},
+				}},
+			}},
+		}
+		podWith := func(images ...string) *unstructured.Unstructured {
+			containers := []interface{}{}
+			for _, image := range images {
+				containers = append(containers, map[string]interface{}{"image": image})
+			}
+			return &unstructured.Unstructured{Object: map[string]interface{}{
+				"spec": map[string]interface{}{"containers": containers},
+			}}
+		}
+
+		It("should leave an array empty in every object without children", func() {
+			objs := []*unstructured.Unstructured{podWith(), podWith()}
+			containers := CreateNodeTree(fieldTree, objs, nil)["spec"].Children()["containers"]
+			Expect(containers.Children()).To(BeEmpty())
+			Expect(containers.Foldable()).To(BeFalse())
+			Expect(containers.Renderable(objs)).To(BeFalse())
+
+			containers = UpdateNodeTree(nil, fieldTree, objs, nil)["spec"].Children()["containers"]
+			Expect(containers.Children()).To(BeEmpty())
+		})
+
+		It("should add the indexes of an array grown between updates", func() {
+			nodes := CreateNodeTree(fieldTree, []*unstructured.Unstructured{podWith("nginx")}, nil)
+			nodes["spec"].Children()["containers"].Children()["0"].Expanded = true
+
+			objs := []*unstructured.Unstructured{podWith("nginx", "sidecar")}
+			Expect(func() { nodes = UpdateNodeTree(nodes, fieldTree, objs, nil) }).NotTo(Panic())
+
+			containers := nodes["spec"].Children()["containers"]
+			Expect(containers.Children()).To(HaveKey("1"))
+			Expect(containers.Children()["0"].Expanded).To(BeTrue())
+			Expect(ValStr(containers.Children()["1"].Children()["image"], objs[0])).To(Equal("sidecar"))
+		})
+	})
+
+	Describe("Value lookup", func() {
+		obj := map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name":   "web",
+				"labels": map[string]interface{}{"app": "web"},
+			},
+			"spec": map[string]interface{}{
+				"containers": []interface{}{
+					map[string]interface{}{"name": "app", "args": []interface{}{"-v"}},
+				},
+				"hostname":  "",
+				"dnsConfig": nil,
+			},
+		}
+
+		DescribeTable("getNestedValue",
+			func(path []string, want interface{}, wantFound, wantErr bool) {
+				val, found, err := getNestedValue(obj, path...)
+				if wantErr {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+				Expect(found).To(Equal(wantFound))
+				if want == nil {
+					Expect(val).To(BeNil())
+				} else {
+					Expect(val).To(Equal(want))
+				}
+			},
+			Entry("an array index", []string{"spec", "containers", "0", "name"}, "app", true, false),
+			Entry("an index out of bounds", []string{"spec", "containers", "1", "name"}, nil, false, true),
+			Entry("a map key", []string{"metadata", "labels", "app"}, "web", true, false),
+			Entry("a map miss", []string{"metadata", "labels", "tier"}, nil, false, false),
+			Entry("an index into a map", []string{"metadata", "labels", "0"}, nil, false, true),
+			Entry("a key into an array", []string{"spec", "containers", "name"}, nil, false, true),
+			Entry("a key into a string", []string{"metadata", "name", "first"}, nil, false, true),
+			Entry("a wildcard", []string{"spec", "containers", "*", "name"}, []interface{}{"app"}, true, false),
+			Entry("an empty string", []string{"spec", "hostname"}, "", true, false),
+			Entry("an explicit null", []string{"spec", "dnsConfig"}, nil, true, false),
+		)
+
+		DescribeTable("LookupValStr",
+			func(path []string, want string, wantOK bool) {
+				val, ok := LookupValStr(NewPathNode(path), &unstructured.Unstructured{Object: obj})
+				Expect(ok).To(Equal(wantOK))
+				Expect(val).To(Equal(want))
+			},
+			Entry("an array index", []string{"spec", "containers", "0", "args", "0"}, "-v", true),
+			Entry("a map miss", []string{"metadata", "labels", "tier"}, "", false),
+			Entry("a type mismatch", []string{"spec", "containers", "name"}, "", false),
+			Entry("an empty string", []string{"spec", "hostname"}, `""`, true),
+			Entry("an explicit null", []string{"spec", "dnsConfig"}, "", false),
+		)
+	})
+
+	Describe("Wildcard paths", func() {
+		obj := map[string]interface{}{"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "True"},
+				map[string]interface{}{"type": "Initialized", "status": "True"},
+				map[string]interface{}{"status": "Unknown"},
+			},
+		}}
+
+		It("should collect the values under every element", func() {
+			val, found, err := getNestedValue(obj, jsonPathToFieldPath(".status.conditions[*].type")...)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal([]interface{}{"Ready", "Initialized"}))
+		})
+
+		It("should fail on a non-array value", func() {
+			_, _, err := getNestedValue(obj, "status", "*")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should join the collected values", func() {
+			node := &Node{name: "type", ancestors: []string{"status", "conditions", "*"}}
+			Expect(ValStr(node, &unstructured.Unstructured{Object: obj})).To(Equal("Ready,Initialized"))
+
+			node = &Node{name: "reason", ancestors: []string{"status", "conditions", "*"}}
+			Expect(ValStr(node, &unstructured.Unstructured{Object: obj})).To(Equal(MissingValue()))
+		})
+	})
 })
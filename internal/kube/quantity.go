@@ -0,0 +1,20 @@
+package kube
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// QuantitySortKey parses quantity-shaped values such as 250m or 1Gi to a comparable number,
+// plain numbers included; values are still rendered as they are
+func QuantitySortKey(s string) (float64, bool) {
+	if !strings.ContainsAny(s, "0123456789") { // ParseQuantity takes a lone sign, e.g. the missing value
+		return 0, false
+	}
+	q, err := resource.ParseQuantity(s)
+	if err != nil {
+		return 0, false
+	}
+	return q.AsApproximateFloat64(), true
+}
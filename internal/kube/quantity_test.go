@@ -0,0 +1,33 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuantitySortKey(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected float64
+		ok       bool
+	}{
+		{value: "500m", expected: 0.5, ok: true},
+		{value: "1", expected: 1, ok: true},
+		{value: "1Gi", expected: 1 << 30, ok: true},
+		{value: "1G", expected: 1e9, ok: true},
+		{value: "1.5", expected: 1.5, ok: true},
+		{value: "Running", ok: false},
+		{value: DefaultMissingValue, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			key, ok := QuantitySortKey(tt.value)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.InDelta(t, tt.expected, key, 1e-9)
+			}
+		})
+	}
+}
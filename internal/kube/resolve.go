@@ -0,0 +1,114 @@
+package kube
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sahilm/fuzzy"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const maxKindSuggestions = 5
+
+// UnknownKindError reports a kind argument that matches no served resource,
+// with the closest kinds to suggest
+type UnknownKindError struct {
+	Kind        string
+	Suggestions []string
+}
+
+func (e *UnknownKindError) Error() string {
+	if len(e.Suggestions) == 0 {
+		return fmt.Sprintf("unknown kind %q", e.Kind)
+	}
+	return fmt.Sprintf("unknown kind %q, did you mean: %s?", e.Kind, strings.Join(e.Suggestions, ", "))
+}
+
+// ResolveKindForContext resolves a kind given like kubectl does: a kind, its plural or short name,
+// optionally with the group as in deployments.apps, or exactly as group/version/kind (version/kind for core)
+// If contextName is empty, uses the current context
+func ResolveKindForContext(contextName, arg string) (schema.GroupVersionKind, error) {
+	var (
+		infos []GVKInfo
+		err   error
+	)
+	if strings.Contains(arg, "/") {
+		// any served version may be asked for, not only the preferred one
+		infos, err = GetAllGVKInfosForContext(contextName)
+	} else {
+		infos, err = GetGVKInfosForContext(contextName)
+	}
+	if err != nil {
+		return schema.GroupVersionKind{}, err
+	}
+	return resolveKind(infos, arg)
+}
+
+func resolveKind(infos []GVKInfo, arg string) (schema.GroupVersionKind, error) {
+	match := func(info GVKInfo) bool { return false }
+	if parts := strings.Split(arg, "/"); len(parts) > 1 {
+		if len(parts) == 2 {
+			parts = append([]string{""}, parts...)
+		}
+		if len(parts) == 3 {
+			match = func(info GVKInfo) bool {
+				return info.Group == parts[0] && info.Version == parts[1] && strings.EqualFold(info.Kind, parts[2])
+			}
+		}
+	} else {
+		name, group, qualified := strings.Cut(arg, ".")
+		match = func(info GVKInfo) bool {
+			if qualified && info.Group != group {
+				return false
+			}
+			return strings.EqualFold(info.Kind, name) ||
+				strings.EqualFold(info.Resource, name) ||
+				slices.ContainsFunc(info.ShortNames, func(s string) bool { return strings.EqualFold(s, name) })
+		}
+	}
+
+	// the core group wins a name served by several groups, e.g. events
+	var found *GVKInfo
+	for i, info := range infos {
+		if !match(info) {
+			continue
+		}
+		if found == nil || (found.Group != "" && info.Group == "") {
+			found = &infos[i]
+		}
+	}
+	if found != nil {
+		return found.GroupVersionKind, nil
+	}
+	return schema.GroupVersionKind{}, &UnknownKindError{Kind: arg, Suggestions: suggestKinds(infos, arg)}
+}
+
+// suggestKinds returns the kinds closest to arg, without repeating kinds served by several versions
+func suggestKinds(infos []GVKInfo, arg string) []string {
+	var kinds []string
+	for _, info := range infos {
+		if !slices.Contains(kinds, info.Kind) {
+			kinds = append(kinds, info.Kind)
+		}
+	}
+
+	// the kind part of group/version/kind, or the name of name.group
+	query := arg[strings.LastIndex(arg, "/")+1:]
+	query, _, _ = strings.Cut(query, ".")
+
+	var suggestions []string
+	for _, match := range fuzzy.Find(query, kinds) {
+		suggestions = append(suggestions, match.Str)
+	}
+	// then kinds within a longer typo, e.g. Pod for podd
+	for _, kind := range kinds {
+		if !slices.Contains(suggestions, kind) && len(fuzzy.Find(kind, []string{query})) > 0 {
+			suggestions = append(suggestions, kind)
+		}
+	}
+	if len(suggestions) > maxKindSuggestions {
+		suggestions = suggestions[:maxKindSuggestions]
+	}
+	return suggestions
+}
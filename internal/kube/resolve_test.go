@@ -0,0 +1,59 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestResolveKind(t *testing.T) {
+	pod := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	event := schema.GroupVersionKind{Version: "v1", Kind: "Event"}
+	eventsV1 := schema.GroupVersionKind{Group: "events.k8s.io", Version: "v1", Kind: "Event"}
+	deploy := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	infos := []GVKInfo{
+		{GroupVersionKind: eventsV1, Resource: "events", ShortNames: []string{"ev"}},
+		{GroupVersionKind: pod, Resource: "pods", ShortNames: []string{"po"}},
+		{GroupVersionKind: event, Resource: "events", ShortNames: []string{"ev"}},
+		{GroupVersionKind: deploy, Resource: "deployments", ShortNames: []string{"deploy"}},
+	}
+
+	tests := []struct {
+		arg  string
+		want schema.GroupVersionKind
+	}{
+		{"pod", pod},
+		{"Pod", pod},
+		{"pods", pod},
+		{"po", pod},
+		{"deployments.apps", deploy},
+		{"apps/v1/Deployment", deploy},
+		{"v1/pod", pod},
+		{"events", event},
+		{"events.events.k8s.io", eventsV1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			gvk, err := resolveKind(infos, tt.arg)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, gvk)
+		})
+	}
+
+	t.Run("suggests close kinds when unknown", func(t *testing.T) {
+		_, err := resolveKind(infos, "podd")
+		var unknown *UnknownKindError
+		require.ErrorAs(t, err, &unknown)
+		assert.Equal(t, []string{"Pod"}, unknown.Suggestions)
+		assert.EqualError(t, err, `unknown kind "podd", did you mean: Pod?`)
+	})
+
+	t.Run("does not guess another version", func(t *testing.T) {
+		_, err := resolveKind(infos, "apps/v1beta1/Deployment")
+		var unknown *UnknownKindError
+		require.ErrorAs(t, err, &unknown)
+		assert.Equal(t, []string{"Deployment"}, unknown.Suggestions)
+	})
+}
@@ -8,6 +8,8 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/watch"
@@ -36,12 +38,12 @@ func ResetEventMetrics() {
 }
 
 // EventType represents the type of watch event
-type EventType string
+type EventType = watch.EventType
 
 const (
-	EventAdded    EventType = "ADDED"
-	EventModified EventType = "MODIFIED"
-	EventDeleted  EventType = "DELETED"
+	EventAdded    = watch.Added
+	EventModified = watch.Modified
+	EventDeleted  = watch.Deleted
 )
 
 // WatchEvent represents a watch event with type and object
@@ -54,28 +56,75 @@ type WatchEvent struct {
 type emitMsg = WatchEvent
 
 type ResourceController struct {
-	contextName string // optional, for GUI multi-context support
-	client      dynamic.Interface
-	gvr         schema.GroupVersionResource
-	store       cache.Store
-	emitCh      chan emitMsg
-	doneCh      chan struct{} // signals that controller is closed (for event consumers)
-	closed      atomic.Bool   // guards trySend to prevent sends after close
+	contextName   string // optional, for GUI multi-context support
+	client        dynamic.Interface
+	gvr           schema.GroupVersionResource
+	namespace     string // empty for all namespaces
+	labelSelector string // narrows list/watch, empty for every object
+	fieldSelector string // e.g. status.phase=Running, filtered server-side
+	listLimit     int64  // caps Objects(), 0 for unlimited
+	limitWarned   atomic.Bool
+	store         cache.Store
+	emitCh        chan emitMsg
+	errCh         chan error    // list/watch failures, e.g. an expired token
+	doneCh        chan struct{} // signals that controller is closed (for event consumers)
+	closed        atomic.Bool   // guards trySend to prevent sends after close
+	sendMu        sync.RWMutex  // held by sends, so Close never closes emitCh under one
 
 	// nameCache stores object names by key to avoid race conditions during sorting.
 	// Updated synchronously by informer handlers, read by Objects().
 	nameCache   map[string]string
 	nameCacheMu sync.RWMutex
+
+	relay  *sharedInformer // fans the events of a shared informer out
+	shared *sharedInformer // set for acquired controllers, which read its owner
+}
+
+// ControllerOption narrows what a ResourceController informs
+type ControllerOption func(*ResourceController)
+
+// WithNamespace informs a single namespace, e.g. where listing across namespaces is forbidden by RBAC
+// If namespace is empty, informs all namespaces. Use only for namespaced resources.
+func WithNamespace(namespace string) ControllerOption {
+	return func(c *ResourceController) {
+		c.namespace = namespace
+	}
+}
+
+// WithLabelSelector informs only objects matching labelSelector
+// The selector is validated when Inform starts
+func WithLabelSelector(labelSelector string) ControllerOption {
+	return func(c *ResourceController) {
+		c.labelSelector = labelSelector
+	}
+}
+
+// WithFieldSelector informs only objects matching fieldSelector, e.g. status.phase=Running for Pods.
+// The selector is validated when Inform starts,
+// one the server does not support for the resource is reported to ErrorEmitted
+func WithFieldSelector(fieldSelector string) ControllerOption {
+	return func(c *ResourceController) {
+		c.fieldSelector = fieldSelector
+	}
+}
+
+// WithListLimit makes Objects() return at most limit objects,
+// warning on ErrorEmitted once more are informed. 0 is unlimited
+// The informer still lists and watches every selected object.
+func WithListLimit(limit int64) ControllerOption {
+	return func(c *ResourceController) {
+		c.listLimit = limit
+	}
 }
 
 // NewResourceController creates a controller for the current context (legacy, kept for TUI compatibility)
-func NewResourceController(gvr schema.GroupVersionResource) *ResourceController {
-	return NewResourceControllerForContext("", gvr)
+func NewResourceController(gvr schema.GroupVersionResource, opts ...ControllerOption) *ResourceController {
+	return NewResourceControllerForContext("", gvr, opts...)
 }
 
 // NewResourceControllerForContext creates a controller for the specified context
 // If contextName is empty, uses the current context
-func NewResourceControllerForContext(contextName string, gvr schema.GroupVersionResource) *ResourceController {
+func NewResourceControllerForContext(contextName string, gvr schema.GroupVersionResource, opts ...ControllerOption) *ResourceController {
 	client, err := DynamicClientForContext(contextName)
 	if err != nil {
 		panic(err)
@@ -85,14 +134,35 @@ func NewResourceControllerForContext(contextName string, gvr schema.GroupVersion
 		contextName, _ = GetCurrentContext()
 	}
 
-	return &ResourceController{
+	controller := newResourceController(contextName, gvr, opts...)
+	controller.client = client
+	return controller
+}
+
+func newResourceController(contextName string, gvr schema.GroupVersionResource, opts ...ControllerOption) *ResourceController {
+	controller := &ResourceController{
 		contextName: contextName,
-		client:      client,
 		gvr:         gvr,
 		emitCh:      make(chan emitMsg, 256),
+		errCh:       make(chan error, 16),
 		doneCh:      make(chan struct{}),
 		nameCache:   make(map[string]string),
 	}
+	for _, opt := range opts {
+		opt(controller)
+	}
+	return controller
+}
+
+// ListLimitExceededError warns that more objects are informed than Objects() returns,
+// the informer keeps running
+type ListLimitExceededError struct {
+	Limit int64
+	Count int
+}
+
+func (e *ListLimitExceededError) Error() string {
+	return fmt.Sprintf("%d objects exceed the list limit of %d, narrow them with a selector", e.Count, e.Limit)
 }
 
 // Context returns the context name this controller is connected to
@@ -100,17 +170,50 @@ func (i *ResourceController) Context() string {
 	return i.contextName
 }
 
+// Namespace returns the namespace this controller informs, empty for all namespaces
+func (i *ResourceController) Namespace() string {
+	return i.namespace
+}
+
+// LabelSelector returns the selector narrowing this controller, empty for every object
+func (i *ResourceController) LabelSelector() string {
+	return i.labelSelector
+}
+
+// FieldSelector returns the field selector narrowing the objects, empty for every object
+func (i *ResourceController) FieldSelector() string {
+	return i.fieldSelector
+}
+
+// GVR returns the resource this controller informs
+func (i *ResourceController) GVR() schema.GroupVersionResource {
+	return i.gvr
+}
+
 func (i *ResourceController) Objects() []*unstructured.Unstructured {
+	if i.shared != nil {
+		return i.shared.owner.Objects()
+	}
+
 	// Get keys from store first to avoid reading from object maps during sort.
 	// This prevents race conditions with concurrent informer updates.
 	keys := i.store.ListKeys()
 
-	// Sort keys using cached names (avoid reading from objects)
+	// Sort keys by namespace, taken from the "namespace/name" key,
+	// then by cached names (avoid reading from objects)
 	i.nameCacheMu.RLock()
-	sort.Slice(keys, func(a, b int) bool {
+	sort.SliceStable(keys, func(a, b int) bool {
+		nsA, _, _ := cache.SplitMetaNamespaceKey(keys[a])
+		nsB, _, _ := cache.SplitMetaNamespaceKey(keys[b])
+		if nsA != nsB {
+			return nsA < nsB
+		}
 		return i.nameCache[keys[a]] < i.nameCache[keys[b]]
 	})
 	i.nameCacheMu.RUnlock()
+	if i.listLimit > 0 && int64(len(keys)) > i.listLimit {
+		keys = keys[:i.listLimit]
+	}
 
 	// Retrieve objects by sorted keys
 	objs := make([]*unstructured.Unstructured, 0, len(keys))
@@ -127,18 +230,47 @@ func (i *ResourceController) Objects() []*unstructured.Unstructured {
 	return objs
 }
 
+// Inform starts informing and waits until the objects are cached, the informer runs
+// until the returned channel or the controller is closed
 func (i *ResourceController) Inform() (chan struct{}, error) {
-	lw := &cache.ListWatch{
-		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
-			return i.client.Resource(i.gvr).Namespace("").List(context.Background(), options)
-		},
-		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
-			return i.client.Resource(i.gvr).Namespace("").Watch(context.Background(), options)
-		},
+	stop, run, hasSynced, err := i.start()
+	if err != nil {
+		return nil, err
+	}
+	if !cache.WaitForCacheSync(run, hasSynced) {
+		close(stop)
+		return nil, fmt.Errorf("failed to sync cache")
+	}
+	return stop, nil
+}
+
+// InformAsync starts informing as Inform without waiting, synced is closed once the objects are cached.
+// Objects() and the events grow while listing, synced stays open if the informer stops first
+func (i *ResourceController) InformAsync() (stop chan struct{}, synced <-chan struct{}, err error) {
+	stop, run, hasSynced, err := i.start()
+	if err != nil {
+		return nil, nil, err
+	}
+	done := make(chan struct{})
+	go func() {
+		if cache.WaitForCacheSync(run, hasSynced) {
+			close(done)
+		}
+	}()
+	return stop, done, nil
+}
+
+// start runs the informer until stop or the controller is closed, which closes run
+func (i *ResourceController) start() (stop chan struct{}, run <-chan struct{}, hasSynced cache.InformerSynced, err error) {
+	if _, err := labels.Parse(i.labelSelector); err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid label selector %q: %w", i.labelSelector, err)
+	}
+	if _, err := fields.ParseSelector(i.fieldSelector); err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid field selector %q: %w", i.fieldSelector, err)
 	}
 
 	options := cache.InformerOptions{
-		ListerWatcher: lw,
+		ListerWatcher: i.listWatch(),
 		ObjectType:    &unstructured.Unstructured{},
 		Handler: cache.ResourceEventHandlerFuncs{
 			AddFunc: func(obj interface{}) {
@@ -150,9 +282,11 @@ func (i *ResourceController) Inform() (chan struct{}, error) {
 				key, _ := cache.MetaNamespaceKeyFunc(u)
 				i.nameCacheMu.Lock()
 				i.nameCache[key] = u.GetName()
+				count := len(i.nameCache)
 				i.nameCacheMu.Unlock()
 
 				i.trySend(emitMsg{Type: EventAdded, Obj: u})
+				i.warnOverLimit(count)
 			},
 			UpdateFunc: func(oldObj, newObj interface{}) {
 				n, ok := newObj.(*unstructured.Unstructured)
@@ -201,22 +335,59 @@ func (i *ResourceController) Inform() (chan struct{}, error) {
 	)
 	i.store = store
 
-	stop := make(chan struct{})
-	go controller.Run(stop)
+	// the informer runs until either the returned channel or the controller is closed
+	stop = make(chan struct{})
+	running := make(chan struct{})
+	go func() {
+		select {
+		case <-stop:
+		case <-i.doneCh:
+		}
+		close(running)
+	}()
+	go controller.Run(running)
 
-	if !cache.WaitForCacheSync(stop, controller.HasSynced) {
-		close(stop)
-		return nil, fmt.Errorf("failed to sync cache")
-	}
+	return stop, running, controller.HasSynced, nil
+}
 
-	return stop, nil
+// listWatch lists and watches the selected objects. The reflector pages the lists itself,
+// following the rules of its ListPager for resource versions and continue tokens.
+// It retries failed calls on its own and only logs them, so they are reported here
+// (InformerOptions has no WatchErrorHandler)
+func (i *ResourceController) listWatch() *cache.ListWatch {
+	return &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.LabelSelector = i.labelSelector
+			options.FieldSelector = i.fieldSelector
+			list, err := i.client.Resource(i.gvr).Namespace(i.namespace).List(context.Background(), options)
+			if err != nil {
+				i.trySendErr(err)
+			}
+			return list, err
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.LabelSelector = i.labelSelector
+			options.FieldSelector = i.fieldSelector
+			w, err := i.client.Resource(i.gvr).Namespace(i.namespace).Watch(context.Background(), options)
+			if err != nil {
+				i.trySendErr(err)
+			}
+			return w, err
+		},
+	}
 }
 
-// WatchEvents returns a read-only channel of watch events
+// WatchEvents returns a read-only channel of watch events, closed by Close
 func (i *ResourceController) WatchEvents() <-chan WatchEvent {
 	return i.emitCh
 }
 
+// ErrorEmitted returns a read-only channel of list/watch errors,
+// after which the objects may be stale until the informer recovers
+func (i *ResourceController) ErrorEmitted() <-chan error {
+	return i.errCh
+}
+
 // Deprecated: use WatchEvents instead
 func (i *ResourceController) EventEmitted() <-chan emitMsg {
 	return i.emitCh
@@ -226,6 +397,15 @@ func (i *ResourceController) EventEmitted() <-chan emitMsg {
 // If the channel buffer is full or controller is closed, the event is dropped.
 // This is safe for Kubernetes watch events since they send full object state.
 func (i *ResourceController) trySend(msg emitMsg) {
+	if i.relay != nil {
+		if !i.closed.Load() {
+			i.relay.send(msg)
+		}
+		return
+	}
+
+	i.sendMu.RLock()
+	defer i.sendMu.RUnlock()
 	if i.closed.Load() {
 		return
 	}
@@ -242,18 +422,48 @@ func (i *ResourceController) trySend(msg emitMsg) {
 	}
 }
 
+// trySendErr reports err without blocking, dropping it when nobody keeps up
+func (i *ResourceController) trySendErr(err error) {
+	if i.closed.Load() {
+		return
+	}
+	if i.relay != nil {
+		i.relay.sendErr(err)
+		return
+	}
+	select {
+	case i.errCh <- err:
+	default:
+	}
+}
+
+// warnOverLimit reports once that count objects exceed the list limit
+func (i *ResourceController) warnOverLimit(count int) {
+	if i.listLimit > 0 && int64(count) > i.listLimit && i.limitWarned.CompareAndSwap(false, true) {
+		i.trySendErr(&ListLimitExceededError{Limit: i.listLimit, Count: count})
+	}
+}
+
 // Done returns a channel that is closed when the controller is closed.
 // Use this to detect when to stop consuming events from WatchEvents().
 func (i *ResourceController) Done() <-chan struct{} {
 	return i.doneCh
 }
 
-// Close marks the controller as closed and signals consumers to stop.
-// After Close is called, new events will be dropped.
+// Close stops the informer, closes WatchEvents and signals consumers to stop.
+// The channel returned by Inform may still be closed afterwards.
 // It is safe to call Close multiple times (subsequent calls are no-ops).
 func (i *ResourceController) Close() {
-	// Use atomic.Bool to ensure we only close doneCh once
-	if i.closed.CompareAndSwap(false, true) {
+	i.sendMu.Lock()
+	// Use atomic.Bool to ensure we only close the channels once
+	closing := i.closed.CompareAndSwap(false, true)
+	if closing {
 		close(i.doneCh)
+		close(i.emitCh)
+	}
+	i.sendMu.Unlock()
+
+	if closing && i.shared != nil {
+		i.shared.release(i)
 	}
 }
@@ -1,13 +1,31 @@
 package kube
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"sync"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/pager"
 )
 
 var _ = Describe("ResourceController", func() {
@@ -371,3 +389,424 @@ var _ = Describe("ResourceController", func() {
 		})
 	})
 })
+
+var _ = Describe("listWatch", func() {
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+	// serves pods in pages of the limit, rejecting a resource version with a continue token as the API server does
+	newServer := func(requests *[]url.Values) *httptest.Server {
+		pages := map[string]struct {
+			names []string
+			next  string
+		}{
+			"":   {[]string{"a", "b"}, "c1"},
+			"c1": {[]string{"c", "d"}, "c2"},
+			"c2": {[]string{"e"}, ""},
+		}
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			*requests = append(*requests, query)
+			w.Header().Set("Content-Type", "application/json")
+			if query.Get("continue") != "" && (query.Get("resourceVersion") != "" || query.Get("resourceVersionMatch") != "") {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"BadRequest","code":400,`+
+					`"message":"specifying resource version is not allowed when using continue"}`)
+				return
+			}
+
+			page := pages[query.Get("continue")]
+			items := make([]string, 0, len(page.names))
+			for _, name := range page.names {
+				items = append(items, fmt.Sprintf(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":%q}}`, name))
+			}
+			fmt.Fprintf(w, `{"apiVersion":"v1","kind":"PodList","metadata":{"resourceVersion":"100","continue":%q},"items":[%s]}`,
+				page.next, strings.Join(items, ","))
+		}))
+	}
+
+	It("should list with a resource version across the pages of the reflector's pager", func() {
+		var requests []url.Values
+		server := newServer(&requests)
+		defer server.Close()
+		client, err := dynamic.NewForConfig(&rest.Config{Host: server.URL})
+		Expect(err).NotTo(HaveOccurred())
+		controller := newResourceController("test", gvr, WithLabelSelector("app=web"))
+		controller.client = client
+
+		p := pager.New(pager.SimplePageFunc(controller.listWatch().ListFunc))
+		p.PageSize = 2
+		obj, _, err := p.List(context.Background(), metav1.ListOptions{
+			ResourceVersion:      "100",
+			ResourceVersionMatch: metav1.ResourceVersionMatchNotOlderThan,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(meta.ExtractList(obj)).To(HaveLen(5))
+
+		Expect(requests).To(HaveLen(3))
+		Expect(requests[0].Get("resourceVersion")).To(Equal("100"))
+		for _, query := range requests {
+			Expect(query.Get("limit")).To(Equal("2"))
+			Expect(query.Get("labelSelector")).To(Equal("app=web"))
+		}
+	})
+})
+
+// newFakeController returns a controller informing gvr from a fake client serving objs
+func newFakeController(gvr schema.GroupVersionResource, listKind string, objs []runtime.Object, opts ...ControllerOption) (*ResourceController, *dynamicfake.FakeDynamicClient) {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: listKind},
+		objs...,
+	)
+	controller := newResourceController("", gvr, opts...)
+	controller.client = client
+	return controller, client
+}
+
+var _ = Describe("Namespace", func() {
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+	newPod := func(namespace, name string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("Pod")
+		obj.SetNamespace(namespace)
+		obj.SetName(name)
+		return obj
+	}
+
+	pods := []runtime.Object{
+		newPod("kube-system", "coredns"),
+		newPod("default", "web"),
+		newPod("default", "api"),
+	}
+
+	namespacedNames := func(objs []*unstructured.Unstructured) []string {
+		var result []string
+		for _, obj := range objs {
+			result = append(result, obj.GetNamespace()+"/"+obj.GetName())
+		}
+		return result
+	}
+
+	It("should inform only the namespace", func() {
+		controller, _ := newFakeController(gvr, "PodList", pods, WithNamespace("default"))
+		stop, err := controller.Inform()
+		Expect(err).NotTo(HaveOccurred())
+		defer close(stop)
+
+		Expect(namespacedNames(controller.Objects())).To(Equal([]string{"default/api", "default/web"}))
+	})
+
+	It("should inform all namespaces sorted by namespace then name", func() {
+		controller, _ := newFakeController(gvr, "PodList", pods)
+		stop, err := controller.Inform()
+		Expect(err).NotTo(HaveOccurred())
+		defer close(stop)
+
+		Expect(namespacedNames(controller.Objects())).To(Equal([]string{
+			"default/api",
+			"default/web",
+			"kube-system/coredns",
+		}))
+	})
+})
+
+var _ = Describe("Label selector", func() {
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+	newPod := func(name string, podLabels map[string]string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("Pod")
+		obj.SetNamespace("default")
+		obj.SetName(name)
+		obj.SetLabels(podLabels)
+		return obj
+	}
+
+	pods := []runtime.Object{
+		newPod("foo", map[string]string{"app": "foo"}),
+		newPod("bar", map[string]string{"app": "bar"}),
+	}
+
+	It("should inform only matching objects", func() {
+		controller, _ := newFakeController(gvr, "PodList", pods, WithLabelSelector("app=foo"))
+		stop, err := controller.Inform()
+		Expect(err).NotTo(HaveOccurred())
+		defer close(stop)
+
+		objs := controller.Objects()
+		Expect(objs).To(HaveLen(1))
+		Expect(objs[0].GetName()).To(Equal("foo"))
+	})
+
+	It("should inform every object without a selector", func() {
+		controller, _ := newFakeController(gvr, "PodList", pods)
+		stop, err := controller.Inform()
+		Expect(err).NotTo(HaveOccurred())
+		defer close(stop)
+
+		Expect(controller.Objects()).To(HaveLen(2))
+	})
+
+	It("should fail to inform with an invalid selector", func() {
+		controller, _ := newFakeController(gvr, "PodList", pods, WithLabelSelector("app in (foo"))
+		_, err := controller.Inform()
+		Expect(err).To(MatchError(ContainSubstring("invalid label selector")))
+	})
+})
+
+var _ = Describe("Field selector", func() {
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+	It("should send the selector on list and watch", func() {
+		controller, client := newFakeController(gvr, "PodList", nil, WithFieldSelector("status.phase=Running"))
+		stop, err := controller.Inform()
+		Expect(err).NotTo(HaveOccurred())
+		defer close(stop)
+
+		Eventually(func() []string {
+			var verbs []string
+			for _, action := range client.Actions() {
+				var selector fields.Selector
+				switch action := action.(type) {
+				case k8stesting.ListAction:
+					selector = action.GetListRestrictions().Fields
+				case k8stesting.WatchAction:
+					selector = action.GetWatchRestrictions().Fields
+				default:
+					continue
+				}
+				Expect(selector.String()).To(Equal("status.phase=Running"))
+				verbs = append(verbs, action.GetVerb())
+			}
+			return verbs
+		}).Should(ContainElements("list", "watch"))
+	})
+
+	It("should fail to inform with an invalid selector", func() {
+		controller, _ := newFakeController(gvr, "PodList", nil, WithFieldSelector("status.phase"))
+		_, err := controller.Inform()
+		Expect(err).To(MatchError(ContainSubstring("invalid field selector")))
+	})
+
+	It("should report a selector the server rejects", func() {
+		controller, client := newFakeController(gvr, "PodList", nil, WithFieldSelector("spec.unknown=x"))
+		client.PrependWatchReactor("pods", func(action k8stesting.Action) (bool, watch.Interface, error) {
+			return true, nil, apierrors.NewBadRequest(`field label not supported: spec.unknown`)
+		})
+		stop, err := controller.Inform()
+		Expect(err).NotTo(HaveOccurred())
+		defer close(stop)
+
+		var watchErr error
+		Eventually(controller.ErrorEmitted()).Should(Receive(&watchErr))
+		Expect(apierrors.IsBadRequest(watchErr)).To(BeTrue())
+	})
+})
+
+var _ = Describe("List limit", func() {
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+	var configMaps []runtime.Object
+	for _, name := range []string{"c", "a", "b"} {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("ConfigMap")
+		obj.SetNamespace("default")
+		obj.SetName(name)
+		configMaps = append(configMaps, obj)
+	}
+
+	It("should cap the objects and warn once", func() {
+		controller, _ := newFakeController(gvr, "ConfigMapList", configMaps, WithListLimit(2))
+		stop, err := controller.Inform()
+		Expect(err).NotTo(HaveOccurred())
+		defer close(stop)
+
+		objs := controller.Objects()
+		Expect(objs).To(HaveLen(2))
+		Expect(objs[0].GetName()).To(Equal("a"))
+		Expect(objs[1].GetName()).To(Equal("b"))
+
+		var warning error
+		Eventually(controller.ErrorEmitted()).Should(Receive(&warning))
+		var limitErr *ListLimitExceededError
+		Expect(errors.As(warning, &limitErr)).To(BeTrue())
+		Expect(limitErr.Limit).To(Equal(int64(2)))
+		Consistently(controller.ErrorEmitted()).ShouldNot(Receive())
+	})
+
+	It("should return every object without a limit", func() {
+		controller, _ := newFakeController(gvr, "ConfigMapList", configMaps)
+		stop, err := controller.Inform()
+		Expect(err).NotTo(HaveOccurred())
+		defer close(stop)
+
+		Expect(controller.Objects()).To(HaveLen(3))
+		Consistently(controller.ErrorEmitted()).ShouldNot(Receive())
+	})
+})
+
+var _ = Describe("ErrorEmitted", func() {
+	It("should report watch failures", func() {
+		gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+		client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+			runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{gvr: "PodList"},
+		)
+		client.PrependWatchReactor("pods", func(action k8stesting.Action) (bool, watch.Interface, error) {
+			return true, nil, apierrors.NewUnauthorized("token expired")
+		})
+
+		controller := &ResourceController{
+			client:    client,
+			gvr:       gvr,
+			emitCh:    make(chan emitMsg, 256),
+			errCh:     make(chan error, 16),
+			doneCh:    make(chan struct{}),
+			nameCache: make(map[string]string),
+		}
+		stop, err := controller.Inform()
+		Expect(err).NotTo(HaveOccurred())
+		defer close(stop)
+
+		var watchErr error
+		Eventually(controller.ErrorEmitted()).Should(Receive(&watchErr))
+		Expect(apierrors.IsUnauthorized(watchErr)).To(BeTrue())
+	})
+})
+
+var _ = Describe("Close", func() {
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+	var (
+		controller *ResourceController
+		watcher    *watch.FakeWatcher
+	)
+
+	BeforeEach(func() {
+		var client *dynamicfake.FakeDynamicClient
+		controller, client = newFakeController(gvr, "PodList", nil)
+		fake := watch.NewFake()
+		watcher = fake
+		client.PrependWatchReactor("pods", func(action k8stesting.Action) (bool, watch.Interface, error) {
+			return true, fake, nil
+		})
+	})
+
+	It("should unblock a consumer ranging over the events and be safe to call twice", func() {
+		_, err := controller.Inform()
+		Expect(err).NotTo(HaveOccurred())
+
+		ranged := make(chan struct{})
+		go func() {
+			defer close(ranged)
+			for range controller.WatchEvents() {
+			}
+		}()
+
+		controller.Close()
+		Expect(func() { controller.Close() }).NotTo(Panic())
+		Eventually(ranged).Should(BeClosed())
+		Expect(controller.Done()).To(BeClosed())
+	})
+
+	It("should stop the informer, leaving the stop channel to close", func() {
+		stop, err := controller.Inform()
+		Expect(err).NotTo(HaveOccurred())
+
+		controller.Close()
+		Eventually(watcher.IsStopped).Should(BeTrue())
+		Expect(func() { close(stop) }).NotTo(Panic())
+	})
+
+	It("should drop events sent after close", func() {
+		controller.Close()
+		Expect(func() { controller.trySend(emitMsg{Type: EventAdded}) }).NotTo(Panic())
+	})
+})
+
+var _ = Describe("WatchEvents", func() {
+	It("should carry the type of every change", func() {
+		gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+		pod := &unstructured.Unstructured{}
+		pod.SetAPIVersion("v1")
+		pod.SetKind("Pod")
+		pod.SetNamespace("default")
+		pod.SetName("web")
+		controller, client := newFakeController(gvr, "PodList", nil)
+		_, err := controller.Inform()
+		Expect(err).NotTo(HaveOccurred())
+		defer controller.Close()
+
+		pods := client.Resource(gvr).Namespace("default")
+		_, err = pods.Create(context.Background(), pod, metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(controller.WatchEvents()).Should(Receive(HaveField("Type", EventAdded)))
+
+		pod.SetLabels(map[string]string{"app": "web"})
+		_, err = pods.Update(context.Background(), pod, metav1.UpdateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(controller.WatchEvents()).Should(Receive(HaveField("Type", EventModified)))
+
+		Expect(pods.Delete(context.Background(), "web", metav1.DeleteOptions{})).To(Succeed())
+		Eventually(controller.WatchEvents()).Should(Receive(HaveField("Type", EventDeleted)))
+		Expect(controller.Objects()).To(BeEmpty())
+	})
+})
+
+var _ = Describe("InformAsync", func() {
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+	var (
+		controller *ResourceController
+		release    chan struct{}
+	)
+
+	BeforeEach(func() {
+		pod := &unstructured.Unstructured{}
+		pod.SetAPIVersion("v1")
+		pod.SetKind("Pod")
+		pod.SetNamespace("default")
+		pod.SetName("foo")
+		var client *dynamicfake.FakeDynamicClient
+		controller, client = newFakeController(gvr, "PodList", []runtime.Object{pod})
+		blocked := make(chan struct{})
+		release = blocked
+		// the list hangs until released, as on a large cluster
+		client.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			<-blocked
+			return false, nil, nil
+		})
+		DeferCleanup(controller.Close)
+	})
+
+	It("should return before the objects are cached and signal once they are", func() {
+		stop, synced, err := controller.InformAsync()
+		Expect(err).NotTo(HaveOccurred())
+		defer close(stop)
+		Consistently(synced, "50ms").ShouldNot(BeClosed())
+
+		close(release)
+		Eventually(synced).Should(BeClosed())
+		Expect(controller.Objects()).To(HaveLen(1))
+	})
+
+	It("should not signal when closed before the objects are cached", func() {
+		_, synced, err := controller.InformAsync()
+		Expect(err).NotTo(HaveOccurred())
+
+		controller.Close()
+		close(release)
+		Consistently(synced, "50ms").ShouldNot(BeClosed())
+	})
+
+	It("should fail right away with an invalid selector", func() {
+		controller.labelSelector = "app in (foo"
+		_, _, err := controller.InformAsync()
+		Expect(err).To(MatchError(ContainSubstring("invalid label selector")))
+		close(release)
+	})
+})
@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/go-openapi/jsonreference"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -21,11 +23,51 @@ func GetDocument(gvr schema.GroupVersionResource) (*spec3.OpenAPI, error) {
 	return getDocumentForContext("", gvr)
 }
 
+var (
+	// parsed OpenAPI documents cached per context and document path, shared by the kinds of an API group version
+	documentsMu sync.RWMutex
+	documents   = make(map[string]map[string]*spec3.OpenAPI)
+)
+
+// InvalidateDocumentCache drops the cached OpenAPI documents of a context
+func InvalidateDocumentCache(contextName string) {
+	documentsMu.Lock()
+	delete(documents, contextName)
+	documentsMu.Unlock()
+}
+
+func invalidateDocuments() {
+	documentsMu.Lock()
+	documents = make(map[string]map[string]*spec3.OpenAPI)
+	documentsMu.Unlock()
+}
+
 // getDocumentForContext retrieves the OpenAPI document for a GVR from the specified context
 // If contextName is empty, uses the current context
+// Documents are cached and shared, callers must not modify them
 func getDocumentForContext(contextName string, gvr schema.GroupVersionResource) (*spec3.OpenAPI, error) {
-	var result *spec3.OpenAPI
+	path := getDocumentPath(gvr)
+	documentsMu.RLock()
+	document, ok := documents[contextName][path]
+	documentsMu.RUnlock()
+	if ok {
+		return document, nil
+	}
 
+	document, err := fetchDocument(contextName, path)
+	if err != nil {
+		return nil, err
+	}
+	documentsMu.Lock()
+	if documents[contextName] == nil {
+		documents[contextName] = make(map[string]*spec3.OpenAPI)
+	}
+	documents[contextName][path] = document
+	documentsMu.Unlock()
+	return document, nil
+}
+
+func fetchDocument(contextName string, path string) (*spec3.OpenAPI, error) {
 	discoveryClient, err := DiscoveryClientForContext(contextName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get discovery client: %v", err)
@@ -37,7 +79,7 @@ func getDocumentForContext(contextName string, gvr schema.GroupVersionResource)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get openapi paths: %v", err)
 	}
-	schemabytes, err := paths[getDocumentPath(gvr)].Schema(runtime.ContentTypeJSON)
+	schemabytes, err := paths[path].Schema(runtime.ContentTypeJSON)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get openapi schema: %v", err)
 	}
@@ -45,9 +87,8 @@ func getDocumentForContext(contextName string, gvr schema.GroupVersionResource)
 	if err := json.Unmarshal(schemabytes, &document); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal schema: %v", err)
 	}
-	result = document
 
-	return result, nil
+	return document, nil
 }
 
 func getPathPrefix(gvr schema.GroupVersionResource) string {
@@ -94,9 +135,46 @@ func CreateFieldTree(gvk schema.GroupVersionKind) (map[string]*Field, error) {
 	return CreateFieldTreeForContext("", gvk)
 }
 
+var (
+	// field trees memoized per context and GVK, the OpenAPI document is slow to fetch and parse
+	fieldTreesMu sync.RWMutex
+	fieldTrees   = make(map[string]map[string]*Field)
+)
+
+func fieldTreeKey(contextName string, gvk schema.GroupVersionKind) string {
+	return contextName + "/" + gvk.String()
+}
+
+// InvalidateFieldTreeCache drops every memoized field tree, e.g. when the kubeconfig is reloaded
+func InvalidateFieldTreeCache() {
+	fieldTreesMu.Lock()
+	fieldTrees = make(map[string]map[string]*Field)
+	fieldTreesMu.Unlock()
+}
+
 // CreateFieldTreeForContext creates a field tree for a GVK from the specified context
 // If contextName is empty, uses the current context
+// Trees are memoized and shared, callers must not modify them
 func CreateFieldTreeForContext(contextName string, gvk schema.GroupVersionKind) (map[string]*Field, error) {
+	key := fieldTreeKey(contextName, gvk)
+	fieldTreesMu.RLock()
+	fields, ok := fieldTrees[key]
+	fieldTreesMu.RUnlock()
+	if ok {
+		return fields, nil
+	}
+
+	fields, err := createFieldTreeForContext(contextName, gvk)
+	if err != nil {
+		return nil, err
+	}
+	fieldTreesMu.Lock()
+	fieldTrees[key] = fields
+	fieldTreesMu.Unlock()
+	return fields, nil
+}
+
+func createFieldTreeForContext(contextName string, gvk schema.GroupVersionKind) (map[string]*Field, error) {
 	gvr, err := GetGVRForContext(contextName, gvk)
 	if err != nil {
 		return nil, err
@@ -109,24 +187,7 @@ func CreateFieldTreeForContext(contextName string, gvk schema.GroupVersionKind)
 	if err != nil {
 		return nil, err
 	}
-	history := make(map[string]bool)
-
-	// Get reference string
-	refString := schema.Ref.String()
-
-	// Circular reference detection (commented out for now)
-	// if refString != "" {
-	// 	if history[refString] {
-	// 		return nil, nil
-	// 	}
-	// 	history[refString] = true
-	// }
-
-	// Resolve schema reference if exists
-	if resolved := resolveRef(refString, document); resolved != nil {
-		schema = resolved
-	}
-
+	history := make(map[string]bool) // refs on the branch being walked
 	nodes, err := createFieldList(schema, []string{}, 0, document, history)
 	if err != nil {
 		return nil, err
@@ -145,16 +206,13 @@ func createFieldList(schema *spec.Schema, prefix []string, level int, document *
 
 	refString := schema.Ref.String()
 	if refString != "" {
+		// a ref already on the current branch is a cycle, stop descending
 		if history[refString] {
 			return nil, nil
 		}
+		// visited only while this branch is walked, so siblings may reuse the type
 		history[refString] = true
-	}
-
-	// copy history to pass for each branch
-	nextHistory := make(map[string]bool)
-	for k, v := range history {
-		nextHistory[k] = v
+		defer delete(history, refString)
 	}
 
 	resolvedSchema := schema
@@ -163,7 +221,7 @@ func createFieldList(schema *spec.Schema, prefix []string, level int, document *
 	}
 
 	for key, prop := range resolvedSchema.Properties {
-		children, err := createFieldList(&prop, append(prefix, key), level+1, document, nextHistory)
+		children, err := createFieldList(&prop, append(prefix, key), level+1, document, history)
 		if err != nil {
 			return nil, err
 		}
@@ -174,24 +232,33 @@ func createFieldList(schema *spec.Schema, prefix []string, level int, document *
 		result = nodes
 	}
 
+	// merge every sub-schema, explicitly defined properties win on collisions
 	for _, subSchema := range resolvedSchema.AllOf {
-		nodes, err := createFieldList(&subSchema, prefix, level, document, nextHistory)
+		subNodes, err := createFieldList(&subSchema, prefix, level, document, history)
 		if err != nil {
 			return nil, err
 		}
-		result = nodes
+		for key, node := range subNodes {
+			if _, defined := resolvedSchema.Properties[key]; defined {
+				continue
+			}
+			nodes[key] = node
+		}
+		if len(nodes) > 0 {
+			result = nodes
+		}
 	}
 
 	if resolvedSchema.Items != nil {
 		// HACK: special char might be needed such as `[]`?
-		nodes, err := createFieldList(resolvedSchema.Items.Schema, prefix, level+1, document, nextHistory)
+		nodes, err := createFieldList(resolvedSchema.Items.Schema, prefix, level+1, document, history)
 		if err != nil {
 			return nil, err
 		}
 		result = nodes
 	}
 	if resolvedSchema.AdditionalProperties != nil && resolvedSchema.AdditionalProperties.Schema != nil {
-		nodes, err := createFieldList(resolvedSchema.AdditionalProperties.Schema, prefix, level, document, nextHistory)
+		nodes, err := createFieldList(resolvedSchema.AdditionalProperties.Schema, prefix, level, document, history)
 		if err != nil {
 			return nil, err
 		}
@@ -234,6 +301,7 @@ func createField(name string, prefix []string, schema *spec.Schema, level int, d
 	}
 
 	result.Enum = extractEnum(&fieldSchema)
+	result.Default = fieldSchema.Default
 
 	return &result
 }
@@ -273,6 +341,17 @@ func typeGuess(schema *spec.Schema, document *spec3.OpenAPI) string {
 		return typeGuess(&schema.AllOf[0], document)
 	}
 
+	// union types such as int-or-string, e.g. integer|string
+	if unions := slices.Concat(schema.OneOf, schema.AnyOf); len(unions) > 0 && len(schema.Type) == 0 {
+		var types []string
+		for i := range unions {
+			if t := typeGuess(&unions[i], document); !slices.Contains(types, t) {
+				types = append(types, t)
+			}
+		}
+		return strings.Join(types, "|")
+	}
+
 	// 기본 타입
 	if len(schema.Type) > 0 {
 		if schema.Type[0] == "object" {
@@ -4,6 +4,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/spec3"
+	"k8s.io/kube-openapi/pkg/validation/spec"
 )
 
 func TestJsonPathToFieldPath(t *testing.T) {
@@ -61,3 +65,153 @@ func TestJsonPathToFieldPath(t *testing.T) {
 		})
 	}
 }
+
+func refSchema(name string) spec.Schema {
+	return spec.Schema{SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/components/schemas/" + name)}}
+}
+
+func stringSchema(description string) spec.Schema {
+	return spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}, Description: description}}
+}
+
+func TestCreateFieldListMergesAllOf(t *testing.T) {
+	document := &spec3.OpenAPI{
+		Components: &spec3.Components{
+			Schemas: map[string]*spec.Schema{
+				"test.Base": {SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"name":  stringSchema("from base"),
+						"owner": stringSchema("from base"),
+					},
+				}},
+				"test.Extra": {SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"replicas": {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}},
+					},
+				}},
+			},
+		},
+	}
+	root := &spec.Schema{SchemaProps: spec.SchemaProps{
+		Properties: map[string]spec.Schema{
+			"owner": {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}},
+		},
+		AllOf: []spec.Schema{refSchema("test.Base"), refSchema("test.Extra")},
+	}}
+
+	fields, err := createFieldList(root, []string{}, 0, document, map[string]bool{})
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"name", "owner", "replicas"}, fieldNames(fields))
+	assert.Equal(t, "string", fields["name"].Type)
+	assert.Equal(t, "integer", fields["replicas"].Type)
+	// the explicitly defined property wins over the sub-schema's
+	assert.Equal(t, "integer", fields["owner"].Type)
+}
+
+func TestTypeGuessUnion(t *testing.T) {
+	integer := spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"integer"}}}
+	str := spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}}}
+
+	oneOf := &spec.Schema{SchemaProps: spec.SchemaProps{OneOf: []spec.Schema{integer, str}}}
+	assert.Equal(t, "integer|string", typeGuess(oneOf, &spec3.OpenAPI{}))
+
+	anyOf := &spec.Schema{SchemaProps: spec.SchemaProps{AnyOf: []spec.Schema{integer, str, integer}}}
+	assert.Equal(t, "integer|string", typeGuess(anyOf, &spec3.OpenAPI{}))
+}
+
+func TestCreateFieldDefault(t *testing.T) {
+	root := &spec.Schema{SchemaProps: spec.SchemaProps{
+		Properties: map[string]spec.Schema{
+			"restartPolicy": {SchemaProps: spec.SchemaProps{Type: []string{"string"}, Default: "Always"}},
+			"nodeName":      stringSchema("no default"),
+		},
+	}}
+
+	fields, err := createFieldList(root, []string{}, 0, &spec3.OpenAPI{}, map[string]bool{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Always", fields["restartPolicy"].Default)
+	assert.Nil(t, fields["nodeName"].Default)
+}
+
+func TestFieldTreeCache(t *testing.T) {
+	t.Cleanup(InvalidateFieldTreeCache)
+	gvk := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	cached := map[string]*Field{"spec": {Name: "spec", Type: "PodSpec"}}
+	fieldTrees[fieldTreeKey("cached", gvk)] = cached
+
+	fields, err := CreateFieldTreeForContext("cached", gvk)
+	require.NoError(t, err)
+	assert.Equal(t, cached, fields)
+
+	InvalidateKubeconfigCache()
+	assert.Empty(t, fieldTrees)
+}
+
+func TestCreateFieldListCircularRef(t *testing.T) {
+	tree := refSchema("test.Tree")
+	document := &spec3.OpenAPI{
+		Components: &spec3.Components{
+			Schemas: map[string]*spec.Schema{
+				"test.Tree": {SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"name": stringSchema("node name"),
+						"children": {SchemaProps: spec.SchemaProps{
+							Type:  []string{"array"},
+							Items: &spec.SchemaOrArray{Schema: &tree},
+						}},
+						"left":  refSchema("test.Leaf"),
+						"right": refSchema("test.Leaf"),
+					},
+				}},
+				"test.Leaf": {SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"value": stringSchema("leaf value"),
+					},
+				}},
+			},
+		},
+	}
+	history := map[string]bool{}
+
+	fields, err := createFieldList(&tree, []string{}, 0, document, history)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"name", "children", "left", "right"}, fieldNames(fields))
+	// the self reference stops at the cycle
+	assert.Empty(t, fields["children"].Children)
+	// the same type still expands in sibling branches
+	assert.ElementsMatch(t, []string{"value"}, fieldNames(fields["left"].Children))
+	assert.ElementsMatch(t, []string{"value"}, fieldNames(fields["right"].Children))
+	// every ref is unmarked on unwind
+	assert.Empty(t, history)
+}
+
+func fieldNames(fields map[string]*Field) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestDocumentCache(t *testing.T) {
+	t.Cleanup(invalidateDocuments)
+	gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	cached := &spec3.OpenAPI{Version: "3.0.0"}
+	documents["cached"] = map[string]*spec3.OpenAPI{getDocumentPath(gvr): cached}
+	documents["other"] = map[string]*spec3.OpenAPI{getDocumentPath(gvr): cached}
+
+	// another kind of the group version shares the document
+	document, err := getDocumentForContext("cached", schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "statefulsets"})
+	require.NoError(t, err)
+	assert.Same(t, cached, document)
+
+	InvalidateClientCache("cached")
+	assert.NotContains(t, documents, "cached")
+	assert.Contains(t, documents, "other")
+
+	InvalidateKubeconfigCache()
+	assert.Empty(t, documents)
+}
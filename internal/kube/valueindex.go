@@ -0,0 +1,55 @@
+package kube
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ValueIndex memoizes whether nodes have a value in any of a set of objects,
+// so rendering a tree does not walk every object per line.
+// Make a new one when the objects change
+type ValueIndex struct {
+	objs   []*unstructured.Unstructured
+	valued map[valueKey]bool
+}
+
+// valueKey tells an array apart once joined, its values are looked up differently
+type valueKey struct {
+	node       *Node
+	aggregated bool
+}
+
+func NewValueIndex(objs []*unstructured.Unstructured) *ValueIndex {
+	return &ValueIndex{objs: objs, valued: make(map[valueKey]bool)}
+}
+
+// Objs returns the objects the index was made of
+func (ix *ValueIndex) Objs() []*unstructured.Unstructured {
+	return ix.objs
+}
+
+// Pickable is Node.Pickable over the objects of the index
+func (ix *ValueIndex) Pickable(n *Node) bool {
+	return n.pickable(ix.hasValue(n))
+}
+
+// Renderable is Node.Renderable over the objects of the index
+func (ix *ValueIndex) Renderable(n *Node) bool {
+	return n.renderable(ix.hasValue(n))
+}
+
+// Defaulted is Node.Defaulted over the objects of the index
+func (ix *ValueIndex) Defaulted(n *Node) bool {
+	return n.defaulted(ix.hasValue(n))
+}
+
+func (ix *ValueIndex) hasValue(n *Node) func() bool {
+	return func() bool {
+		key := valueKey{node: n, aggregated: n.Aggregated}
+		valued, ok := ix.valued[key]
+		if !ok {
+			valued = !n.allNil(ix.objs)
+			ix.valued[key] = valued
+		}
+		return valued
+	}
+}
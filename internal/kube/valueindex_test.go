@@ -0,0 +1,92 @@
+package kube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestValueIndex(t *testing.T) {
+	fields := map[string]*Field{
+		"spec": {Name: "spec", Type: "PodSpec", Children: map[string]*Field{
+			"nodeName":      {Name: "nodeName", Type: "string", Prefix: []string{"spec"}},
+			"restartPolicy": {Name: "restartPolicy", Type: "string", Prefix: []string{"spec"}, Default: "Always"},
+		}},
+	}
+	objs := []*unstructured.Unstructured{{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"nodeName": "node-1"},
+	}}}
+	spec := CreateNodeTree(fields, objs, nil)["spec"]
+	nodeName, restartPolicy := spec.Children()["nodeName"], spec.Children()["restartPolicy"]
+	ix := NewValueIndex(objs)
+
+	t.Run("agrees with the nodes", func(t *testing.T) {
+		for _, n := range []*Node{spec, nodeName, restartPolicy} {
+			assert.Equal(t, n.Pickable(objs), ix.Pickable(n), n.Name())
+			assert.Equal(t, n.Renderable(objs), ix.Renderable(n), n.Name())
+			assert.Equal(t, n.Defaulted(objs), ix.Defaulted(n), n.Name())
+		}
+	})
+
+	t.Run("keeps the values of its objects", func(t *testing.T) {
+		objs[0].Object["spec"] = map[string]interface{}{}
+		assert.True(t, ix.Pickable(nodeName), "memoized")
+		assert.False(t, NewValueIndex(objs).Pickable(nodeName))
+	})
+}
+
+// benchTree returns a tree of width object fields of width leaves each,
+// set in the last object only so looking a value up scans every object
+func benchTree(width, objCount int) (map[string]*Node, []*unstructured.Unstructured) {
+	fields := map[string]*Field{}
+	objs := make([]*unstructured.Unstructured, objCount)
+	for i := range objs {
+		objs[i] = &unstructured.Unstructured{Object: map[string]interface{}{}}
+	}
+	for i := range width {
+		name := fmt.Sprintf("f%d", i)
+		children := map[string]*Field{}
+		for j := range width {
+			leaf := fmt.Sprintf("l%d", j)
+			children[leaf] = &Field{Name: leaf, Type: "string", Prefix: []string{name}}
+		}
+		fields[name] = &Field{Name: name, Type: "Object", Children: children}
+	}
+	for name, field := range fields {
+		values := map[string]interface{}{}
+		for leaf := range field.Children {
+			values[leaf] = "v"
+		}
+		objs[len(objs)-1].Object[name] = values
+	}
+	return CreateNodeTree(fields, objs, nil), objs
+}
+
+// BenchmarkRenderPass checks every line of a tree as the nav renders it, once per keystroke
+func BenchmarkRenderPass(b *testing.B) {
+	nodes, objs := benchTree(20, 1000)
+	var leaves []*Node
+	for _, n := range nodes {
+		for _, leaf := range n.Children() {
+			leaves = append(leaves, leaf)
+		}
+	}
+
+	b.Run("objs", func(b *testing.B) {
+		for b.Loop() {
+			for _, leaf := range leaves {
+				_ = leaf.Renderable(objs) && leaf.Pickable(objs) && !leaf.Defaulted(objs)
+			}
+		}
+	})
+	b.Run("index", func(b *testing.B) {
+		ix := NewValueIndex(objs) // once per refresh of the objects
+		for b.Loop() {
+			for _, leaf := range leaves {
+				_ = ix.Renderable(leaf) && ix.Pickable(leaf) && !ix.Defaulted(leaf)
+			}
+		}
+	})
+}
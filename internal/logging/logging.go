@@ -0,0 +1,98 @@
+package logging
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/flavono123/kattle/internal/config"
+)
+
+// Level orders the `[LEVEL]` tags used in log messages, e.g. log.Printf("[WARN] ...")
+// Messages without a tag are treated as Info
+type Level int
+
+const (
+	Debug Level = iota
+	Info
+	Warn
+	Error
+)
+
+var levelTags = map[Level]string{
+	Debug: "[DEBUG]",
+	Info:  "[INFO]",
+	Warn:  "[WARN]",
+	Error: "[ERROR]",
+}
+
+// ParseLevel parses a level name case-insensitively
+func ParseLevel(name string) (Level, error) {
+	for level, tag := range levelTags {
+		if strings.EqualFold(strings.Trim(tag, "[]"), name) {
+			return level, nil
+		}
+	}
+	return Warn, fmt.Errorf("unknown log level %q", name)
+}
+
+// Setup redirects the standard logger to a rotating file so nothing is written
+// to the terminal while the TUI owns it. The returned closer flushes the file.
+func Setup(cfg config.LogConfig) (io.Closer, error) {
+	level, err := ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, err
+	}
+	// DEBUG=1 keeps working as the quick switch for verbose logs
+	if os.Getenv("DEBUG") != "" {
+		level = Debug
+	}
+
+	path := cfg.Path
+	if path == "" {
+		dir, err := config.Dir()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(dir, "kupid.log")
+	}
+
+	file, err := newRotatingFile(path, int64(cfg.MaxSizeMB)*1024*1024, cfg.MaxBackups)
+	if err != nil {
+		return nil, err
+	}
+
+	log.SetOutput(&levelWriter{out: file, min: level})
+	return file, nil
+}
+
+// SetupStderr writes the standard logger to stderr from level on, e.g. when Setup fails
+func SetupStderr(level Level) {
+	log.SetOutput(&levelWriter{out: os.Stderr, min: level})
+}
+
+// levelWriter drops log lines tagged below min
+type levelWriter struct {
+	out io.Writer
+	min Level
+}
+
+func (w *levelWriter) Write(p []byte) (int, error) {
+	if lineLevel(p) < w.min {
+		return len(p), nil
+	}
+	return w.out.Write(p)
+}
+
+func lineLevel(line []byte) Level {
+	for level, tag := range levelTags {
+		if bytes.Contains(line, []byte(tag)) {
+			return level
+		}
+	}
+	return Info
+}
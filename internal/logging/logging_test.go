@@ -0,0 +1,87 @@
+package logging
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLevelWriter(t *testing.T) {
+	tests := []struct {
+		name    string
+		min     Level
+		line    string
+		written bool
+	}{
+		{"debug dropped at warn", Warn, "2024/01/01 00:00:00 [DEBUG] goroutines=3\n", false},
+		{"untagged dropped at warn", Warn, "2024/01/01 00:00:00 started\n", false},
+		{"warn kept at warn", Warn, "2024/01/01 00:00:00 [WARN] Event dropped\n", true},
+		{"error kept at warn", Warn, "2024/01/01 00:00:00 [ERROR] watch failed\n", true},
+		{"untagged kept at info", Info, "2024/01/01 00:00:00 started\n", true},
+		{"debug kept at debug", Debug, "2024/01/01 00:00:00 [DEBUG] goroutines=3\n", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := &levelWriter{out: &buf, min: tt.min}
+
+			n, err := w.Write([]byte(tt.line))
+			if err != nil {
+				t.Fatalf("Write failed: %v", err)
+			}
+			if n != len(tt.line) {
+				t.Errorf("expected %d bytes reported, got %d", len(tt.line), n)
+			}
+			if (buf.Len() > 0) != tt.written {
+				t.Errorf("expected written=%v, got %q", tt.written, buf.String())
+			}
+		})
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	level, err := ParseLevel("WARN")
+	if err != nil || level != Warn {
+		t.Errorf("ParseLevel(WARN) = %v, %v", level, err)
+	}
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Error("expected error for unknown level")
+	}
+}
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kupid.log")
+	r, err := newRotatingFile(path, 10, 2)
+	if err != nil {
+		t.Fatalf("newRotatingFile failed: %v", err)
+	}
+	defer r.Close()
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		if _, err := r.Write([]byte(line)); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+
+	expected := map[string]string{
+		path:        "fourth\n",
+		path + ".1": "third\n",
+		path + ".2": "second\n",
+	}
+	for p, content := range expected {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", p, err)
+		}
+		if string(data) != content {
+			t.Errorf("%s: expected %q, got %q", filepath.Base(p), content, string(data))
+		}
+	}
+
+	// the oldest file beyond maxBackups is discarded
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("expected no third backup, got err=%v", err)
+	}
+}
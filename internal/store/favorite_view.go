@@ -15,6 +15,7 @@ type FavoriteView struct {
 	Name      string     `json:"name"`
 	GVK       GVKRef     `json:"gvk"`
 	Fields    [][]string `json:"fields"`
+	Tags      []string   `json:"tags,omitempty"`
 	CreatedAt time.Time  `json:"createdAt"`
 	UpdatedAt time.Time  `json:"updatedAt"`
 }
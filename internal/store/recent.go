@@ -0,0 +1,106 @@
+package store
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// RecentKindsLimit is how many recently picked kinds are remembered.
+const RecentKindsLimit = 5
+
+// recentKindsStore is the JSON file structure.
+type recentKindsStore struct {
+	Kinds []GVKRef `json:"kinds"`
+}
+
+// Recents remembers the most recently picked GVKs, newest first.
+type Recents struct {
+	path  string
+	kinds []GVKRef
+	mu    sync.RWMutex
+}
+
+// NewRecents creates recents stored beside the favorite views.
+func NewRecents(opts ...StoreOptions) (*Recents, error) {
+	var opt StoreOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	dir, err := storeDir(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Recents{path: filepath.Join(dir, "recent-kinds.json")}, nil
+}
+
+// Load reads the recents from disk, starting empty when the file is missing or corrupted.
+func (r *Recents) Load() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := os.ReadFile(r.path)
+	if os.IsNotExist(err) {
+		r.kinds = nil
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var store recentKindsStore
+	if err := json.Unmarshal(data, &store); err != nil {
+		r.kinds = nil
+		return nil
+	}
+
+	r.kinds = store.Kinds
+	if len(r.kinds) > RecentKindsLimit {
+		r.kinds = r.kinds[:RecentKindsLimit]
+	}
+	return nil
+}
+
+// Save writes the recents to disk.
+func (r *Recents) Save() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	data, err := json.MarshalIndent(recentKindsStore{Kinds: r.kinds}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.path, data, 0644)
+}
+
+// Push moves gvk to the front, dropping its older entry and the oldest beyond the limit.
+func (r *Recents) Push(gvk GVKRef) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kinds := []GVKRef{gvk}
+	for _, k := range r.kinds {
+		if k == gvk {
+			continue
+		}
+		if len(kinds) == RecentKindsLimit {
+			break
+		}
+		kinds = append(kinds, k)
+	}
+	r.kinds = kinds
+}
+
+// List returns the recents, newest first.
+func (r *Recents) List() []GVKRef {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]GVKRef, len(r.kinds))
+	copy(result, r.kinds)
+	return result
+}
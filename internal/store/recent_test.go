@@ -0,0 +1,70 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRecents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "recent-kinds.json")
+	recents := &Recents{path: path}
+
+	pod := GVKRef{Version: "v1", Kind: "Pod"}
+	deploy := GVKRef{Group: "apps", Version: "v1", Kind: "Deployment"}
+
+	t.Run("PushDedupes", func(t *testing.T) {
+		recents.Push(pod)
+		recents.Push(deploy)
+		recents.Push(pod)
+
+		got := recents.List()
+		if len(got) != 2 || got[0] != pod || got[1] != deploy {
+			t.Errorf("expected [Pod Deployment], got %v", got)
+		}
+	})
+
+	t.Run("PushTrimsOldest", func(t *testing.T) {
+		for i := 0; i < RecentKindsLimit; i++ {
+			recents.Push(GVKRef{Version: "v1", Kind: "Kind" + string(rune('A'+i))})
+		}
+
+		got := recents.List()
+		if len(got) != RecentKindsLimit {
+			t.Fatalf("expected %d recents, got %d", RecentKindsLimit, len(got))
+		}
+		for _, k := range got {
+			if k == pod || k == deploy {
+				t.Errorf("expected %v to be trimmed", k)
+			}
+		}
+	})
+
+	t.Run("SaveAndLoad", func(t *testing.T) {
+		if err := recents.Save(); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+
+		loaded := &Recents{path: path}
+		if err := loaded.Load(); err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+		if got, want := loaded.List(), recents.List(); len(got) != len(want) || got[0] != want[0] {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("LoadCorrupted", func(t *testing.T) {
+		if err := os.WriteFile(path, []byte("{"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		loaded := &Recents{path: path}
+		if err := loaded.Load(); err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+		if len(loaded.List()) != 0 {
+			t.Errorf("expected no recents, got %v", loaded.List())
+		}
+	})
+}
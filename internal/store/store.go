@@ -3,8 +3,12 @@ package store
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -16,6 +20,7 @@ import (
 var (
 	ErrDuplicateName = errors.New("a favorite with this name already exists for this GVK")
 	ErrNotFound      = errors.New("favorite view not found")
+	ErrOrderMismatch = errors.New("reordered ids do not match the favorite views")
 )
 
 // favoriteViewStore is the JSON file structure.
@@ -42,11 +47,24 @@ func NewStore(opts ...StoreOptions) (*Store, error) {
 		opt = opts[0]
 	}
 
-	configDir, err := os.UserConfigDir()
+	dir, err := storeDir(opt)
 	if err != nil {
 		return nil, err
 	}
 
+	return &Store{
+		path: filepath.Join(dir, "favorite-views.json"),
+		data: &favoriteViewStore{Views: []FavoriteView{}},
+	}, nil
+}
+
+// storeDir creates and returns the directory holding the store files.
+func storeDir(opt StoreOptions) (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
 	appDir := config.AppID
 	if opt.DevMode {
 		appDir = config.AppID + "-dev"
@@ -54,13 +72,9 @@ func NewStore(opts ...StoreOptions) (*Store, error) {
 
 	dir := filepath.Join(configDir, appDir)
 	if err := os.MkdirAll(dir, 0755); err != nil {
-		return nil, err
+		return "", err
 	}
-
-	return &Store{
-		path: filepath.Join(dir, "favorite-views.json"),
-		data: &favoriteViewStore{Views: []FavoriteView{}},
-	}, nil
+	return dir, nil
 }
 
 // Load reads the store from disk.
@@ -127,6 +141,101 @@ func (s *Store) ListByGVK(gvk GVKRef) []FavoriteView {
 	return result
 }
 
+// ListByTag returns favorite views tagged with tag.
+func (s *Store) ListByTag(tag string) []FavoriteView {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []FavoriteView
+	for _, v := range s.data.Views {
+		if slices.Contains(v.Tags, tag) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// Search returns favorite views whose name or kind contains every word of query, case-insensitively.
+// Exact name matches come first, then names starting with query, otherwise in list order.
+func (s *Store) Search(query string) []FavoriteView {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	query = strings.ToLower(strings.TrimSpace(query))
+	terms := strings.Fields(query)
+
+	var result []FavoriteView
+	for _, v := range s.data.Views {
+		name, kind := strings.ToLower(v.Name), strings.ToLower(v.GVK.Kind)
+		matched := true
+		for _, term := range terms {
+			if !strings.Contains(name, term) && !strings.Contains(kind, term) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			result = append(result, v)
+		}
+	}
+
+	rank := func(v FavoriteView) int {
+		name := strings.ToLower(v.Name)
+		switch {
+		case name == query:
+			return 0
+		case strings.HasPrefix(name, query):
+			return 1
+		default:
+			return 2
+		}
+	}
+	slices.SortStableFunc(result, func(a, b FavoriteView) int {
+		return rank(a) - rank(b)
+	})
+	return result
+}
+
+// normalizeTags trims tags, dropping empty and repeated ones.
+func normalizeTags(tags []string) []string {
+	var result []string
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || slices.Contains(result, tag) {
+			continue
+		}
+		result = append(result, tag)
+	}
+	return result
+}
+
+// HasFields reports whether a favorite view for gvk holds exactly fields, in order.
+func (s *Store) HasFields(gvk GVKRef, fields [][]string) bool {
+	for _, v := range s.ListByGVK(gvk) {
+		if equalFields(v.Fields, fields) {
+			return true
+		}
+	}
+	return false
+}
+
+func equalFields(a, b [][]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if len(a[i]) != len(b[i]) {
+			return false
+		}
+		for j := range a[i] {
+			if a[i][j] != b[i][j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // Get returns a favorite view by ID.
 func (s *Store) Get(id string) (*FavoriteView, error) {
 	s.mu.RLock()
@@ -141,7 +250,7 @@ func (s *Store) Get(id string) (*FavoriteView, error) {
 }
 
 // Create adds a new favorite view.
-func (s *Store) Create(name string, gvk GVKRef, fields [][]string) (*FavoriteView, error) {
+func (s *Store) Create(name string, gvk GVKRef, fields [][]string, tags []string) (*FavoriteView, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -158,6 +267,46 @@ func (s *Store) Create(name string, gvk GVKRef, fields [][]string) (*FavoriteVie
 		Name:      name,
 		GVK:       gvk,
 		Fields:    fields,
+		Tags:      normalizeTags(tags),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	s.data.Views = append(s.data.Views, view)
+	return &view, nil
+}
+
+// Duplicate copies a favorite view under newName with a fresh ID and timestamps.
+func (s *Store) Duplicate(id string, newName string) (*FavoriteView, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var source *FavoriteView
+	for i := range s.data.Views {
+		if s.data.Views[i].ID == id {
+			source = &s.data.Views[i]
+			break
+		}
+	}
+	if source == nil {
+		return nil, ErrNotFound
+	}
+	if s.indexByName(source.GVK, newName) >= 0 {
+		return nil, ErrDuplicateName
+	}
+
+	fields := make([][]string, len(source.Fields))
+	for i, field := range source.Fields {
+		fields[i] = slices.Clone(field)
+	}
+
+	now := time.Now()
+	view := FavoriteView{
+		ID:        uuid.New().String(),
+		Name:      newName,
+		GVK:       source.GVK,
+		Fields:    fields,
+		Tags:      slices.Clone(source.Tags),
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
@@ -166,6 +315,35 @@ func (s *Store) Create(name string, gvk GVKRef, fields [][]string) (*FavoriteVie
 	return &view, nil
 }
 
+// Reorder arranges the favorite views in the order of ids, which must list every view exactly once.
+// The order is persisted as the order of the views in the file.
+func (s *Store) Reorder(ids []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(ids) != len(s.data.Views) {
+		return ErrOrderMismatch
+	}
+
+	byID := make(map[string]FavoriteView, len(s.data.Views))
+	for _, v := range s.data.Views {
+		byID[v.ID] = v
+	}
+
+	views := make([]FavoriteView, 0, len(ids))
+	for _, id := range ids {
+		v, ok := byID[id]
+		if !ok {
+			return ErrOrderMismatch // unknown or repeated id
+		}
+		delete(byID, id)
+		views = append(views, v)
+	}
+
+	s.data.Views = views
+	return nil
+}
+
 // Delete removes a favorite view by ID.
 func (s *Store) Delete(id string) error {
 	s.mu.Lock()
@@ -180,8 +358,8 @@ func (s *Store) Delete(id string) error {
 	return ErrNotFound
 }
 
-// Rename updates the name of a favorite view.
-func (s *Store) Rename(id string, newName string) (*FavoriteView, error) {
+// Rename updates the name and tags of a favorite view, nil tags keep the current ones.
+func (s *Store) Rename(id string, newName string, tags []string) (*FavoriteView, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -206,8 +384,64 @@ func (s *Store) Rename(id string, newName string) (*FavoriteView, error) {
 	}
 
 	s.data.Views[targetIdx].Name = newName
+	if tags != nil {
+		s.data.Views[targetIdx].Tags = normalizeTags(tags)
+	}
 	s.data.Views[targetIdx].UpdatedAt = time.Now()
 
 	result := s.data.Views[targetIdx]
 	return &result, nil
 }
+
+// ExportAll writes all favorite views as a JSON array.
+func (s *Store) ExportAll(w io.Writer) error {
+	views := s.ListAll()
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(views)
+}
+
+// ImportAll adds the favorite views of a JSON array written by ExportAll under fresh IDs.
+// A view named like an existing one of the same GVK is skipped, or replaces its fields when overwrite is set.
+func (s *Store) ImportAll(r io.Reader, overwrite bool) error {
+	var views []FavoriteView
+	if err := json.NewDecoder(r).Decode(&views); err != nil {
+		return fmt.Errorf("failed to parse favorite views: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for _, view := range views {
+		if idx := s.indexByName(view.GVK, view.Name); idx >= 0 {
+			if !overwrite {
+				continue // ErrDuplicateName
+			}
+			s.data.Views[idx].Fields = view.Fields
+			s.data.Views[idx].Tags = normalizeTags(view.Tags)
+			s.data.Views[idx].UpdatedAt = now
+			continue
+		}
+
+		view.ID = uuid.New().String()
+		view.Tags = normalizeTags(view.Tags)
+		if view.CreatedAt.IsZero() {
+			view.CreatedAt = now
+		}
+		view.UpdatedAt = now
+		s.data.Views = append(s.data.Views, view)
+	}
+	return nil
+}
+
+// indexByName returns the index of the view named name for gvk, -1 when none.
+func (s *Store) indexByName(gvk GVKRef, name string) int {
+	for i, v := range s.data.Views {
+		if v.GVK == gvk && v.Name == name {
+			return i
+		}
+	}
+	return -1
+}
@@ -1,8 +1,11 @@
 package store
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -18,7 +21,7 @@ func TestStore(t *testing.T) {
 	fields := [][]string{{"metadata", "name"}, {"status", "phase"}}
 
 	t.Run("Create", func(t *testing.T) {
-		view, err := store.Create("Test View", gvk, fields)
+		view, err := store.Create("Test View", gvk, fields, nil)
 		if err != nil {
 			t.Fatalf("Create failed: %v", err)
 		}
@@ -31,7 +34,7 @@ func TestStore(t *testing.T) {
 	})
 
 	t.Run("DuplicateName", func(t *testing.T) {
-		_, err := store.Create("Test View", gvk, fields)
+		_, err := store.Create("Test View", gvk, fields, nil)
 		if err != ErrDuplicateName {
 			t.Errorf("expected ErrDuplicateName, got %v", err)
 		}
@@ -39,7 +42,7 @@ func TestStore(t *testing.T) {
 
 	t.Run("SameNameDifferentGVK", func(t *testing.T) {
 		otherGVK := GVKRef{Group: "apps", Version: "v1", Kind: "Deployment"}
-		_, err := store.Create("Test View", otherGVK, fields)
+		_, err := store.Create("Test View", otherGVK, fields, nil)
 		if err != nil {
 			t.Fatalf("expected no error for same name in different GVK, got %v", err)
 		}
@@ -59,6 +62,21 @@ func TestStore(t *testing.T) {
 		}
 	})
 
+	t.Run("HasFields", func(t *testing.T) {
+		if !store.HasFields(gvk, [][]string{{"metadata", "name"}, {"status", "phase"}}) {
+			t.Error("expected saved fields to be found")
+		}
+		if store.HasFields(gvk, [][]string{{"status", "phase"}, {"metadata", "name"}}) {
+			t.Error("expected reordered fields not to match")
+		}
+		if store.HasFields(gvk, [][]string{{"metadata", "name"}}) {
+			t.Error("expected a subset of fields not to match")
+		}
+		if store.HasFields(GVKRef{Version: "v1", Kind: "Service"}, fields) {
+			t.Error("expected fields of another GVK not to match")
+		}
+	})
+
 	t.Run("Get", func(t *testing.T) {
 		all := store.ListAll()
 		view, err := store.Get(all[0].ID)
@@ -79,7 +97,7 @@ func TestStore(t *testing.T) {
 
 	t.Run("Rename", func(t *testing.T) {
 		all := store.ListAll()
-		view, err := store.Rename(all[0].ID, "Renamed View")
+		view, err := store.Rename(all[0].ID, "Renamed View", nil)
 		if err != nil {
 			t.Fatalf("Rename failed: %v", err)
 		}
@@ -157,3 +175,292 @@ func TestStore(t *testing.T) {
 		}
 	})
 }
+
+func TestExportImport(t *testing.T) {
+	newStore := func() *Store {
+		return &Store{
+			path: filepath.Join(t.TempDir(), "favorites.json"),
+			data: &favoriteViewStore{Views: []FavoriteView{}},
+		}
+	}
+
+	pod := GVKRef{Version: "v1", Kind: "Pod"}
+	src := newStore()
+	exported, _ := src.Create("phases", pod, [][]string{{"status", "phase"}}, nil)
+	_, _ = src.Create("images", pod, [][]string{{"spec", "containers", "*", "image"}}, nil)
+
+	var buf bytes.Buffer
+	if err := src.ExportAll(&buf); err != nil {
+		t.Fatalf("ExportAll failed: %v", err)
+	}
+	data := buf.Bytes()
+
+	t.Run("ImportRegeneratesIDs", func(t *testing.T) {
+		dst := newStore()
+		if err := dst.ImportAll(bytes.NewReader(data), false); err != nil {
+			t.Fatalf("ImportAll failed: %v", err)
+		}
+
+		views := dst.ListByGVK(pod)
+		if len(views) != 2 {
+			t.Fatalf("expected 2 views, got %d", len(views))
+		}
+		if views[0].Name != "phases" || views[0].ID == exported.ID {
+			t.Errorf("expected phases under a new ID, got %+v", views[0])
+		}
+	})
+
+	t.Run("ImportSkipsDuplicateNames", func(t *testing.T) {
+		dst := newStore()
+		existing, _ := dst.Create("phases", pod, [][]string{{"metadata", "name"}}, nil)
+
+		if err := dst.ImportAll(bytes.NewReader(data), false); err != nil {
+			t.Fatalf("ImportAll failed: %v", err)
+		}
+
+		view, _ := dst.Get(existing.ID)
+		if !equalFields(view.Fields, existing.Fields) {
+			t.Errorf("expected fields kept, got %v", view.Fields)
+		}
+		if len(dst.ListAll()) != 2 {
+			t.Errorf("expected 2 views, got %d", len(dst.ListAll()))
+		}
+	})
+
+	t.Run("ImportOverwritesDuplicateNames", func(t *testing.T) {
+		dst := newStore()
+		existing, _ := dst.Create("phases", pod, [][]string{{"metadata", "name"}}, nil)
+
+		if err := dst.ImportAll(bytes.NewReader(data), true); err != nil {
+			t.Fatalf("ImportAll failed: %v", err)
+		}
+
+		view, _ := dst.Get(existing.ID)
+		if !equalFields(view.Fields, exported.Fields) {
+			t.Errorf("expected fields overwritten, got %v", view.Fields)
+		}
+		if !view.UpdatedAt.After(existing.UpdatedAt) {
+			t.Error("expected UpdatedAt to be bumped")
+		}
+	})
+
+	t.Run("ImportInvalid", func(t *testing.T) {
+		if err := newStore().ImportAll(strings.NewReader("{"), false); err == nil {
+			t.Error("expected an error for invalid JSON")
+		}
+	})
+}
+
+func TestTags(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := &Store{
+		path: filepath.Join(tmpDir, "favorites.json"),
+		data: &favoriteViewStore{Views: []FavoriteView{}},
+	}
+	pod := GVKRef{Version: "v1", Kind: "Pod"}
+	fields := [][]string{{"status", "phase"}}
+
+	t.Run("CreateNormalizesTags", func(t *testing.T) {
+		view, err := store.Create("phases", pod, fields, []string{" prod", "debug", "prod", ""})
+		if err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+		if !slices.Equal(view.Tags, []string{"prod", "debug"}) {
+			t.Errorf("expected [prod debug], got %v", view.Tags)
+		}
+	})
+
+	t.Run("ListByTag", func(t *testing.T) {
+		if _, err := store.Create("untagged", pod, fields, nil); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+		views := store.ListByTag("debug")
+		if len(views) != 1 || views[0].Name != "phases" {
+			t.Errorf("expected only phases, got %v", views)
+		}
+		if len(store.ListByTag("networking")) != 0 {
+			t.Error("expected no views for an unused tag")
+		}
+	})
+
+	t.Run("RenameTags", func(t *testing.T) {
+		id := store.ListByTag("prod")[0].ID
+
+		view, _ := store.Rename(id, "phases", nil)
+		if !slices.Equal(view.Tags, []string{"prod", "debug"}) {
+			t.Errorf("expected nil tags to keep [prod debug], got %v", view.Tags)
+		}
+
+		view, _ = store.Rename(id, "phases", []string{})
+		if len(view.Tags) != 0 {
+			t.Errorf("expected tags cleared, got %v", view.Tags)
+		}
+	})
+
+	t.Run("LoadWithoutTags", func(t *testing.T) {
+		path := filepath.Join(tmpDir, "old.json")
+		old := `{"views": [{"id": "1", "name": "old", "gvk": {"group": "", "version": "v1", "kind": "Pod"}, "fields": [["status", "phase"]]}]}`
+		if err := os.WriteFile(path, []byte(old), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		loaded := &Store{path: path}
+		if err := loaded.Load(); err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+		views := loaded.ListAll()
+		if len(views) != 1 || views[0].Tags != nil {
+			t.Errorf("expected one view without tags, got %v", views)
+		}
+	})
+}
+
+func TestDuplicate(t *testing.T) {
+	store := &Store{
+		path: filepath.Join(t.TempDir(), "favorites.json"),
+		data: &favoriteViewStore{Views: []FavoriteView{}},
+	}
+	pod := GVKRef{Version: "v1", Kind: "Pod"}
+	source, _ := store.Create("phases", pod, [][]string{{"status", "phase"}}, []string{"debug"})
+
+	t.Run("CopiesUnderFreshID", func(t *testing.T) {
+		view, err := store.Duplicate(source.ID, "phases copy")
+		if err != nil {
+			t.Fatalf("Duplicate failed: %v", err)
+		}
+		if view.ID == source.ID || view.GVK != pod || !equalFields(view.Fields, source.Fields) {
+			t.Errorf("unexpected copy %+v", view)
+		}
+		if !slices.Equal(view.Tags, source.Tags) {
+			t.Errorf("expected tags %v, got %v", source.Tags, view.Tags)
+		}
+
+		// the copy does not share fields with the source
+		view.Fields[0][0] = "spec"
+		got, _ := store.Get(source.ID)
+		if got.Fields[0][0] != "status" {
+			t.Error("expected the source fields untouched")
+		}
+	})
+
+	t.Run("DuplicateName", func(t *testing.T) {
+		if _, err := store.Duplicate(source.ID, "phases"); err != ErrDuplicateName {
+			t.Errorf("expected ErrDuplicateName, got %v", err)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		if _, err := store.Duplicate("nonexistent", "copy"); err != ErrNotFound {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+}
+
+func TestReorder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "favorites.json")
+	store := &Store{
+		path: path,
+		data: &favoriteViewStore{Views: []FavoriteView{}},
+	}
+	pod := GVKRef{Version: "v1", Kind: "Pod"}
+	deploy := GVKRef{Group: "apps", Version: "v1", Kind: "Deployment"}
+	a, _ := store.Create("a", pod, [][]string{{"status", "phase"}}, nil)
+	b, _ := store.Create("b", deploy, [][]string{{"spec", "replicas"}}, nil)
+	c, _ := store.Create("c", pod, [][]string{{"spec", "nodeName"}}, nil)
+
+	names := func(views []FavoriteView) []string {
+		var result []string
+		for _, v := range views {
+			result = append(result, v.Name)
+		}
+		return result
+	}
+
+	t.Run("RejectsMismatchedIDs", func(t *testing.T) {
+		for _, ids := range [][]string{
+			{a.ID, b.ID},
+			{a.ID, b.ID, "nonexistent"},
+			{a.ID, a.ID, b.ID},
+		} {
+			if err := store.Reorder(ids); err != ErrOrderMismatch {
+				t.Errorf("expected ErrOrderMismatch for %v, got %v", ids, err)
+			}
+		}
+		if got := names(store.ListAll()); !slices.Equal(got, []string{"a", "b", "c"}) {
+			t.Errorf("expected the order untouched, got %v", got)
+		}
+	})
+
+	t.Run("ListsInOrder", func(t *testing.T) {
+		if err := store.Reorder([]string{c.ID, b.ID, a.ID}); err != nil {
+			t.Fatalf("Reorder failed: %v", err)
+		}
+		if got := names(store.ListAll()); !slices.Equal(got, []string{"c", "b", "a"}) {
+			t.Errorf("expected [c b a], got %v", got)
+		}
+		if got := names(store.ListByGVK(pod)); !slices.Equal(got, []string{"c", "a"}) {
+			t.Errorf("expected [c a], got %v", got)
+		}
+	})
+
+	t.Run("SaveAndLoadKeepOrder", func(t *testing.T) {
+		if err := store.Save(); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		loaded := &Store{path: path}
+		if err := loaded.Load(); err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+		if got := names(loaded.ListAll()); !slices.Equal(got, []string{"c", "b", "a"}) {
+			t.Errorf("expected [c b a], got %v", got)
+		}
+	})
+}
+
+func TestSearch(t *testing.T) {
+	store := &Store{
+		path: filepath.Join(t.TempDir(), "favorites.json"),
+		data: &favoriteViewStore{Views: []FavoriteView{}},
+	}
+	pod := GVKRef{Version: "v1", Kind: "Pod"}
+	netpol := GVKRef{Group: "networking.k8s.io", Version: "v1", Kind: "NetworkPolicy"}
+	ingress := GVKRef{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"}
+	fields := [][]string{{"metadata", "name"}}
+	for _, c := range []struct {
+		name string
+		gvk  GVKRef
+	}{
+		{"prod pods", pod},
+		{"prod", netpol},
+		{"staging", netpol},
+		{"Prod hosts", ingress},
+	} {
+		if _, err := store.Create(c.name, c.gvk, fields, nil); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	names := func(views []FavoriteView) []string {
+		var result []string
+		for _, v := range views {
+			result = append(result, v.Name)
+		}
+		return result
+	}
+
+	for _, c := range []struct {
+		query string
+		want  []string
+	}{
+		{"prod", []string{"prod", "prod pods", "Prod hosts"}},
+		{"PROD HOSTS", []string{"Prod hosts"}},
+		{"prod networkpolicy", []string{"prod"}},
+		{"network", []string{"prod", "staging"}},
+		{"", []string{"prod pods", "prod", "staging", "Prod hosts"}},
+		{"secret", nil},
+	} {
+		if got := names(store.Search(c.query)); !slices.Equal(got, c.want) {
+			t.Errorf("Search(%q): expected %v, got %v", c.query, c.want, got)
+		}
+	}
+}
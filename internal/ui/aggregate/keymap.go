@@ -0,0 +1,17 @@
+package aggregate
+
+import "github.com/charmbracelet/bubbles/key"
+
+type keyMap struct {
+	up   key.Binding
+	down key.Binding
+	hide key.Binding
+}
+
+func newKeyMap() keyMap {
+	return keyMap{
+		up:   key.NewBinding(key.WithKeys("up")),
+		down: key.NewBinding(key.WithKeys("down")),
+		hide: key.NewBinding(key.WithKeys("esc")),
+	}
+}
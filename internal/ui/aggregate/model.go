@@ -0,0 +1,181 @@
+package aggregate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/flavono123/kattle/internal/kube"
+	"github.com/flavono123/kattle/internal/ui/theme"
+)
+
+const (
+	AGGREGATE_WIDTH_DIV  = 2
+	AGGREGATE_MAX_HEIGHT = 15
+
+	AGGREGATE_SCROLL_STEP = 1
+)
+
+// Model is a sub-view detached from a map node,
+// listing its distinct keys with how many objects have each
+type Model struct {
+	keys      keyMap
+	style     lipgloss.Style
+	node      *kube.Node
+	objCount  int
+	keyCounts []kube.KeyCount
+	filtered  []kube.KeyCount
+	input     textinput.Model
+	vp        viewport.Model
+	cursor    int
+}
+
+func NewModel() *Model {
+	ti := textinput.New()
+	ti.Placeholder = "Filter keys..."
+	ti.Prompt = "🔍 "
+	ti.Width = 30
+
+	return &Model{
+		keys:  newKeyMap(),
+		style: lipgloss.NewStyle().Border(lipgloss.ThickBorder()),
+		input: ti,
+		vp:    viewport.New(0, 0),
+	}
+}
+
+func (m *Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case HideMsg:
+		m.input.Blur()
+	case tea.WindowSizeMsg:
+		m.vp.Width = msg.Width / AGGREGATE_WIDTH_DIV
+		m.vp.Height = AGGREGATE_MAX_HEIGHT
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.up):
+			if m.cursor > 0 {
+				m.cursor--
+			} else {
+				m.vp.ScrollUp(AGGREGATE_SCROLL_STEP)
+			}
+		case key.Matches(msg, m.keys.down):
+			if m.cursor < min(len(m.filtered)-1, m.vp.Height-1) {
+				m.cursor++
+			} else {
+				m.vp.ScrollDown(AGGREGATE_SCROLL_STEP)
+			}
+		case key.Matches(msg, m.keys.hide):
+			cmds = append(cmds, Hide())
+		default:
+			prevInputValue := m.input.Value()
+			im, iCmd := m.input.Update(msg)
+			m.input = im
+			cmds = append(cmds, iCmd)
+			if prevInputValue != m.input.Value() {
+				m.applyFilter()
+			}
+		}
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m *Model) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Peach())
+	subtitleStyle := lipgloss.NewStyle().Foreground(theme.Subtext0())
+	inputStyle := lipgloss.NewStyle().Margin(0, 0, 1, 0)
+
+	m.vp.SetContent(m.renderRows())
+	return m.style.Render(
+		lipgloss.JoinVertical(lipgloss.Left,
+			lipgloss.JoinHorizontal(lipgloss.Left,
+				titleStyle.Render(strings.Join(m.node.NodeFullPath(), ".")),
+				subtitleStyle.Render(fmt.Sprintf(" · %d keys in %d objects", len(m.keyCounts), m.objCount)),
+			),
+			inputStyle.Render(m.input.View()),
+			m.vp.View(),
+		),
+	)
+}
+
+// Focus shows the keys of the map node counted over objs
+func (m *Model) Focus(node *kube.Node, objs []*unstructured.Unstructured) tea.Cmd {
+	m.node = node
+	m.input.Reset()
+	m.cursor = 0
+	m.vp.SetYOffset(0)
+	m.SetObjs(objs)
+	return m.input.Focus()
+}
+
+// SetObjs recounts the keys, e.g. when objects are updated
+func (m *Model) SetObjs(objs []*unstructured.Unstructured) {
+	if m.node == nil {
+		return
+	}
+	m.objCount = len(objs)
+	m.keyCounts = kube.CountKeys(m.node, objs)
+	m.applyFilter()
+}
+
+func (m *Model) applyFilter() {
+	keyword := m.input.Value()
+	if keyword == "" {
+		m.filtered = m.keyCounts
+	} else {
+		keys := make([]string, len(m.keyCounts))
+		for i, kc := range m.keyCounts {
+			keys[i] = kc.Key
+		}
+		m.filtered = []kube.KeyCount{}
+		for _, match := range fuzzy.Find(keyword, keys) {
+			m.filtered = append(m.filtered, m.keyCounts[match.Index])
+		}
+	}
+
+	if m.cursor > len(m.filtered)-1 {
+		m.cursor = max(len(m.filtered)-1, 0)
+		m.vp.SetYOffset(0)
+	}
+}
+
+func (m *Model) renderRows() string {
+	if len(m.filtered) == 0 {
+		return lipgloss.NewStyle().Foreground(theme.Overlay0()).Render("No keys found.")
+	}
+
+	keyWidth := 0
+	for _, kc := range m.filtered {
+		keyWidth = max(keyWidth, len(kc.Key))
+	}
+	keyStyle := lipgloss.NewStyle().Padding(0, 1).Width(keyWidth + 2).MaxWidth(m.vp.Width / 2)
+	countStyle := lipgloss.NewStyle().Foreground(theme.Subtext1())
+	hoveredStyle := lipgloss.NewStyle().Background(theme.Overlay0())
+
+	lines := make([]string, 0, len(m.filtered))
+	for i, kc := range m.filtered {
+		line := lipgloss.JoinHorizontal(lipgloss.Left,
+			keyStyle.Render(kc.Key),
+			countStyle.Render(fmt.Sprintf("%d/%d", kc.Count, m.objCount)),
+		)
+		if i == m.cursor+m.vp.YOffset {
+			line = hoveredStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -7,15 +7,15 @@ import (
 	"github.com/flavono123/kattle/internal/kube"
 )
 
-type CancelPickMsg struct {
-	Canceled bool
-	Node     *kube.Node
-}
-
 type PickGVKMsg struct {
 	GVK schema.GroupVersionKind
 }
 
+// namespace picker -> root, an empty Namespace is all namespaces
+type PickNamespaceMsg struct {
+	Namespace string
+}
+
 type PickFieldMsg struct {
 	Node *kube.Node
 }
@@ -24,20 +24,43 @@ type UnpickFieldMsg struct {
 	Node *kube.Node
 }
 
+// nav -> root, to list the keys of a map field in a sub-view
+type DetachFieldMsg struct {
+	Node *kube.Node
+}
+
+// nav -> root, to re-measure the columns joined by the array
+type AggregateFieldMsg struct {
+	Node *kube.Node
+}
+
 type HoverFieldMsg struct {
 	Candidate *kube.Node
 }
 
+// controller -> root, Type is how Obj changed and Objs are the objects after it
 type UpdateObjsMsg struct {
+	Type kube.EventType
 	Obj  *unstructured.Unstructured
 	Objs []*unstructured.Unstructured
 }
 
+// controller -> root, when listing or watching fails
+type ControllerErrorMsg struct {
+	Err error
+}
+
 // table -> result
 type TableUpdatedMsg struct {
 	Width int
 }
 
+// favorite -> root, replaces the picked fields with the ones of the view
+type ApplyFavoriteMsg struct {
+	Name   string
+	Fields [][]string
+}
+
 // kbar(hiding) -> root
 type RestoreLastSessionMsg struct{}
 
@@ -48,6 +71,7 @@ type Status uint
 const (
 	Error Status = iota
 	Warn
+	Info
 )
 
 type SetStatusMsg struct {
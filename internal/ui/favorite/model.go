@@ -0,0 +1,164 @@
+package favorite
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+
+	"github.com/flavono123/kattle/internal/store"
+	"github.com/flavono123/kattle/internal/ui/event"
+	"github.com/flavono123/kattle/internal/ui/theme"
+)
+
+const (
+	FAVORITE_WIDTH_DIV  = 3
+	FAVORITE_MAX_HEIGHT = 10
+
+	FAVORITE_SCROLL_STEP = 1
+)
+
+// Model is a kbar-like list to pick the favorite view of the current kind to apply
+type Model struct {
+	keys     keyMap
+	style    lipgloss.Style
+	items    []store.FavoriteView
+	filtered []store.FavoriteView
+	input    textinput.Model
+	vp       viewport.Model
+	cursor   int
+}
+
+func NewModel() *Model {
+	ti := textinput.New()
+	ti.Placeholder = "Apply favorite..."
+	ti.Prompt = "⭐ "
+	ti.Width = 30
+
+	return &Model{
+		keys:  newKeyMap(),
+		style: lipgloss.NewStyle().Border(lipgloss.ThickBorder()),
+		input: ti,
+		vp:    viewport.New(0, 0),
+	}
+}
+
+func (m *Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case HideMsg:
+		m.input.Blur()
+	case tea.WindowSizeMsg:
+		m.vp.Width = msg.Width / FAVORITE_WIDTH_DIV
+		m.vp.Height = FAVORITE_MAX_HEIGHT
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.up):
+			if m.cursor > 0 {
+				m.cursor--
+			} else {
+				m.vp.ScrollUp(FAVORITE_SCROLL_STEP)
+			}
+		case key.Matches(msg, m.keys.down):
+			if m.cursor < min(len(m.filtered)-1, m.vp.Height-1) {
+				m.cursor++
+			} else {
+				m.vp.ScrollDown(FAVORITE_SCROLL_STEP)
+			}
+		case key.Matches(msg, m.keys.pick):
+			index := m.cursor + m.vp.YOffset
+			if index >= len(m.filtered) {
+				break
+			}
+			view := m.filtered[index]
+			cmds = append(cmds, func() tea.Msg {
+				return event.ApplyFavoriteMsg{Name: view.Name, Fields: view.Fields}
+			})
+		case key.Matches(msg, m.keys.hide):
+			cmds = append(cmds, Hide())
+		default:
+			prevInputValue := m.input.Value()
+			im, iCmd := m.input.Update(msg)
+			m.input = im
+			cmds = append(cmds, iCmd)
+			if prevInputValue != m.input.Value() {
+				m.cursor = 0
+				m.vp.SetYOffset(0)
+				m.applyFilter()
+			}
+		}
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m *Model) View() string {
+	m.vp.SetContent(m.renderRows())
+	return m.style.Render(
+		lipgloss.JoinVertical(lipgloss.Left,
+			m.input.View(),
+			m.vp.View(),
+		),
+	)
+}
+
+// Focus opens the picker listing views, e.g. the favorites of the current kind
+func (m *Model) Focus(views []store.FavoriteView) tea.Cmd {
+	m.items = views
+	m.input.Reset()
+	m.cursor = 0
+	m.vp.SetYOffset(0)
+	m.applyFilter()
+	return m.input.Focus()
+}
+
+func (m *Model) applyFilter() {
+	keyword := m.input.Value()
+	if keyword == "" {
+		m.filtered = m.items
+	} else {
+		names := make([]string, len(m.items))
+		for i, item := range m.items {
+			names[i] = item.Name
+		}
+		m.filtered = []store.FavoriteView{}
+		for _, match := range fuzzy.Find(keyword, names) {
+			m.filtered = append(m.filtered, m.items[match.Index])
+		}
+	}
+
+	if m.cursor > len(m.filtered)-1 {
+		m.cursor = max(len(m.filtered)-1, 0)
+		m.vp.SetYOffset(0)
+	}
+}
+
+func (m *Model) renderRows() string {
+	dimStyle := lipgloss.NewStyle().Foreground(theme.Overlay0())
+	if len(m.filtered) == 0 {
+		return dimStyle.Render("No favorites found.")
+	}
+
+	itemStyle := lipgloss.NewStyle().Padding(0, 0, 0, 1).MaxWidth(m.vp.Width)
+	hoveredStyle := lipgloss.NewStyle().Background(theme.Overlay0())
+
+	lines := make([]string, 0, len(m.filtered))
+	for i, item := range m.filtered {
+		line := itemStyle.Render(item.Name + dimStyle.Render(fmt.Sprintf(" %d fields", len(item.Fields))))
+		if i == m.cursor+m.vp.YOffset {
+			line = hoveredStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
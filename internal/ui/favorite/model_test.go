@@ -0,0 +1,50 @@
+package favorite
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flavono123/kattle/internal/store"
+	"github.com/flavono123/kattle/internal/ui/event"
+)
+
+func TestPick(t *testing.T) {
+	views := []store.FavoriteView{
+		{Name: "phases", Fields: [][]string{{"status", "phase"}}},
+		{Name: "images", Fields: [][]string{{"spec", "containers", "*", "image"}}},
+	}
+	newModel := func() *Model {
+		m := NewModel()
+		m.Update(tea.WindowSizeMsg{Width: 90, Height: 30})
+		m.Focus(views)
+		return m
+	}
+	pick := func(m *Model) tea.Msg {
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		return cmd()
+	}
+
+	t.Run("applies the hovered view", func(t *testing.T) {
+		m := newModel()
+		m.Update(tea.KeyMsg{Type: tea.KeyDown})
+		assert.Equal(t, event.ApplyFavoriteMsg{Name: "images", Fields: views[1].Fields}, pick(m))
+	})
+
+	t.Run("filters by the typed name", func(t *testing.T) {
+		m := newModel()
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("pha")})
+		assert.Equal(t, views[:1], m.filtered)
+		assert.Equal(t, event.ApplyFavoriteMsg{Name: "phases", Fields: views[0].Fields}, pick(m))
+	})
+
+	t.Run("applies nothing without views", func(t *testing.T) {
+		m := NewModel()
+		m.Update(tea.WindowSizeMsg{Width: 90, Height: 30})
+		m.Focus(nil)
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Nil(t, cmd)
+		assert.Contains(t, m.View(), "No favorites found.")
+	})
+}
@@ -0,0 +1,20 @@
+package favorite
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/flavono123/kattle/internal/ui/event"
+)
+
+type HideMsg struct{}
+
+func Hide() tea.Cmd {
+	return tea.Sequence(
+		func() tea.Msg {
+			return HideMsg{}
+		},
+		func() tea.Msg {
+			return event.RestoreLastSessionMsg{}
+		},
+	)
+}
@@ -1,19 +1,45 @@
 package kbar
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/flavono123/kattle/internal/ui/keybind"
+)
 
 type keyMap struct {
 	up   key.Binding
 	down key.Binding
 	pick key.Binding
 	hide key.Binding
+	// toggleGroup switches between the flat list and sections by API group
+	toggleGroup key.Binding
+	// toggleVersions lists every served version of the kinds, not only the preferred one
+	toggleVersions key.Binding
 }
 
 func newKeyMap() keyMap {
-	return keyMap{
-		up:   key.NewBinding(key.WithKeys("up")),
-		down: key.NewBinding(key.WithKeys("down")),
-		pick: key.NewBinding(key.WithKeys("enter")),
-		hide: key.NewBinding(key.WithKeys("esc")),
+	km := keyMap{
+		up:             key.NewBinding(key.WithKeys("up")),
+		down:           key.NewBinding(key.WithKeys("down")),
+		pick:           key.NewBinding(key.WithKeys("enter")),
+		hide:           key.NewBinding(key.WithKeys("esc")),
+		toggleGroup:    key.NewBinding(key.WithKeys("ctrl+g")),
+		toggleVersions: key.NewBinding(key.WithKeys("ctrl+v")),
 	}
+	keybind.Rebind(keybind.Kbar, map[string]*key.Binding{
+		"up":             &km.up,
+		"down":           &km.down,
+		"pick":           &km.pick,
+		"hide":           &km.hide,
+		"toggleGroup":    &km.toggleGroup,
+		"toggleVersions": &km.toggleVersions,
+	})
+	return km
+}
+
+// toggles reports whether msg switches the listing, which the input must not see,
+// e.g. ctrl+v would paste as well
+func (km keyMap) toggles(msg tea.KeyMsg) bool {
+	return key.Matches(msg, km.toggleGroup, km.toggleVersions)
 }
@@ -1,7 +1,9 @@
 package kbar
 
 import (
+	"fmt"
 	"log"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -13,6 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/flavono123/kattle/internal/kube"
+	"github.com/flavono123/kattle/internal/store"
 	"github.com/flavono123/kattle/internal/ui/event"
 	"github.com/flavono123/kattle/internal/ui/theme"
 )
@@ -33,17 +36,25 @@ type Model struct {
 	searchResults searchResults
 	srViewport    viewport.Model
 	cursor        int
+	grouped       bool           // sections the results by API group
+	allVersions   bool           // lists every served version, not only the preferred one of each kind
+	recents       *store.Recents // nil when recents can't be persisted
+
+	// object counts fetched on hover, nil while pending or when not countable
+	counts    map[schema.GroupVersionKind]*int64
+	countFunc func(schema.GroupVersionKind) (int64, error)
 }
 
-func NewModel() *Model {
+// NewModel creates the kbar listing every kind served in the named context, recently picked ones first
+func NewModel(recents *store.Recents, contextName string) *Model {
 	var items kbarItems
 
-	gvks, err := kube.GetGVKs()
+	infos, err := kube.GetAllGVKInfosForContext(contextName)
 	if err != nil {
 		log.Fatalf("failed to get gvks: %v", err)
 	}
-	for _, gvk := range gvks {
-		items = append(items, kbarItem{GroupVersionKind: gvk})
+	for _, info := range infos {
+		items = append(items, kbarItem{GVKInfo: info})
 	}
 
 	ti := textinput.New()
@@ -61,9 +72,14 @@ func NewModel() *Model {
 		input:      ti,
 		cursor:     0,
 		srViewport: viewport.New(0, 0),
+		recents:    recents,
+		counts:     make(map[schema.GroupVersionKind]*int64),
+		countFunc: func(gvk schema.GroupVersionKind) (int64, error) {
+			return kube.CountForContext(contextName, gvk)
+		},
 	}
 
-	m.setSearchResults(items)
+	m.setSearchResults(m.rows())
 	return m
 }
 
@@ -76,10 +92,12 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	prevInputValue := m.input.Value()
 
-	im, iCmd := m.input.Update(msg)
-	m.input = im
-	cmds = append(cmds, iCmd)
-	filtered := m.items.filter(m.input.Value())
+	if keyMsg, ok := msg.(tea.KeyMsg); !ok || !m.keys.toggles(keyMsg) {
+		im, iCmd := m.input.Update(msg)
+		m.input = im
+		cmds = append(cmds, iCmd)
+	}
+	filtered := m.rows()
 	if prevInputValue != m.input.Value() {
 		m.moveCursorTop(filtered)
 	}
@@ -95,29 +113,42 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.reset()
 		m.input.Blur()
 
+	case countMsg:
+		if msg.Err != nil {
+			log.Printf("[DEBUG] not counting %s: %v", msg.GVK.String(), msg.Err)
+			break
+		}
+		count := msg.Count
+		m.counts[msg.GVK] = &count
+		m.setSearchResults(filtered)
 	case tea.WindowSizeMsg:
 		m.setViewSize(msg)
 	case tea.KeyMsg:
 		if m.Visible() {
 			switch {
 			case key.Matches(msg, m.keys.up):
-				if m.cursor > 0 {
-					m.cursor--
-				} else {
-					m.srViewport.ScrollUp(KBAR_SCROLL_STEP)
-				}
-				m.setSearchResults(filtered)
+				m.moveCursor(filtered, -KBAR_SCROLL_STEP)
 			case key.Matches(msg, m.keys.down):
-				if m.cursor < min(len(filtered)-1, KBAR_SEARCH_RESULTS_MAX_HEIGHT-1) {
-					m.cursor++
-				} else {
-					m.srViewport.ScrollDown(KBAR_SCROLL_STEP)
-				}
-				m.setSearchResults(filtered)
+				m.moveCursor(filtered, KBAR_SCROLL_STEP)
+			case key.Matches(msg, m.keys.toggleGroup):
+				m.grouped = !m.grouped
+				filtered = m.rows()
+				m.srViewport.SetYOffset(0)
+				m.moveCursorTop(filtered)
+			case key.Matches(msg, m.keys.toggleVersions):
+				m.allVersions = !m.allVersions
+				filtered = m.rows()
+				m.srViewport.SetYOffset(0)
+				m.moveCursorTop(filtered)
 			case key.Matches(msg, m.keys.pick):
 				actualIndex := m.cursor + m.srViewport.YOffset
+				if actualIndex >= len(filtered) || filtered[actualIndex].item == nil {
+					break
+				}
+				gvk := filtered[actualIndex].item.GroupVersionKind
+				m.pushRecent(gvk)
 				cmds = append(cmds, func() tea.Msg {
-					return event.PickGVKMsg{GVK: filtered[actualIndex].GroupVersionKind}
+					return event.PickGVKMsg{GVK: gvk}
 				})
 			case key.Matches(msg, m.keys.hide): // Additional key to hide kbar when only kbar is showing
 				cmds = append(cmds, Hide())
@@ -125,21 +156,128 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	if m.Visible() {
+		cmds = append(cmds, m.countHovered())
+	}
+
 	return m, tea.Batch(cmds...)
 }
 
 func (m *Model) View() string {
-	inputStyle := lipgloss.NewStyle().Margin(0, 0, 1, 0)
 	searchResult := strings.TrimSuffix(m.searchResults.string(m.srViewport.Width), "\n")
 	m.srViewport.SetContent(searchResult)
 	return m.style.Render(
 		lipgloss.JoinVertical(lipgloss.Left,
-			inputStyle.Render(m.input.View()),
+			m.input.View(),
+			m.renderHeader(),
 			m.srViewport.View(),
 		),
 	)
 }
 
+// renderHeader labels the recents above the list, taking the line between the input and results
+func (m *Model) renderHeader() string {
+	if m.grouped || m.input.Value() != "" || len(m.listed().recent(m.recentRefs())) == 0 {
+		return ""
+	}
+	return lipgloss.NewStyle().
+		Foreground(theme.Overlay0()).
+		Padding(0, 0, 0, 1).
+		Render("recent")
+}
+
+// listed are the items to search: the preferred version of each kind, or every version
+func (m *Model) listed() kbarItems {
+	if m.allVersions {
+		return m.items
+	}
+	var items kbarItems
+	for _, item := range m.items {
+		if item.Preferred {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// candidates are the items to list: filtered by the input, or recents first when it's empty
+func (m *Model) candidates() kbarItems {
+	if value := m.input.Value(); value != "" {
+		return m.listed().filter(value)
+	}
+	return m.listed().withRecents(m.recentRefs())
+}
+
+// rows lists the candidates, under a header per API group when grouped
+func (m *Model) rows() []kbarRow {
+	items := m.candidates()
+	if !m.grouped {
+		rows := make([]kbarRow, len(items))
+		for i := range items {
+			rows[i] = kbarRow{item: &items[i]}
+		}
+		return rows
+	}
+
+	// the core group first, then by name; the candidate order is kept within a group
+	var groups []string
+	byGroup := map[string][]int{}
+	for i, item := range items {
+		if _, ok := byGroup[item.Group]; !ok {
+			groups = append(groups, item.Group)
+		}
+		byGroup[item.Group] = append(byGroup[item.Group], i)
+	}
+	slices.Sort(groups)
+
+	rows := make([]kbarRow, 0, len(items)+len(groups))
+	for _, group := range groups {
+		rows = append(rows, kbarRow{group: group})
+		for _, i := range byGroup[group] {
+			rows = append(rows, kbarRow{item: &items[i]})
+		}
+	}
+	return rows
+}
+
+// countHovered fetches the object count of the hovered kind once, in the background
+func (m *Model) countHovered() tea.Cmd {
+	rows := m.rows()
+	index := m.cursor + m.srViewport.YOffset
+	if index < 0 || index >= len(rows) || rows[index].item == nil {
+		return nil
+	}
+
+	gvk := rows[index].item.GroupVersionKind
+	if _, requested := m.counts[gvk]; requested {
+		return nil
+	}
+	m.counts[gvk] = nil
+
+	countFunc := m.countFunc
+	return func() tea.Msg {
+		count, err := countFunc(gvk)
+		return countMsg{GVK: gvk, Count: count, Err: err}
+	}
+}
+
+func (m *Model) recentRefs() []store.GVKRef {
+	if m.recents == nil {
+		return nil
+	}
+	return m.recents.List()
+}
+
+func (m *Model) pushRecent(gvk schema.GroupVersionKind) {
+	if m.recents == nil {
+		return
+	}
+	m.recents.Push(store.GVKRef{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind})
+	if err := m.recents.Save(); err != nil {
+		log.Printf("[WARN] failed to save recent kinds: %v", err)
+	}
+}
+
 func (m *Model) setVisible(visible bool) {
 	m.visible = visible
 }
@@ -155,78 +293,221 @@ func (m *Model) setViewSize(msg tea.WindowSizeMsg) {
 
 func (m *Model) reset() {
 	m.input.Reset()
-	m.cursor = 0
-	m.setSearchResults(m.items)
 	m.srViewport.SetYOffset(0)
+	m.moveCursorTop(m.rows())
 }
 
-func (m *Model) setSearchResults(items kbarItems) {
+func (m *Model) setSearchResults(rows []kbarRow) {
 	var newSearchResults searchResults
-	for index, item := range items {
+	for index, row := range rows {
+		if row.item == nil {
+			newSearchResults = append(newSearchResults, searchResult{Header: groupName(row.group)})
+			continue
+		}
 		newSearchResults = append(newSearchResults, searchResult{
-			Item:    item,
+			Item:    *row.item,
 			Hovered: m.cursor == index-m.srViewport.YOffset,
+			Count:   m.counts[row.item.GroupVersionKind],
 		})
 	}
 	m.searchResults = newSearchResults
 }
 
-func (m *Model) moveCursorTop(items kbarItems) {
+// moveCursorTop hovers the first kind, below the header of its group
+func (m *Model) moveCursorTop(rows []kbarRow) {
 	m.cursor = 0
-	m.setSearchResults(items)
+	if len(rows) > 0 && rows[0].item == nil {
+		m.cursor = 1
+	}
+	m.setSearchResults(rows)
+}
+
+// moveCursor hovers the kind delta rows away, skipping group headers and scrolling to keep it in view
+func (m *Model) moveCursor(rows []kbarRow, delta int) {
+	index := m.cursor + m.srViewport.YOffset
+	next := index + delta
+	for next >= 0 && next < len(rows) && rows[next].item == nil {
+		next += delta
+	}
+	if next < 0 || next >= len(rows) {
+		if delta < 0 {
+			// nothing above, but reveal the header of the first group
+			m.cursor = index
+			m.srViewport.SetYOffset(0)
+		}
+		m.setSearchResults(rows)
+		return
+	}
+
+	offset := m.srViewport.YOffset
+	if top := next; top < offset {
+		if top > 0 && rows[top-1].item == nil {
+			top-- // keep the group header in sight
+		}
+		offset = top
+	}
+	if height := KBAR_SEARCH_RESULTS_MAX_HEIGHT; next >= offset+height {
+		offset = next - height + 1
+	}
+	m.srViewport.YOffset = offset // not clamped by the content rendered before the move
+	m.cursor = next - m.srViewport.YOffset
+	m.setSearchResults(rows)
 }
 
 // subcomponents(not model)
 type kbarItem struct {
-	schema.GroupVersionKind
+	kube.GVKInfo
 }
 type kbarItems []kbarItem
 
+// kbarRow is a kind, or the header of an API group when item is nil
+type kbarRow struct {
+	group string
+	item  *kbarItem
+}
+
 type searchResult struct {
+	Header  string // not selectable, the rest are empty
 	Item    kbarItem
 	Hovered bool
+	Count   *int64
 }
 
 type searchResults []searchResult
 
-func (i kbarItem) render(width int) string {
+// render shows the kind with its short name, an ns badge when namespaced,
+// group version and the object count when known
+func (i kbarItem) render(width int, count *int64) string {
 	l := lipgloss.NewStyle().
 		MaxWidth(width).
 		Padding(0, 0, 0, 1)
 	g := lipgloss.NewStyle().Foreground(theme.Subtext1())
-	s := lipgloss.JoinHorizontal(
-		lipgloss.Left,
-		i.Kind,
-		" ",
-		g.Render(i.Group),
-	)
+	sn := lipgloss.NewStyle().Foreground(theme.Overlay0())
+	ns := lipgloss.NewStyle().Foreground(theme.Teal())
+	parts := []string{i.Kind}
+	if len(i.ShortNames) > 0 {
+		parts = append(parts, " ", sn.Render("("+i.ShortNames[0]+")"))
+	}
+	if i.Namespaced {
+		parts = append(parts, " ", ns.Render("ns"))
+	}
+	if count != nil {
+		parts = append(parts, " ", sn.Render(fmt.Sprintf("(%d)", *count)))
+	}
+	parts = append(parts, " ", g.Render(i.GroupVersion().String()))
+	s := lipgloss.JoinHorizontal(lipgloss.Left, parts...)
 
 	return l.Render(s)
 }
 
+// recent returns the items of refs, in the order of refs
+func (m kbarItems) recent(refs []store.GVKRef) kbarItems {
+	var items kbarItems
+	for _, ref := range refs {
+		for _, item := range m {
+			if item.Group == ref.Group && item.Version == ref.Version && item.Kind == ref.Kind {
+				items = append(items, item)
+				break
+			}
+		}
+	}
+	return items
+}
+
+// withRecents lists the items of refs first, then the rest without repeating them
+func (m kbarItems) withRecents(refs []store.GVKRef) kbarItems {
+	items := m.recent(refs)
+	if len(items) == 0 {
+		return m
+	}
+
+	seen := make(map[schema.GroupVersionKind]struct{}, len(items))
+	for _, item := range items {
+		seen[item.GroupVersionKind] = struct{}{}
+	}
+	for _, item := range m {
+		if _, ok := seen[item.GroupVersionKind]; ok {
+			continue
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
 func (m kbarItems) filter(inputValue string) kbarItems {
 	if inputValue == "" {
 		return m
 	}
 
+	// exact short names, then categories, rank before fuzzy matches
 	var items kbarItems
-	var itemStrings []string
-	for _, item := range m {
-		itemStrings = append(itemStrings, item.String())
+	added := map[int]struct{}{}
+	for _, matchesAlias := range []func(kbarItem) bool{
+		func(item kbarItem) bool { return containsFold(item.ShortNames, inputValue) },
+		func(item kbarItem) bool { return containsFold(item.Categories, inputValue) },
+	} {
+		for idx, item := range m {
+			if _, ok := added[idx]; ok || !matchesAlias(item) {
+				continue
+			}
+			added[idx] = struct{}{}
+			items = append(items, item)
+		}
+	}
+
+	// aliases are searched along with the kind, e.g. "al" finds the members of the all category,
+	// an item ranks by its best scored string
+	var (
+		itemStrings []string
+		owners      []int
+	)
+	for idx, item := range m {
+		for _, s := range slices.Concat([]string{item.String()}, item.ShortNames, item.Categories) {
+			itemStrings = append(itemStrings, s)
+			owners = append(owners, idx)
+		}
 	}
 	matches := fuzzy.Find(inputValue, itemStrings)
 	for _, match := range matches {
-		items = append(items, m[match.Index])
+		idx := owners[match.Index]
+		if _, ok := added[idx]; ok {
+			continue
+		}
+		added[idx] = struct{}{}
+		items = append(items, m[idx])
 	}
 	return items
 }
 
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func groupName(group string) string {
+	if group == "" {
+		return "core"
+	}
+	return group
+}
+
 func (sr searchResult) render(width int) string {
+	if sr.Header != "" {
+		return lipgloss.NewStyle().
+			Foreground(theme.Overlay0()).
+			Bold(true).
+			MaxWidth(width).
+			Render(sr.Header)
+	}
 	style := lipgloss.NewStyle()
 	if sr.Hovered {
 		style = style.Background(theme.Overlay0())
 	}
-	return style.Render(sr.Item.render(width))
+	return style.Render(sr.Item.render(width, sr.Count))
 }
 
 func (sr searchResults) string(width int) string {
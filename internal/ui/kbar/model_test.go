@@ -0,0 +1,235 @@
+package kbar
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/flavono123/kattle/internal/kube"
+	"github.com/flavono123/kattle/internal/store"
+)
+
+func TestFilter(t *testing.T) {
+	pod := kbarItem{GVKInfo: kube.GVKInfo{
+		GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "Pod"},
+		ShortNames:       []string{"po"},
+		Categories:       []string{"all"},
+	}}
+	podTemplate := kbarItem{GVKInfo: kube.GVKInfo{
+		GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "PodTemplate"},
+	}}
+	deploy := kbarItem{GVKInfo: kube.GVKInfo{
+		GroupVersionKind: schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+		ShortNames:       []string{"deploy"},
+		Categories:       []string{"all"},
+	}}
+	items := kbarItems{podTemplate, deploy, pod}
+
+	kinds := func(items kbarItems) []string {
+		var result []string
+		for _, item := range items {
+			result = append(result, item.Kind)
+		}
+		return result
+	}
+
+	t.Run("short name resolves to its kind first", func(t *testing.T) {
+		filtered := items.filter("po")
+		assert.Equal(t, "Pod", filtered[0].Kind)
+		assert.Equal(t, schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, filtered[0].GroupVersionKind)
+	})
+
+	t.Run("short name matches ignore case", func(t *testing.T) {
+		assert.Equal(t, "Deployment", items.filter("DEPLOY")[0].Kind)
+	})
+
+	t.Run("category lists every member", func(t *testing.T) {
+		assert.Equal(t, []string{"Deployment", "Pod"}, kinds(items.filter("all"))[:2])
+	})
+
+	t.Run("aliases are fuzzy matched too", func(t *testing.T) {
+		// Pod's kind string has no "a", its category does
+		assert.Contains(t, kinds(items.filter("al")), "Pod")
+	})
+
+	t.Run("no duplicates with fuzzy matches", func(t *testing.T) {
+		filtered := items.filter("po")
+		seen := map[string]bool{}
+		for _, kind := range kinds(filtered) {
+			assert.False(t, seen[kind], kind)
+			seen[kind] = true
+		}
+	})
+}
+
+func TestWithRecents(t *testing.T) {
+	pod := kbarItem{GVKInfo: kube.GVKInfo{
+		GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "Pod"},
+	}}
+	svc := kbarItem{GVKInfo: kube.GVKInfo{
+		GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "Service"},
+	}}
+	deploy := kbarItem{GVKInfo: kube.GVKInfo{
+		GroupVersionKind: schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+	}}
+	items := kbarItems{pod, svc, deploy}
+
+	t.Run("recents come first without repeating", func(t *testing.T) {
+		refs := []store.GVKRef{
+			{Group: "apps", Version: "v1", Kind: "Deployment"},
+			{Version: "v1", Kind: "Pod"},
+		}
+		assert.Equal(t, kbarItems{deploy, pod, svc}, items.withRecents(refs))
+	})
+
+	t.Run("kinds missing from the cluster are skipped", func(t *testing.T) {
+		refs := []store.GVKRef{{Group: "example.com", Version: "v1", Kind: "Widget"}}
+		assert.Equal(t, items, items.withRecents(refs))
+		assert.Empty(t, items.recent(refs))
+	})
+}
+
+func TestRenderScope(t *testing.T) {
+	pod := kbarItem{GVKInfo: kube.GVKInfo{
+		GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "Pod"},
+		Namespaced:       true,
+	}}
+	node := kbarItem{GVKInfo: kube.GVKInfo{
+		GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "Node"},
+	}}
+
+	assert.Contains(t, pod.render(80, nil), "Pod ns v1")
+	assert.NotContains(t, node.render(80, nil), "ns")
+}
+
+func TestGrouped(t *testing.T) {
+	gvk := func(group, kind string) kbarItem {
+		return kbarItem{GVKInfo: kube.GVKInfo{GroupVersionKind: schema.GroupVersionKind{Group: group, Version: "v1", Kind: kind}, Preferred: true}}
+	}
+	m := &Model{
+		keys:       newKeyMap(),
+		visible:    true,
+		items:      kbarItems{gvk("apps", "Deployment"), gvk("", "Pod"), gvk("apps", "DaemonSet"), gvk("", "Service")},
+		input:      textinput.New(),
+		srViewport: viewport.New(0, KBAR_SEARCH_RESULTS_MAX_HEIGHT),
+		counts:     make(map[schema.GroupVersionKind]*int64),
+		countFunc:  func(schema.GroupVersionKind) (int64, error) { return 0, nil },
+	}
+	hovered := func() string {
+		return m.rows()[m.cursor+m.srViewport.YOffset].item.Kind
+	}
+	headers := func() []string {
+		var result []string
+		for _, row := range m.rows() {
+			if row.item == nil {
+				result = append(result, row.group)
+			}
+		}
+		return result
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlG})
+	assert.Equal(t, []string{"", "apps"}, headers())
+	assert.Equal(t, "Pod", hovered())
+
+	t.Run("cursor skips group headers", func(t *testing.T) {
+		m.Update(tea.KeyMsg{Type: tea.KeyDown})
+		assert.Equal(t, "Service", hovered())
+		m.Update(tea.KeyMsg{Type: tea.KeyDown})
+		assert.Equal(t, "Deployment", hovered())
+		m.Update(tea.KeyMsg{Type: tea.KeyUp})
+		assert.Equal(t, "Service", hovered())
+	})
+
+	t.Run("filtering hides groups without matches", func(t *testing.T) {
+		m.input.SetValue("daemon")
+		assert.Equal(t, []string{"apps"}, headers())
+	})
+}
+
+func TestToggleVersions(t *testing.T) {
+	hpa := func(version string, preferred bool) kbarItem {
+		return kbarItem{GVKInfo: kube.GVKInfo{
+			GroupVersionKind: schema.GroupVersionKind{Group: "autoscaling", Version: version, Kind: "HorizontalPodAutoscaler"},
+			ShortNames:       []string{"hpa"},
+			Preferred:        preferred,
+		}}
+	}
+	m := &Model{
+		keys:       newKeyMap(),
+		visible:    true,
+		items:      kbarItems{hpa("v2", true), hpa("v1", false)},
+		input:      textinput.New(),
+		srViewport: viewport.New(0, KBAR_SEARCH_RESULTS_MAX_HEIGHT),
+		counts:     make(map[schema.GroupVersionKind]*int64),
+		countFunc:  func(schema.GroupVersionKind) (int64, error) { return 0, nil },
+	}
+	versions := func() []string {
+		var result []string
+		for _, row := range m.rows() {
+			result = append(result, row.item.Version)
+		}
+		return result
+	}
+
+	m.input.SetValue("hpa")
+	assert.Equal(t, []string{"v2"}, versions(), "only the preferred version by default")
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlV})
+	assert.Equal(t, []string{"v2", "v1"}, versions())
+	assert.Equal(t, "hpa", m.input.Value())
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlV})
+	assert.Equal(t, []string{"v2"}, versions())
+
+	t.Run("the input does not see the toggle", func(t *testing.T) {
+		m.input.Focus()
+		m.keys.toggleVersions = key.NewBinding(key.WithKeys("alt+v"))
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v"), Alt: true})
+		assert.Equal(t, "hpa", m.input.Value())
+		assert.Equal(t, []string{"v2", "v1"}, versions())
+	})
+}
+
+func TestCountHovered(t *testing.T) {
+	pod := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	binding := schema.GroupVersionKind{Version: "v1", Kind: "Binding"}
+	calls := 0
+	m := &Model{
+		items: kbarItems{
+			{GVKInfo: kube.GVKInfo{GroupVersionKind: pod, Preferred: true}},
+			{GVKInfo: kube.GVKInfo{GroupVersionKind: binding, Preferred: true}},
+		},
+		input:      textinput.New(),
+		srViewport: viewport.New(0, 0),
+		counts:     make(map[schema.GroupVersionKind]*int64),
+		countFunc: func(gvk schema.GroupVersionKind) (int64, error) {
+			calls++
+			if gvk == binding {
+				return 0, errors.New("forbidden")
+			}
+			return 42, nil
+		},
+	}
+
+	t.Run("caches the hovered kind's count", func(t *testing.T) {
+		m.Update(m.countHovered()())
+		assert.Nil(t, m.countHovered())
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, int64(42), *m.counts[pod])
+		assert.Contains(t, m.items[0].render(80, m.counts[pod]), "(42)")
+	})
+
+	t.Run("errors render no count", func(t *testing.T) {
+		m.cursor = 1
+		m.Update(m.countHovered()())
+		assert.Nil(t, m.counts[binding])
+		assert.NotContains(t, m.items[1].render(80, m.counts[binding]), "(")
+	})
+}
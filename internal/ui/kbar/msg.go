@@ -2,6 +2,7 @@ package kbar
 
 import (
 	tea "github.com/charmbracelet/bubbletea"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/flavono123/kattle/internal/ui/event"
 )
@@ -24,3 +25,10 @@ func Hide() tea.Cmd {
 		},
 	)
 }
+
+// countMsg carries the object count of a hovered kind
+type countMsg struct {
+	GVK   schema.GroupVersionKind
+	Count int64
+	Err   error
+}
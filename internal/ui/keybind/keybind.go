@@ -0,0 +1,76 @@
+// Package keybind applies the key bindings configured by the user over the keymap defaults.
+package keybind
+
+import (
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/key"
+
+	"github.com/flavono123/kattle/internal/config"
+)
+
+// Sections that can be rebound, each overriding the keymap of a pane
+const (
+	Global = "global"
+	Schema = "schema"
+	Result = "result"
+	Table  = "table"
+	Kbar   = "kbar"
+)
+
+var (
+	mu        sync.RWMutex
+	overrides config.KeysConfig
+)
+
+// Apply keeps cfg to rebind keymaps created afterwards, unknown sections are ignored.
+// Call before the model builds its keymaps.
+func Apply(cfg config.KeysConfig) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	overrides = config.KeysConfig{}
+	for section, bindings := range cfg {
+		switch section {
+		case Global, Schema, Result, Table, Kbar:
+			overrides[section] = bindings
+		default:
+			log.Printf("[WARN] unknown key binding section %q, ignored", section)
+		}
+	}
+}
+
+// Rebind replaces the keys of the named bindings configured for section, keeping their help text.
+// Names configured without a matching binding are ignored.
+func Rebind(section string, bindings map[string]*key.Binding) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	for name, keys := range overrides[section] {
+		binding, ok := bindings[name]
+		if !ok {
+			log.Printf("[WARN] unknown key binding %s.%s, ignored", section, name)
+			continue
+		}
+		if len(keys) == 0 {
+			log.Printf("[WARN] no keys for key binding %s.%s, ignored", section, name)
+			continue
+		}
+
+		binding.SetKeys(keys...)
+		if help := binding.Help(); help.Desc != "" {
+			binding.SetHelp(helpKey(keys[0]), help.Desc)
+		}
+	}
+}
+
+// helpKey abbreviates a key the way the default help does, e.g. ctrl+k as ^+k
+func helpKey(k string) string {
+	k = strings.Replace(k, "ctrl+", "^+", 1)
+	if k == " " {
+		return "spc"
+	}
+	return strings.Replace(k, "+ ", "+spc", 1)
+}
@@ -0,0 +1,46 @@
+package keybind
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flavono123/kattle/internal/config"
+)
+
+func TestRebind(t *testing.T) {
+	t.Cleanup(func() { Apply(nil) })
+
+	Apply(config.KeysConfig{
+		Table: {
+			"sort":    {"o", "ctrl+o"},
+			"missing": {"x"},
+			"reverse": {},
+		},
+		"unknown": {"sort": {"z"}},
+	})
+
+	sort := key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "sort column"))
+	reverse := key.NewBinding(key.WithKeys("S"), key.WithHelp("S", "reverse"))
+	up := key.NewBinding(key.WithKeys("up"))
+	Rebind(Table, map[string]*key.Binding{"sort": &sort, "reverse": &reverse, "up": &up})
+
+	assert.Equal(t, []string{"o", "ctrl+o"}, sort.Keys())
+	assert.Equal(t, key.Help{Key: "o", Desc: "sort column"}, sort.Help())
+	// no keys configured keeps the default
+	assert.Equal(t, []string{"S"}, reverse.Keys())
+	assert.Equal(t, []string{"up"}, up.Keys())
+
+	// other sections are left alone
+	other := key.NewBinding(key.WithKeys("s"))
+	Rebind(Schema, map[string]*key.Binding{"sort": &other})
+	assert.Equal(t, []string{"s"}, other.Keys())
+}
+
+func TestHelpKey(t *testing.T) {
+	assert.Equal(t, "^+l", helpKey("ctrl+l"))
+	assert.Equal(t, "spc", helpKey(" "))
+	assert.Equal(t, "^+spc", helpKey("ctrl+ "))
+	assert.Equal(t, "alt+k", helpKey("alt+k"))
+}
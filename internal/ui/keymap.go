@@ -1,32 +1,93 @@
 package ui
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"github.com/charmbracelet/bubbles/key"
+
+	"github.com/flavono123/kattle/internal/ui/keybind"
+)
 
 type keyMap struct {
-	quit       key.Binding
-	hideKbar   key.Binding
-	toggleKbar key.Binding
-	tabView    key.Binding
+	quit            key.Binding
+	hideKbar        key.Binding
+	toggleKbar      key.Binding
+	toggleNamespace key.Binding
+	tabView         key.Binding
+	dryRun          key.Binding
+	copyCmd         key.Binding
+	export          key.Binding
+	favorites       key.Binding
+	saveFavorite    key.Binding
+	jsonPathColumn  key.Binding
 }
 
-func newKeyMap() keyMap {
-	return keyMap{
+func newKeyMap(allowMutations bool) keyMap {
+	km := keyMap{
 		quit:     key.NewBinding(key.WithKeys("ctrl+c")),
 		hideKbar: key.NewBinding(key.WithKeys("esc", "alt+k")),
 		toggleKbar: key.NewBinding(
 			key.WithKeys("ctrl+k"),
 			key.WithHelp("^+k", "kinds"),
 		),
+		toggleNamespace: key.NewBinding(
+			key.WithKeys("ctrl+n"),
+			key.WithHelp("^+n", "namespaces"),
+		),
 		tabView: key.NewBinding(
 			key.WithKeys("tab"),
 			key.WithHelp("tab", "switch schema/result"),
 		),
+		dryRun: key.NewBinding(
+			key.WithKeys("ctrl+t"),
+			key.WithHelp("^+t", "dry-run"),
+		),
+		copyCmd: key.NewBinding(
+			key.WithKeys("ctrl+y"),
+			key.WithHelp("^+y", "copy kubectl"),
+		),
+		export: key.NewBinding(
+			key.WithKeys("ctrl+x"),
+			key.WithHelp("^+x", "export"),
+		),
+		favorites: key.NewBinding(
+			key.WithKeys("ctrl+o"),
+			key.WithHelp("^+o", "favorites"),
+		),
+		saveFavorite: key.NewBinding(
+			key.WithKeys("ctrl+s"),
+			key.WithHelp("^+s", "save favorite"),
+		),
+		jsonPathColumn: key.NewBinding(
+			key.WithKeys("ctrl+e"),
+			key.WithHelp("^+e", "jsonpath column"),
+		),
 	}
+	keybind.Rebind(keybind.Global, map[string]*key.Binding{
+		"quit":            &km.quit,
+		"hideKbar":        &km.hideKbar,
+		"toggleKbar":      &km.toggleKbar,
+		"toggleNamespace": &km.toggleNamespace,
+		"tabView":         &km.tabView,
+		"dryRun":          &km.dryRun,
+		"copyCmd":         &km.copyCmd,
+		"export":          &km.export,
+		"favorites":       &km.favorites,
+		"saveFavorite":    &km.saveFavorite,
+		"jsonPathColumn":  &km.jsonPathColumn,
+	})
+	km.dryRun.SetEnabled(allowMutations)
+	return km
 }
 
 func (k keyMap) ShortHelp() []key.Binding {
 	return []key.Binding{
 		k.toggleKbar,
+		k.toggleNamespace,
+		k.dryRun,
+		k.copyCmd,
+		k.export,
+		k.favorites,
+		k.saveFavorite,
+		k.jsonPathColumn,
 	}
 }
 
@@ -35,3 +96,117 @@ func (k keyMap) FullHelp() [][]key.Binding {
 		{}, // only render short help
 	}
 }
+
+// quitPromptKeyMap answers the confirmation shown when quitting with an unsaved view
+type quitPromptKeyMap struct {
+	save    key.Binding
+	discard key.Binding
+	cancel  key.Binding
+}
+
+func newQuitPromptKeyMap() quitPromptKeyMap {
+	return quitPromptKeyMap{
+		save: key.NewBinding(
+			key.WithKeys("s", "y"),
+			key.WithHelp("s", "save as favorite and quit"),
+		),
+		discard: key.NewBinding(
+			key.WithKeys("d", "n", "ctrl+c"),
+			key.WithHelp("d", "discard and quit"),
+		),
+		cancel: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel"),
+		),
+	}
+}
+
+func (k quitPromptKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		k.save,
+		k.discard,
+		k.cancel,
+	}
+}
+
+func (k quitPromptKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{}, // only render short help
+	}
+}
+
+// exportPromptKeyMap picks the format after the export key
+type exportPromptKeyMap struct {
+	yaml     key.Binding
+	csv      key.Binding
+	markdown key.Binding
+	cancel   key.Binding
+}
+
+func newExportPromptKeyMap() exportPromptKeyMap {
+	return exportPromptKeyMap{
+		yaml: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "yaml"),
+		),
+		csv: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "csv"),
+		),
+		markdown: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "markdown"),
+		),
+		cancel: key.NewBinding(
+			key.WithKeys("esc", "ctrl+x"),
+			key.WithHelp("esc", "cancel"),
+		),
+	}
+}
+
+func (k exportPromptKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		k.yaml,
+		k.csv,
+		k.markdown,
+		k.cancel,
+	}
+}
+
+func (k exportPromptKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{}, // only render short help
+	}
+}
+
+// inputPromptKeyMap answers a prompt typed in the status bar, e.g. the name of a favorite
+type inputPromptKeyMap struct {
+	submit key.Binding
+	cancel key.Binding
+}
+
+func newInputPromptKeyMap(submitHelp string) inputPromptKeyMap {
+	return inputPromptKeyMap{
+		submit: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", submitHelp),
+		),
+		cancel: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel"),
+		),
+	}
+}
+
+func (k inputPromptKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		k.submit,
+		k.cancel,
+	}
+}
+
+func (k inputPromptKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{}, // only render short help
+	}
+}
@@ -0,0 +1,28 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flavono123/kattle/internal/config"
+	"github.com/flavono123/kattle/internal/ui/keybind"
+)
+
+func TestKeyMapRebind(t *testing.T) {
+	t.Cleanup(func() { keybind.Apply(nil) })
+
+	cfg, err := config.Parse([]byte(`{
+		"keys": {
+			"global": { "tabView": ["ctrl+l"], "noSuchBinding": ["x"] }
+		}
+	}`))
+	require.NoError(t, err)
+	keybind.Apply(cfg.Keys)
+
+	keys := newKeyMap(false)
+	assert.Equal(t, []string{"ctrl+l"}, keys.tabView.Keys())
+	assert.Equal(t, "^+l", keys.tabView.Help().Key)
+	assert.Equal(t, []string{"ctrl+k"}, keys.toggleKbar.Keys())
+}
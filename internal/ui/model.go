@@ -1,22 +1,33 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
+	"github.com/flavono123/kattle/internal/export"
 	"github.com/flavono123/kattle/internal/kube"
+	"github.com/flavono123/kattle/internal/store"
+	"github.com/flavono123/kattle/internal/ui/aggregate"
 	"github.com/flavono123/kattle/internal/ui/event"
+	"github.com/flavono123/kattle/internal/ui/favorite"
 	"github.com/flavono123/kattle/internal/ui/kbar"
+	"github.com/flavono123/kattle/internal/ui/namespace"
 	"github.com/flavono123/kattle/internal/ui/nav"
 	"github.com/flavono123/kattle/internal/ui/result"
 	"github.com/flavono123/kattle/internal/ui/theme"
@@ -28,85 +39,193 @@ const (
 	schemaView sessionState = iota
 	resultView
 	kbarView
+	aggregateView
+	namespaceView
+	favoriteView
 )
 
+// Options configures the root model
+type Options struct {
+	// AllowMutations enables actions that send write requests (always dry-run) to the cluster
+	AllowMutations bool
+	// ConfirmQuit asks before quitting with picked fields that match no favorite view
+	ConfirmQuit bool
+	// Favorites stores the views saved from the quit confirmation and applied from the picker, disabled when nil
+	Favorites *store.Store
+	// Recents remembers the kinds picked from the kbar, not persisted when nil
+	Recents *store.Recents
+	// ListLimit caps the objects listed per kind, 0 for unlimited
+	ListLimit int64
+	// GVK is the kind opened first, Service when empty
+	GVK schema.GroupVersionKind
+	// Context is the kubeconfig context to browse, the current one when empty
+	Context string
+	// Namespace is the namespace informed first, all namespaces when empty
+	Namespace string
+}
+
 type Model struct {
 	session        sessionState
 	lastTabSession sessionState
 	keys           keyMap
+	quitKeys       quitPromptKeyMap
+	confirmQuit    bool
+	quitPrompt     bool
+	exportKeys     exportPromptKeyMap
+	exportPrompt   bool
+	saveKeys       inputPromptKeyMap
+	savePrompt     bool // naming the picked fields to save as a favorite
+	nameInput      textinput.Model
+	exprKeys       inputPromptKeyMap
+	exprPrompt     bool // typing the JSONPath of a column to pick
+	exprInput      textinput.Model
+	themeVersion   int // of the palette the help, name input and spinner are styled with
+	favorites      *store.Store
 	help           help.Model
 	vp             viewport.Model
 	nav            *nav.Model
 	result         *result.Model
 	gvk            schema.GroupVersionKind
 	controller     *kube.ResourceController
+	context        string
+	namespace      string // informed when the kind is namespaced, empty for all namespaces
+	clusterScoped  bool
+	listLimit      int64
 	stop           chan struct{}
+	syncing        bool // the controller is listing the objects, see inform
+	spinner        spinner.Model
 	selectedNodes  []*kube.Node
 	kbar           *kbar.Model
+	aggregate      *aggregate.Model
+	nsPicker       *namespace.Model
+	favPicker      *favorite.Model
 	status         event.Status
 	statusMsg      string
 	showStatus     bool
 	statusTimer    *time.Timer
 }
 
-func NewModel() *Model {
+func NewModel(opts Options) *Model {
 	initGvk := schema.GroupVersionKind{
 		Group:   "",
 		Version: "v1",
 		Kind:    "Service",
 	}
-	gvr, err := kube.GetGVR(initGvk)
+	if !opts.GVK.Empty() {
+		initGvk = opts.GVK
+	}
+	contextName := opts.Context
+	if contextName == "" {
+		// left empty on errors, the kube calls fall back to the current context themselves
+		current, err := kube.CurrentContext()
+		if err != nil {
+			log.Printf("failed to get current context: %v", err)
+		}
+		contextName = current
+	}
+	controller, clusterScoped, err := newController(contextName, opts.Namespace, initGvk, opts.ListLimit)
 	if err != nil {
 		log.Fatalf("failed to get gvr: %v", err)
 	}
-	controller := kube.NewResourceController(gvr)
-	if _, err := controller.Inform(); err != nil {
+	stop, err := controller.Inform()
+	if err != nil {
 		log.Fatalf("failed to start informer: %v", err)
 	}
 
-	helpKeyStyle := lipgloss.NewStyle().Foreground(theme.Lavender())
-	helpDescStyle := lipgloss.NewStyle().Foreground(theme.Subtext0())
-	helpSepStyle := lipgloss.NewStyle().Foreground(theme.Surface1())
 	customHelp := help.Model{
 		ShortSeparator: " · ",
-		Styles: help.Styles{
-			ShortKey:       helpKeyStyle,
-			ShortDesc:      helpDescStyle,
-			ShortSeparator: helpSepStyle,
-		},
 	}
-	return &Model{
+	keys := newKeyMap(opts.AllowMutations)
+	keys.favorites.SetEnabled(opts.Favorites != nil)
+	keys.saveFavorite.SetEnabled(opts.Favorites != nil)
+	nameInput := textinput.New()
+	nameInput.Prompt = ""
+	nameInput.Width = 30
+	exprInput := textinput.New()
+	exprInput.Prompt = ""
+	exprInput.Placeholder = `.spec.containers[?(@.name=="app")].image`
+	exprInput.Width = 50
+	m := &Model{
 		session:        schemaView,
 		lastTabSession: schemaView,
-		keys:           newKeyMap(),
+		keys:           keys,
+		quitKeys:       newQuitPromptKeyMap(),
+		exportKeys:     newExportPromptKeyMap(),
+		saveKeys:       newInputPromptKeyMap("save"),
+		nameInput:      nameInput,
+		exprKeys:       newInputPromptKeyMap("pick"),
+		exprInput:      exprInput,
+		confirmQuit:    opts.ConfirmQuit && opts.Favorites != nil,
+		favorites:      opts.Favorites,
 		help:           customHelp,
-		nav:            nav.NewModel(initGvk, controller.Objects()),
+		nav:            nav.NewModel(contextName, initGvk, controller.Objects()),
 		result:         result.NewModel(controller.Objects()),
 		vp:             viewport.New(0, 0),
 		gvk:            initGvk,
-		kbar:           kbar.NewModel(),
+		kbar:           kbar.NewModel(opts.Recents, contextName),
+		aggregate:      aggregate.NewModel(),
+		nsPicker:       namespace.NewModel(contextName),
+		favPicker:      favorite.NewModel(),
 		controller:     controller,
-		stop:           nil,
+		context:        contextName,
+		namespace:      opts.Namespace,
+		clusterScoped:  clusterScoped,
+		listLimit:      opts.ListLimit,
+		stop:           stop,
+		spinner:        spinner.New(spinner.WithSpinner(spinner.MiniDot)),
 		selectedNodes:  []*kube.Node{},
 		statusTimer:    nil,
+		themeVersion:   -1, // styled below
 	}
+	m.restyle()
+	return m
+}
+
+// restyle rebuilds the help, name input and spinner colors when the palette changed since
+func (m *Model) restyle() {
+	if m.themeVersion == theme.Version() {
+		return
+	}
+	m.help.Styles = help.Styles{
+		ShortKey:       lipgloss.NewStyle().Foreground(theme.Lavender()),
+		ShortDesc:      lipgloss.NewStyle().Foreground(theme.Subtext0()),
+		ShortSeparator: lipgloss.NewStyle().Foreground(theme.Surface1()),
+	}
+	m.nameInput.TextStyle = lipgloss.NewStyle().Foreground(theme.Lavender())
+	m.exprInput.TextStyle = lipgloss.NewStyle().Foreground(theme.Lavender())
+	m.spinner.Style = lipgloss.NewStyle().Foreground(theme.Blue())
+	m.themeVersion = theme.Version()
 }
 
 func (m *Model) Init() tea.Cmd {
-	m.inform()
-	return m.listenController()
+	return tea.Batch(m.listenController(), m.setNavNamespace(), m.setTableScope(), m.nav.Init())
 }
 
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.quitPrompt {
+		return m, m.answerQuitPrompt(keyMsg)
+	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.exportPrompt {
+		return m, m.answerExportPrompt(keyMsg)
+	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.savePrompt {
+		return m, m.answerSavePrompt(keyMsg)
+	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.exprPrompt {
+		return m, m.answerExprPrompt(keyMsg)
+	}
+
 	if keyMsg, ok := msg.(tea.KeyMsg); ok {
 		if key.Matches(keyMsg, m.keys.toggleKbar) {
 			if m.session == kbarView {
 				m.session = m.lastTabSession
 				cmds = append(cmds, kbar.Hide())
 			} else {
-				m.lastTabSession = m.session
+				if m.session != aggregateView && m.session != namespaceView && m.session != favoriteView { // overlays are left for where they were opened from
+					m.lastTabSession = m.session
+				}
 				m.session = kbarView
 				m.nav.Blur()
 				m.result.Blur()
@@ -114,6 +233,34 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		if key.Matches(keyMsg, m.keys.toggleNamespace) {
+			switch m.session {
+			case namespaceView:
+				m.session = m.lastTabSession
+				cmds = append(cmds, namespace.Hide())
+			case schemaView, resultView:
+				m.lastTabSession = m.session
+				m.session = namespaceView
+				m.nav.Blur()
+				m.result.Blur()
+				cmds = append(cmds, m.nsPicker.Focus(m.namespace))
+			}
+		}
+
+		if key.Matches(keyMsg, m.keys.favorites) {
+			switch m.session {
+			case favoriteView:
+				m.session = m.lastTabSession
+				cmds = append(cmds, favorite.Hide())
+			case schemaView, resultView:
+				m.lastTabSession = m.session
+				m.session = favoriteView
+				m.nav.Blur()
+				m.result.Blur()
+				cmds = append(cmds, m.favPicker.Focus(m.favorites.ListByGVK(m.gvkRef())))
+			}
+		}
+
 		switch m.session {
 		case schemaView:
 			nm, nCmd := m.nav.Update(msg)
@@ -127,6 +274,18 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			km, kCmd := m.kbar.Update(msg)
 			m.kbar = km.(*kbar.Model)
 			cmds = append(cmds, kCmd)
+		case aggregateView:
+			am, aCmd := m.aggregate.Update(msg)
+			m.aggregate = am.(*aggregate.Model)
+			cmds = append(cmds, aCmd)
+		case namespaceView:
+			pm, pCmd := m.nsPicker.Update(msg)
+			m.nsPicker = pm.(*namespace.Model)
+			cmds = append(cmds, pCmd)
+		case favoriteView:
+			fm, fCmd := m.favPicker.Update(msg)
+			m.favPicker = fm.(*favorite.Model)
+			cmds = append(cmds, fCmd)
 		}
 
 		switch {
@@ -143,7 +302,23 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.result.Blur()
 				cmds = append(cmds, m.nav.Focus())
 			} // do nothing when kbar session
+		case key.Matches(keyMsg, m.keys.dryRun):
+			cmds = append(cmds, m.dryRun())
+		case key.Matches(keyMsg, m.keys.copyCmd):
+			cmds = append(cmds, m.copyKubectlCmd())
+		case key.Matches(keyMsg, m.keys.export) && m.inTabView():
+			m.exportPrompt = true
+		case key.Matches(keyMsg, m.keys.saveFavorite) && m.inTabView():
+			cmds = append(cmds, m.openSavePrompt())
+		case key.Matches(keyMsg, m.keys.jsonPathColumn) && m.inTabView():
+			m.exprPrompt = true
+			m.exprInput.Reset()
+			cmds = append(cmds, m.exprInput.Focus())
 		case key.Matches(keyMsg, m.keys.quit):
+			if m.hasUnsavedView() {
+				m.quitPrompt = true
+				break
+			}
 			cmds = append(cmds, tea.Quit)
 		}
 	} else {
@@ -158,6 +333,29 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		km, kCmd := m.kbar.Update(msg)
 		m.kbar = km.(*kbar.Model)
 		cmds = append(cmds, kCmd)
+
+		am, aCmd := m.aggregate.Update(msg)
+		m.aggregate = am.(*aggregate.Model)
+		cmds = append(cmds, aCmd)
+
+		pm, pCmd := m.nsPicker.Update(msg)
+		m.nsPicker = pm.(*namespace.Model)
+		cmds = append(cmds, pCmd)
+
+		fm, fCmd := m.favPicker.Update(msg)
+		m.favPicker = fm.(*favorite.Model)
+		cmds = append(cmds, fCmd)
+
+		if m.savePrompt {
+			var iCmd tea.Cmd
+			m.nameInput, iCmd = m.nameInput.Update(msg)
+			cmds = append(cmds, iCmd)
+		}
+		if m.exprPrompt {
+			var iCmd tea.Cmd
+			m.exprInput, iCmd = m.exprInput.Update(msg)
+			cmds = append(cmds, iCmd)
+		}
 	}
 
 	switch msg := msg.(type) {
@@ -170,7 +368,16 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			cmds = append(cmds, m.result.Focus())
 		}
+	case event.DetachFieldMsg:
+		m.lastTabSession = m.session
+		m.session = aggregateView
+		m.nav.Blur()
+		m.result.Blur()
+		cmds = append(cmds, m.aggregate.Focus(msg.Node, m.controller.Objects()))
 	case event.UpdateObjsMsg:
+		if m.session == aggregateView {
+			m.aggregate.SetObjs(msg.Objs)
+		}
 		setResultCmd := func() tea.Msg {
 			return result.SetResultMsg{
 				Nodes:      m.selectedNodes,
@@ -181,17 +388,15 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, tea.Batch(
 			setResultCmd,
-			m.updateNavObjs(m.controller.Objects()),
+			m.updateNavObjs(msg.Objs),
 			m.listenController(),
 		)
 	case event.PickGVKMsg:
-		m.gvk = msg.GVK
-		m.setController(msg.GVK)
-		m.selectedNodes = []*kube.Node{}
-
-		cmds = append(cmds, m.setNavGVK(msg.GVK, m.controller.Objects()))
-		cmds = append(cmds, m.updateObjs(nil, m.controller.Objects()))
-		cmds = append(cmds, kbar.Hide())
+		cmds = append(cmds, m.pickGVK(msg.GVK), kbar.Hide())
+	case event.PickNamespaceMsg:
+		cmds = append(cmds, m.pickNamespace(msg.Namespace), namespace.Hide())
+	case event.ApplyFavoriteMsg:
+		cmds = append(cmds, favorite.Hide(), m.applyFavorite(msg.Name, msg.Fields))
 	case event.PickFieldMsg:
 		m.selectedNodes = append(m.selectedNodes, msg.Node)
 		return m, func() tea.Msg {
@@ -203,12 +408,16 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 	case event.UnpickFieldMsg:
-		for idx, node := range m.selectedNodes {
-			if node.Name() == msg.Node.Name() {
-				m.selectedNodes = append(m.selectedNodes[:idx], m.selectedNodes[idx+1:]...)
-				break
+		m.selectedNodes = unpickNode(m.selectedNodes, msg.Node)
+		return m, func() tea.Msg {
+			return result.SetResultMsg{
+				Nodes:      m.selectedNodes,
+				Objs:       m.controller.Objects(),
+				Picked:     false,
+				PickedNode: nil,
 			}
 		}
+	case event.AggregateFieldMsg:
 		return m, func() tea.Msg {
 			return result.SetResultMsg{
 				Nodes:      m.selectedNodes,
@@ -217,13 +426,26 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				PickedNode: nil,
 			}
 		}
-	case event.CancelPickMsg:
-		if msg.Canceled {
-			msg.Node.Selected = false
-			m.selectedNodes = append(m.selectedNodes[:len(m.selectedNodes)-1], m.selectedNodes[len(m.selectedNodes):]...)
-
-			cmds = append(cmds, errCannotPick(msg.Node))
+	case event.ControllerErrorMsg:
+		var limitErr *kube.ListLimitExceededError
+		if errors.As(msg.Err, &limitErr) {
+			log.Printf("[WARN] %s: %v", m.gvk.String(), limitErr)
+			cmds = append(cmds, m.listenController(), func() tea.Msg {
+				return event.SetStatusMsg{
+					Message: limitErr.Error(),
+					Status:  event.Warn,
+				}
+			})
+			break
 		}
+		err := fmt.Errorf("watching %s failed, objects may be stale: %w", m.gvk.String(), msg.Err)
+		log.Printf("[ERROR] %v", err)
+		cmds = append(cmds, m.listenController(), func() tea.Msg {
+			return event.SetStatusMsg{
+				Message: err.Error(),
+				Status:  event.Error,
+			}
+		})
 	case event.HoverFieldMsg:
 		return m, func() tea.Msg {
 			return result.SetTableCandidateMsg{
@@ -246,12 +468,23 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case event.HideStatusMsg:
 		m.showStatus = false
 		m.statusMsg = ""
+	case syncedMsg:
+		if msg.controller == m.controller {
+			m.syncing = false
+		}
+	case spinner.TickMsg:
+		if m.syncing {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			cmds = append(cmds, cmd)
+		}
 	}
 
 	return m, tea.Batch(cmds...)
 }
 
 func (m *Model) View() string {
+	m.restyle()
 	mainContent := lipgloss.JoinVertical(
 		lipgloss.Left,
 		lipgloss.JoinHorizontal(
@@ -263,15 +496,11 @@ func (m *Model) View() string {
 
 	m.vp.SetContent(mainContent)
 
-	if m.session == kbarView {
-		return lipgloss.Place(
-			m.vp.Width,
-			m.vp.Height,
-			lipgloss.Center,
-			UPPER_20,
-			m.kbar.View(),
-			lipgloss.WithWhitespaceBackground(theme.Mantle()),
-		)
+	// the quit prompt may be asked over an overlay, the status bar is drawn in its place
+	if !m.quitPrompt {
+		if overlay, ok := m.renderOverlay(); ok {
+			return overlay
+		}
 	}
 
 	return lipgloss.JoinVertical(
@@ -281,18 +510,82 @@ func (m *Model) View() string {
 	)
 }
 
+// renderOverlay places the kbar, a picker or the aggregate over the whole view, when one is open
+func (m *Model) renderOverlay() (string, bool) {
+	var view string
+	vertical := lipgloss.Position(UPPER_20)
+	switch m.session {
+	case kbarView:
+		view = m.kbar.View()
+	case namespaceView:
+		view = m.nsPicker.View()
+	case favoriteView:
+		view = m.favPicker.View()
+	case aggregateView:
+		view, vertical = m.aggregate.View(), lipgloss.Center
+	default:
+		return "", false
+	}
+	return lipgloss.Place(
+		m.vp.Width,
+		m.vp.Height,
+		lipgloss.Center,
+		vertical,
+		view,
+		lipgloss.WithWhitespaceBackground(theme.Mantle()),
+	), true
+}
+
+// inTabView reports whether the schema or result view is shown, the sessions the status bar
+// and its prompts are drawn in
+func (m *Model) inTabView() bool {
+	return m.session == schemaView || m.session == resultView
+}
+
 func (m *Model) renderStatusBar() string {
+	if m.quitPrompt {
+		prompt := lipgloss.NewStyle().Foreground(theme.Yellow()).Render("unsaved view, quit? ")
+		return prompt + m.help.View(m.quitKeys)
+	}
+	if m.exportPrompt {
+		prompt := lipgloss.NewStyle().Foreground(theme.Lavender()).Render("export as ")
+		return prompt + m.help.View(m.exportKeys)
+	}
+	if m.savePrompt {
+		prompt := lipgloss.NewStyle().Foreground(theme.Lavender()).Render("save favorite as ")
+		statusBar := prompt + m.nameInput.View() + m.help.View(m.saveKeys)
+		if m.showStatus {
+			statusBar += m.statusStyle().Render(m.statusMsg) // e.g. the name is taken
+		}
+		return statusBar
+	}
+	if m.exprPrompt {
+		prompt := lipgloss.NewStyle().Foreground(theme.Lavender()).Render("jsonpath ")
+		statusBar := prompt + m.exprInput.View() + m.help.View(m.exprKeys)
+		if m.showStatus {
+			statusBar += m.statusStyle().Render(m.statusMsg) // e.g. the expression is invalid
+		}
+		return statusBar
+	}
+
 	globalHelp := m.help.View(m.keys)
 	var sessionHelp string
-	if m.session == schemaView {
+	switch m.session {
+	case schemaView:
 		sessionHelp = m.help.View(m.nav.Keys())
-	} else {
+	case resultView:
+		sessionHelp = m.help.View(m.result.Keys())
+	default:
 		sessionHelp = ""
 	}
 
 	statusBar := lipgloss.NewStyle().
 		Render(globalHelp + sessionHelp)
 
+	if m.syncing {
+		statusBar += lipgloss.NewStyle().MarginLeft(2).Foreground(theme.Subtext0()).
+			Render(m.spinner.View() + " syncing " + m.gvk.Kind + "…")
+	}
 	if m.showStatus {
 		statusBar += m.statusStyle().Render(m.statusMsg)
 	}
@@ -313,30 +606,407 @@ func (m *Model) statusStyle() lipgloss.Style {
 	}
 }
 
-func errCannotPick(node *kube.Node) tea.Cmd {
+// dryRun validates a skeleton built from the required and picked fields
+// against the API server without persisting it
+func (m *Model) dryRun() tea.Cmd {
+	gvk := m.gvk
+	skeleton := kube.BuildSkeleton(gvk, m.nav.Fields(), m.selectedNodes)
 	return func() tea.Msg {
+		if err := kube.DryRunCreate(m.context, gvk, skeleton); err != nil {
+			return event.SetStatusMsg{
+				Message: err.Error(),
+				Status:  event.Error,
+			}
+		}
 		return event.SetStatusMsg{
-			Message: fmt.Sprintf("cannot pick `%s'", strings.Join(node.NodeFullPath(), ".")),
-			Status:  event.Error,
+			Message: fmt.Sprintf("%s manifest is valid (dry-run)", gvk.Kind),
+			Status:  event.Info,
+		}
+	}
+}
+
+// copyKubectlCmd copies the `kubectl get` equivalent of the current view,
+// showing the command in the status bar when no clipboard is available
+func (m *Model) copyKubectlCmd() tea.Cmd {
+	command := kube.BuildCustomColumns(m.controller.GVR(), m.selectedNodes, m.clusterScoped)
+	command.Context = m.controller.Context()
+	command.Namespace = m.controller.Namespace()
+	command.LabelSelector = m.controller.LabelSelector()
+	command.FieldSelector = m.controller.FieldSelector()
+	return func() tea.Msg {
+		if err := clipboard.WriteAll(command.String()); err != nil {
+			return event.SetStatusMsg{
+				Message: command.String(),
+				Status:  event.Warn,
+			}
+		}
+		return event.SetStatusMsg{
+			Message: "copied: " + command.String(),
+			Status:  event.Info,
 		}
 	}
 }
 
+func (m *Model) answerExportPrompt(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.exportKeys.yaml):
+		m.exportPrompt = false
+		return m.exportTable("yaml")
+	case key.Matches(msg, m.exportKeys.csv):
+		m.exportPrompt = false
+		return m.exportTable("csv")
+	case key.Matches(msg, m.exportKeys.markdown):
+		m.exportPrompt = false
+		return m.exportTable("md")
+	case key.Matches(msg, m.exportKeys.cancel):
+		m.exportPrompt = false
+	}
+	return nil
+}
+
+// exportTable writes the picked fields of the objects to a file of the format in the working directory
+// With the result pane focused, only the rows it shows are exported, in its order
+func (m *Model) exportTable(format string) tea.Cmd {
+	formatter, err := export.FormatterFor(format)
+	if err != nil {
+		return func() tea.Msg {
+			return event.SetStatusMsg{Message: err.Error(), Status: event.Error}
+		}
+	}
+	if len(m.selectedNodes) == 0 {
+		return func() tea.Msg {
+			return event.SetStatusMsg{
+				Message: "pick fields to export first",
+				Status:  event.Warn,
+			}
+		}
+	}
+
+	objs := m.controller.Objects()
+	if m.session == resultView {
+		objs = m.result.VisibleObjs()
+	}
+	nodes := append([]*kube.Node{}, m.selectedNodes...)
+	return func() tea.Msg {
+		path, err := export.WriteFile(".", format, func(w io.Writer) error {
+			return formatter.Format(nodes, objs, w)
+		})
+		if err != nil {
+			return event.SetStatusMsg{
+				Message: err.Error(),
+				Status:  event.Error,
+			}
+		}
+		return event.SetStatusMsg{
+			Message: fmt.Sprintf("exported %d objects to %s", len(objs), path),
+			Status:  event.Info,
+		}
+	}
+}
+
+// hasUnsavedView reports whether picked fields would be lost on quit
+func (m *Model) hasUnsavedView() bool {
+	if !m.confirmQuit {
+		return false
+	}
+	fields := m.selectedFields()
+	return len(fields) > 0 && !m.favorites.HasFields(m.gvkRef(), fields)
+}
+
+func (m *Model) answerQuitPrompt(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.quitKeys.save):
+		m.quitPrompt = false
+		if err := m.saveFavorite(m.defaultFavoriteName()); err != nil {
+			return func() tea.Msg {
+				return event.SetStatusMsg{
+					Message: err.Error(),
+					Status:  event.Error,
+				}
+			}
+		}
+		return tea.Quit
+	case key.Matches(msg, m.quitKeys.discard):
+		return tea.Quit
+	case key.Matches(msg, m.quitKeys.cancel):
+		m.quitPrompt = false
+	}
+	return nil
+}
+
+// answerExprPrompt picks a column computed by the typed JSONPath, or unpicks it when
+// it is picked already, keeping the prompt open on an invalid expression
+func (m *Model) answerExprPrompt(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.exprKeys.submit):
+		node, err := kube.NewJSONPathNode(m.exprInput.Value())
+		if err != nil {
+			return func() tea.Msg {
+				return event.SetStatusMsg{Message: err.Error(), Status: event.Error}
+			}
+		}
+		m.exprPrompt = false
+		m.exprInput.Blur()
+		if idx := slices.IndexFunc(m.selectedNodes, func(n *kube.Node) bool {
+			return slices.Equal(n.NodeFullPath(), node.NodeFullPath())
+		}); idx >= 0 {
+			picked := m.selectedNodes[idx]
+			return func() tea.Msg {
+				return event.UnpickFieldMsg{Node: picked}
+			}
+		}
+		return func() tea.Msg {
+			return event.PickFieldMsg{Node: node}
+		}
+	case key.Matches(msg, m.exprKeys.cancel):
+		m.exprPrompt = false
+		m.exprInput.Blur()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.exprInput, cmd = m.exprInput.Update(msg)
+	return cmd
+}
+
+// unpickNode drops the node at the same path, not by name since e.g. metadata.name
+// and spec.template.metadata.name could both be picked
+func unpickNode(nodes []*kube.Node, node *kube.Node) []*kube.Node {
+	path := node.NodeFullPath()
+	idx := slices.IndexFunc(nodes, func(n *kube.Node) bool {
+		return slices.Equal(n.NodeFullPath(), path)
+	})
+	if idx < 0 {
+		return nodes
+	}
+	return slices.Delete(nodes, idx, idx+1)
+}
+
+// openSavePrompt asks for the name to save the picked fields as a favorite view under
+func (m *Model) openSavePrompt() tea.Cmd {
+	if len(m.selectedFields()) == 0 {
+		message := "pick fields to save first"
+		if len(m.selectedNodes) > 0 {
+			message = "JSONPath columns are not saved, " + message
+		}
+		return func() tea.Msg {
+			return event.SetStatusMsg{
+				Message: message,
+				Status:  event.Warn,
+			}
+		}
+	}
+	m.savePrompt = true
+	m.nameInput.Reset()
+	m.nameInput.Placeholder = m.defaultFavoriteName()
+	return m.nameInput.Focus()
+}
+
+// answerSavePrompt saves under the typed name, or the placeholder when empty,
+// keeping the prompt open when the kind has a favorite of that name
+func (m *Model) answerSavePrompt(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.saveKeys.submit):
+		name := strings.TrimSpace(m.nameInput.Value())
+		if name == "" {
+			name = m.nameInput.Placeholder
+		}
+		status := event.SetStatusMsg{Message: fmt.Sprintf("saved favorite %q", name), Status: event.Info}
+		err := m.saveFavorite(name)
+		switch {
+		case errors.Is(err, store.ErrDuplicateName):
+			status = event.SetStatusMsg{
+				Message: fmt.Sprintf("%s already has a favorite named %q", m.gvk.Kind, name),
+				Status:  event.Warn,
+			}
+		case err != nil:
+			m.closeSavePrompt()
+			status = event.SetStatusMsg{Message: err.Error(), Status: event.Error}
+		case len(m.selectedFields()) < len(m.selectedNodes):
+			m.closeSavePrompt()
+			status = event.SetStatusMsg{
+				Message: fmt.Sprintf("saved favorite %q without its JSONPath columns", name),
+				Status:  event.Warn,
+			}
+		default:
+			m.closeSavePrompt()
+		}
+		return func() tea.Msg {
+			return status
+		}
+	case key.Matches(msg, m.saveKeys.cancel):
+		m.closeSavePrompt()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.nameInput, cmd = m.nameInput.Update(msg)
+	return cmd
+}
+
+func (m *Model) closeSavePrompt() {
+	m.savePrompt = false
+	m.nameInput.Blur()
+}
+
+// defaultFavoriteName names a favorite view after the kind and time
+func (m *Model) defaultFavoriteName() string {
+	return fmt.Sprintf("%s %s", m.gvk.Kind, time.Now().Format("2006-01-02 15:04:05"))
+}
+
+// saveFavorite saves the picked fields as a favorite view named name
+func (m *Model) saveFavorite(name string) error {
+	if _, err := m.favorites.Create(name, m.gvkRef(), m.selectedFields(), nil); err != nil {
+		return fmt.Errorf("failed to create favorite view: %w", err)
+	}
+	if err := m.favorites.Save(); err != nil {
+		return fmt.Errorf("failed to save favorite views: %w", err)
+	}
+	return nil
+}
+
+// applyFavorite replaces the picked fields with the ones of a favorite view,
+// the nav picks those it has and warns about the rest
+func (m *Model) applyFavorite(name string, fields [][]string) tea.Cmd {
+	for _, node := range m.selectedNodes {
+		node.Selected = false
+	}
+	m.selectedNodes = []*kube.Node{}
+
+	objs := m.controller.Objects()
+	return tea.Sequence(
+		func() tea.Msg {
+			return result.SetResultMsg{Nodes: []*kube.Node{}, Objs: objs}
+		},
+		func() tea.Msg {
+			return nav.PickPathsMsg{Name: name, Paths: fields}
+		},
+	)
+}
+
+func (m *Model) gvkRef() store.GVKRef {
+	return store.GVKRef{Group: m.gvk.Group, Version: m.gvk.Version, Kind: m.gvk.Kind}
+}
+
+// selectedFields returns the paths of the picked fields to save, leaving out the JSONPath
+// columns which are no fields of the schema
+func (m *Model) selectedFields() [][]string {
+	fields := make([][]string, 0, len(m.selectedNodes))
+	for _, node := range m.selectedNodes {
+		if _, ok := node.Expression(); ok {
+			continue
+		}
+		fields = append(fields, node.NodeFullPath())
+	}
+	return fields
+}
+
 func (m *Model) setViewSize(msg tea.WindowSizeMsg) {
 	m.vp.Width = msg.Width
 	m.vp.Height = msg.Height - 1 // HACK: status bar 1
 }
 
-func (m *Model) setController(gvk schema.GroupVersionKind) {
+// pickGVK switches to the objects of gvk, staying on the current kind when they cannot be informed
+func (m *Model) pickGVK(gvk schema.GroupVersionKind) tea.Cmd {
+	inform, err := m.setController(gvk, m.namespace)
+	if err != nil {
+		return controllerErrStatus(err)
+	}
+	m.gvk = gvk
+	m.selectedNodes = []*kube.Node{}
+
+	return tea.Batch(
+		inform,
+		m.setNavGVK(gvk, m.controller.Objects()),
+		m.setNavNamespace(),
+		m.setTableScope(),
+		m.updateObjs(nil, m.controller.Objects()),
+	)
+}
+
+// pickNamespace switches to the objects in namespace, the schema is the same so picked fields are kept
+func (m *Model) pickNamespace(namespace string) tea.Cmd {
+	inform, err := m.setController(m.gvk, namespace)
+	if err != nil {
+		return controllerErrStatus(err)
+	}
+	m.namespace = namespace
+
+	return tea.Batch(
+		inform,
+		m.setNavNamespace(),
+		m.setTableScope(),
+		m.updateObjs(nil, m.controller.Objects()),
+	)
+}
+
+// controllerErrStatus reports a controller that could not be created
+func controllerErrStatus(err error) tea.Cmd {
+	log.Printf("[ERROR] %v", err)
+	return func() tea.Msg {
+		return event.SetStatusMsg{
+			Message: err.Error(),
+			Status:  event.Error,
+		}
+	}
+}
+
+// setController informs gvk in namespace in place of the current controller, kept when it fails
+func (m *Model) setController(gvk schema.GroupVersionKind, namespace string) (tea.Cmd, error) {
+	controller, clusterScoped, err := newController(m.context, namespace, gvk, m.listLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inform %s: %w", gvk.Kind, err)
+	}
 	if m.stop != nil {
 		close(m.stop)
+		m.stop = nil
 	}
-	gvr, err := kube.GetGVR(gvk)
+	// ends the listener of the old controller
+	m.controller.Close()
+
+	m.controller = controller
+	m.clusterScoped = clusterScoped
+	return m.inform(), nil
+}
+
+// newController creates the controller of gvk, informing namespace only when the kind is namespaced
+func newController(contextName, namespace string, gvk schema.GroupVersionKind, limit int64) (*kube.ResourceController, bool, error) {
+	gvr, err := kube.GetGVRForContext(contextName, gvk)
 	if err != nil {
-		return
+		return nil, false, err
+	}
+	// assume namespaced when discovery fails, the namespace is still honored then
+	namespaced, err := kube.IsNamespacedForContext(contextName, gvk)
+	clusterScoped := err == nil && !namespaced
+	if clusterScoped {
+		namespace = ""
+	}
+	return kube.NewResourceControllerForContext(contextName, gvr, kube.WithNamespace(namespace), kube.WithListLimit(limit)), clusterScoped, nil
+}
+
+func (m *Model) setNavNamespace() tea.Cmd {
+	msg := nav.SetNamespaceMsg{
+		Namespace:     m.namespace,
+		ClusterScoped: m.clusterScoped,
+	}
+	return func() tea.Msg {
+		return msg
+	}
+}
+
+// setTableScope names where the kind is informed for the empty table, e.g. context/namespace
+func (m *Model) setTableScope() tea.Cmd {
+	scope := m.context
+	if m.namespace != "" && !m.clusterScoped {
+		scope += "/" + m.namespace
+	}
+	msg := result.SetTableScopeMsg{
+		Kind:  m.gvk.Kind,
+		Scope: scope,
+	}
+	return func() tea.Msg {
+		return msg
 	}
-	m.controller = kube.NewResourceController(gvr)
-	m.inform()
 }
 
 func (m *Model) setNavGVK(gvk schema.GroupVersionKind, objs []*unstructured.Unstructured) tea.Cmd {
@@ -363,27 +1033,60 @@ func (m *Model) updateObjs(updatedObj *unstructured.Unstructured, objs []*unstru
 	}
 }
 
-// TODO: ? why return?
+// syncedMsg reports that the controller cached its objects
+type syncedMsg struct {
+	controller *kube.ResourceController
+}
+
+// inform starts the controller without waiting for its objects, which stream in as events,
+// spinning in the status bar until they are cached
 func (m *Model) inform() tea.Cmd {
-	stop, err := m.controller.Inform()
+	stop, synced, err := m.controller.InformAsync()
 	if err != nil {
 		return nil
 	}
 	m.stop = stop
+	m.syncing = true
 
-	return nil
+	controller := m.controller
+	return tea.Batch(m.spinner.Tick, func() tea.Msg {
+		select {
+		case <-synced:
+			return syncedMsg{controller: controller}
+		case <-controller.Done():
+			return nil
+		}
+	})
 }
 
+// listenController waits for the next event of the current controller, until it is closed
 func (m *Model) listenController() tea.Cmd {
+	controller := m.controller
 	return func() tea.Msg {
-		match, ok := <-m.controller.WatchEvents()
-		if !ok || match.Obj == nil {
-			return nil
-		}
+		select {
+		case match, ok := <-controller.WatchEvents():
+			if !ok || match.Obj == nil {
+				return nil
+			}
 
-		return event.UpdateObjsMsg{
-			Obj:  match.Obj,
-			Objs: m.controller.Objects(),
+			objs := controller.Objects()
+			if match.Type == kube.EventDeleted {
+				objs = withoutObj(objs, match.Obj)
+			}
+			return event.UpdateObjsMsg{
+				Type: match.Type,
+				Obj:  match.Obj,
+				Objs: objs,
+			}
+		case err := <-controller.ErrorEmitted():
+			return event.ControllerErrorMsg{Err: err}
 		}
 	}
 }
+
+// withoutObj drops a deleted object, in case the store has not caught up with its event
+func withoutObj(objs []*unstructured.Unstructured, obj *unstructured.Unstructured) []*unstructured.Unstructured {
+	return slices.DeleteFunc(slices.Clone(objs), func(o *unstructured.Unstructured) bool {
+		return o.GetNamespace() == obj.GetNamespace() && o.GetName() == obj.GetName()
+	})
+}
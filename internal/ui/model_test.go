@@ -0,0 +1,197 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/flavono123/kattle/internal/kube"
+	"github.com/flavono123/kattle/internal/store"
+	"github.com/flavono123/kattle/internal/ui/event"
+	"github.com/flavono123/kattle/internal/ui/favorite"
+	"github.com/flavono123/kattle/internal/ui/nav"
+	"github.com/flavono123/kattle/internal/ui/result"
+)
+
+func TestUnpickNode(t *testing.T) {
+	name := kube.NewPathNode([]string{"metadata", "name"})
+	templateName := kube.NewPathNode([]string{"spec", "template", "metadata", "name"})
+	phase := kube.NewPathNode([]string{"status", "phase"})
+
+	nodes := unpickNode([]*kube.Node{name, templateName, phase}, kube.NewPathNode([]string{"spec", "template", "metadata", "name"}))
+	assert.Equal(t, []*kube.Node{name, phase}, nodes)
+
+	nodes = unpickNode(nodes, kube.NewPathNode([]string{"spec", "replicas"}))
+	assert.Equal(t, []*kube.Node{name, phase}, nodes, "a path not picked changes nothing")
+}
+
+func TestAnswerExprPrompt(t *testing.T) {
+	m := &Model{exprKeys: newInputPromptKeyMap("pick"), exprInput: textinput.New()}
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+
+	t.Run("keeps the prompt open on an invalid expression", func(t *testing.T) {
+		m.exprPrompt = true
+		m.exprInput.SetValue(".spec.containers[")
+		msg := m.answerExprPrompt(enter)()
+		require.IsType(t, event.SetStatusMsg{}, msg)
+		assert.Equal(t, event.Error, msg.(event.SetStatusMsg).Status)
+		assert.True(t, m.exprPrompt)
+	})
+
+	t.Run("picks the expression as a column", func(t *testing.T) {
+		m.exprInput.SetValue(".spec.containers[*].image")
+		msg := m.answerExprPrompt(enter)()
+		require.IsType(t, event.PickFieldMsg{}, msg)
+		expr, ok := msg.(event.PickFieldMsg).Node.Expression()
+		assert.True(t, ok)
+		assert.Equal(t, ".spec.containers[*].image", expr)
+		assert.False(t, m.exprPrompt)
+
+		m.selectedNodes = append(m.selectedNodes, msg.(event.PickFieldMsg).Node)
+	})
+
+	t.Run("unpicks an expression picked already", func(t *testing.T) {
+		m.exprPrompt = true
+		m.exprInput.SetValue(".spec.containers[*].image")
+		msg := m.answerExprPrompt(enter)()
+		require.IsType(t, event.UnpickFieldMsg{}, msg)
+		assert.Same(t, m.selectedNodes[0], msg.(event.UnpickFieldMsg).Node)
+	})
+}
+
+func TestPromptsOverOverlays(t *testing.T) {
+	t.Setenv("KUBECONFIG", t.TempDir()+"/config") // no cluster, the views render empty
+	newModel := func() *Model {
+		return &Model{
+			session:       favoriteView,
+			keys:          newKeyMap(false),
+			quitKeys:      newQuitPromptKeyMap(),
+			exprInput:     textinput.New(),
+			nameInput:     textinput.New(),
+			nav:           nav.NewModel("", schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, nil),
+			result:        result.NewModel(nil),
+			favPicker:     favorite.NewModel(),
+			selectedNodes: []*kube.Node{kube.NewPathNode([]string{"metadata", "name"})},
+		}
+	}
+
+	t.Run("opens no prompt the overlay hides", func(t *testing.T) {
+		m := newModel()
+		for _, k := range []tea.KeyType{tea.KeyCtrlX, tea.KeyCtrlS, tea.KeyCtrlE} {
+			m.Update(tea.KeyMsg{Type: k})
+		}
+		assert.False(t, m.exportPrompt)
+		assert.False(t, m.savePrompt)
+		assert.False(t, m.exprPrompt)
+
+		m.session = resultView
+		m.Update(tea.KeyMsg{Type: tea.KeyCtrlX})
+		assert.True(t, m.exportPrompt)
+	})
+
+	t.Run("draws the quit prompt over the overlay", func(t *testing.T) {
+		m := newModel()
+		m.quitPrompt = true
+		assert.Contains(t, m.View(), "unsaved view, quit?")
+
+		m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		assert.False(t, m.quitPrompt)
+		assert.NotContains(t, m.View(), "unsaved view, quit?")
+	})
+}
+
+func TestSaveFavoriteWithJSONPath(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	favorites, err := store.NewStore()
+	require.NoError(t, err)
+	expr, err := kube.NewJSONPathNode(".spec.containers[*].image")
+	require.NoError(t, err)
+	m := &Model{
+		gvk:           schema.GroupVersionKind{Version: "v1", Kind: "Pod"},
+		favorites:     favorites,
+		confirmQuit:   true,
+		saveKeys:      newInputPromptKeyMap("save"),
+		nameInput:     textinput.New(),
+		selectedNodes: []*kube.Node{expr},
+	}
+
+	t.Run("saves no view of JSONPath columns only", func(t *testing.T) {
+		assert.False(t, m.hasUnsavedView())
+		msg := m.openSavePrompt()()
+		assert.Equal(t, "JSONPath columns are not saved, pick fields to save first", msg.(event.SetStatusMsg).Message)
+		assert.False(t, m.savePrompt)
+	})
+
+	t.Run("saves the fields without the JSONPath columns", func(t *testing.T) {
+		m.selectedNodes = append(m.selectedNodes, kube.NewPathNode([]string{"metadata", "name"}))
+		assert.True(t, m.hasUnsavedView())
+		m.openSavePrompt()
+		m.nameInput.SetValue("images")
+
+		msg := m.answerSavePrompt(tea.KeyMsg{Type: tea.KeyEnter})()
+		assert.Equal(t, event.SetStatusMsg{
+			Message: `saved favorite "images" without its JSONPath columns`,
+			Status:  event.Warn,
+		}, msg)
+		views := favorites.ListByGVK(m.gvkRef())
+		require.Len(t, views, 1)
+		assert.Equal(t, [][]string{{"metadata", "name"}}, views[0].Fields)
+		assert.False(t, m.hasUnsavedView())
+	})
+}
+
+func TestSaveFavoriteDuplicateName(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	favorites, err := store.NewStore()
+	require.NoError(t, err)
+	m := &Model{
+		gvk:           schema.GroupVersionKind{Version: "v1", Kind: "Pod"},
+		favorites:     favorites,
+		saveKeys:      newInputPromptKeyMap("save"),
+		nameInput:     textinput.New(),
+		selectedNodes: []*kube.Node{kube.NewPathNode([]string{"metadata", "name"})},
+	}
+	save := func(name string) tea.Msg {
+		m.openSavePrompt()
+		m.nameInput.SetValue(name)
+		return m.answerSavePrompt(tea.KeyMsg{Type: tea.KeyEnter})()
+	}
+
+	assert.Equal(t, event.Info, save("names").(event.SetStatusMsg).Status)
+	assert.False(t, m.savePrompt)
+
+	m.selectedNodes = append(m.selectedNodes, kube.NewPathNode([]string{"metadata", "namespace"}))
+	assert.Equal(t, event.SetStatusMsg{
+		Message: `Pod already has a favorite named "names"`,
+		Status:  event.Warn,
+	}, save("names"))
+	assert.True(t, m.savePrompt, "the prompt stays open to pick another name")
+	assert.Len(t, favorites.ListByGVK(m.gvkRef()), 1)
+}
+
+func TestPickUninformableKind(t *testing.T) {
+	t.Setenv("KUBECONFIG", t.TempDir()+"/config")
+	pod := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	picked := []*kube.Node{kube.NewPathNode([]string{"metadata", "name"})}
+	m := &Model{context: "kupid-test-no-such-context", gvk: pod, namespace: "default", selectedNodes: picked}
+
+	for name, cmd := range map[string]func() tea.Cmd{
+		"kind": func() tea.Cmd {
+			return m.pickGVK(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"})
+		},
+		"namespace": func() tea.Cmd { return m.pickNamespace("kube-system") },
+	} {
+		t.Run(name, func(t *testing.T) {
+			msg := cmd()()
+			require.IsType(t, event.SetStatusMsg{}, msg)
+			assert.Equal(t, event.Error, msg.(event.SetStatusMsg).Status)
+			assert.Equal(t, pod, m.gvk)
+			assert.Equal(t, "default", m.namespace)
+			assert.Equal(t, picked, m.selectedNodes)
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package namespace
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+
+	"github.com/flavono123/kattle/internal/kube"
+	"github.com/flavono123/kattle/internal/ui/event"
+	"github.com/flavono123/kattle/internal/ui/theme"
+)
+
+const (
+	NAMESPACE_WIDTH_DIV  = 3
+	NAMESPACE_MAX_HEIGHT = 10
+
+	NAMESPACE_SCROLL_STEP = 1
+
+	ALL_NAMESPACES = "All namespaces"
+)
+
+// Model is a kbar-like list to pick the namespace to inform, all namespaces first
+type Model struct {
+	keys     keyMap
+	style    lipgloss.Style
+	items    []string // "" is all namespaces
+	filtered []string
+	current  string
+	err      error
+	input    textinput.Model
+	vp       viewport.Model
+	cursor   int
+
+	listFunc func() ([]string, error)
+}
+
+// NewModel lists the namespaces of the named context
+func NewModel(contextName string) *Model {
+	ti := textinput.New()
+	ti.Placeholder = "Switch namespace..."
+	ti.Prompt = "🔍 "
+	ti.Width = 30
+
+	return &Model{
+		keys:  newKeyMap(),
+		style: lipgloss.NewStyle().Border(lipgloss.ThickBorder()),
+		input: ti,
+		vp:    viewport.New(0, 0),
+		listFunc: func() ([]string, error) {
+			return kube.ListNamespacesForContext(contextName)
+		},
+	}
+}
+
+func (m *Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case HideMsg:
+		m.input.Blur()
+	case listMsg:
+		m.err = msg.Err
+		m.items = append([]string{""}, msg.Namespaces...)
+		m.applyFilter()
+	case tea.WindowSizeMsg:
+		m.vp.Width = msg.Width / NAMESPACE_WIDTH_DIV
+		m.vp.Height = NAMESPACE_MAX_HEIGHT
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.up):
+			if m.cursor > 0 {
+				m.cursor--
+			} else {
+				m.vp.ScrollUp(NAMESPACE_SCROLL_STEP)
+			}
+		case key.Matches(msg, m.keys.down):
+			if m.cursor < min(len(m.filtered)-1, m.vp.Height-1) {
+				m.cursor++
+			} else {
+				m.vp.ScrollDown(NAMESPACE_SCROLL_STEP)
+			}
+		case key.Matches(msg, m.keys.pick):
+			index := m.cursor + m.vp.YOffset
+			if index >= len(m.filtered) {
+				break
+			}
+			namespace := m.filtered[index]
+			cmds = append(cmds, func() tea.Msg {
+				return event.PickNamespaceMsg{Namespace: namespace}
+			})
+		case key.Matches(msg, m.keys.hide):
+			cmds = append(cmds, Hide())
+		default:
+			prevInputValue := m.input.Value()
+			im, iCmd := m.input.Update(msg)
+			m.input = im
+			cmds = append(cmds, iCmd)
+			if prevInputValue != m.input.Value() {
+				m.cursor = 0
+				m.vp.SetYOffset(0)
+				m.applyFilter()
+			}
+		}
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m *Model) View() string {
+	m.vp.SetContent(m.renderRows())
+	return m.style.Render(
+		lipgloss.JoinVertical(lipgloss.Left,
+			m.input.View(),
+			m.vp.View(),
+		),
+	)
+}
+
+// Focus opens the picker marking current, fetching the namespaces in the background
+func (m *Model) Focus(current string) tea.Cmd {
+	m.current = current
+	m.input.Reset()
+	m.cursor = 0
+	m.vp.SetYOffset(0)
+	m.items = []string{""}
+	m.err = nil
+	m.applyFilter()
+
+	listFunc := m.listFunc
+	return tea.Batch(
+		m.input.Focus(),
+		func() tea.Msg {
+			namespaces, err := listFunc()
+			return listMsg{Namespaces: namespaces, Err: err}
+		},
+	)
+}
+
+func (m *Model) applyFilter() {
+	keyword := m.input.Value()
+	if keyword == "" {
+		m.filtered = m.items
+	} else {
+		labels := make([]string, len(m.items))
+		for i, item := range m.items {
+			labels[i] = label(item)
+		}
+		m.filtered = []string{}
+		for _, match := range fuzzy.Find(keyword, labels) {
+			m.filtered = append(m.filtered, m.items[match.Index])
+		}
+	}
+
+	if m.cursor > len(m.filtered)-1 {
+		m.cursor = max(len(m.filtered)-1, 0)
+		m.vp.SetYOffset(0)
+	}
+}
+
+func (m *Model) renderRows() string {
+	dimStyle := lipgloss.NewStyle().Foreground(theme.Overlay0())
+	if len(m.filtered) == 0 {
+		return dimStyle.Render("No namespaces found.")
+	}
+
+	itemStyle := lipgloss.NewStyle().Padding(0, 0, 0, 1).MaxWidth(m.vp.Width)
+	currentStyle := itemStyle.Foreground(theme.Blue())
+	hoveredStyle := lipgloss.NewStyle().Background(theme.Overlay0())
+
+	lines := make([]string, 0, len(m.filtered)+1)
+	for i, item := range m.filtered {
+		line := itemStyle.Render(label(item))
+		if item == m.current {
+			line = currentStyle.Render(label(item))
+		}
+		if i == m.cursor+m.vp.YOffset {
+			line = hoveredStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	if m.err != nil {
+		// all namespaces stays pickable, e.g. when listing them is forbidden
+		lines = append(lines, dimStyle.Padding(0, 0, 0, 1).Render(m.err.Error()))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func label(namespace string) string {
+	if namespace == "" {
+		return ALL_NAMESPACES
+	}
+	return namespace
+}
@@ -0,0 +1,47 @@
+package namespace
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flavono123/kattle/internal/ui/event"
+)
+
+func TestPick(t *testing.T) {
+	newModel := func(namespaces []string, err error) *Model {
+		m := NewModel("")
+		m.listFunc = func() ([]string, error) {
+			return namespaces, err
+		}
+		m.Update(tea.WindowSizeMsg{Width: 90, Height: 30})
+		m.Focus("")
+		m.Update(listMsg{Namespaces: namespaces, Err: err})
+		return m
+	}
+	pick := func(m *Model) tea.Msg {
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		return cmd()
+	}
+
+	t.Run("all namespaces comes first", func(t *testing.T) {
+		m := newModel([]string{"default", "kube-system"}, nil)
+		assert.Equal(t, []string{"", "default", "kube-system"}, m.filtered)
+		assert.Equal(t, event.PickNamespaceMsg{Namespace: ""}, pick(m))
+	})
+
+	t.Run("filters by the typed name", func(t *testing.T) {
+		m := newModel([]string{"default", "kube-system"}, nil)
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("kube")})
+		assert.Equal(t, []string{"kube-system"}, m.filtered)
+		assert.Equal(t, event.PickNamespaceMsg{Namespace: "kube-system"}, pick(m))
+	})
+
+	t.Run("keeps all namespaces when listing fails", func(t *testing.T) {
+		m := newModel(nil, errors.New("forbidden"))
+		assert.Equal(t, []string{""}, m.filtered)
+		assert.Contains(t, m.View(), "forbidden")
+	})
+}
@@ -1,20 +1,53 @@
 package nav
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"github.com/charmbracelet/bubbles/key"
+
+	"github.com/flavono123/kattle/internal/ui/keybind"
+)
 
 // Keymaps
 type keyMap struct {
 	up          key.Binding
 	down        key.Binding
 	action      key.Binding
+	pageUp      key.Binding
+	pageDown    key.Binding
+	top         key.Binding
+	bottom      key.Binding
+	fold        key.Binding
+	unfold      key.Binding
 	levelExpand key.Binding
 	allExpand   key.Binding
+	collapseAll key.Binding
+	pickSort    key.Binding
+	pickGroup   key.Binding
+	detach      key.Binding
+	aggregate   key.Binding
+	bookmark    key.Binding
+	jumpMark    key.Binding
+	typeFilter  key.Binding
+	required    key.Binding
+	pickable    key.Binding
+	search      key.Binding
+	nextMatch   key.Binding
+	prevMatch   key.Binding
+	endPrompt   key.Binding
+	clearPrompt key.Binding
+
+	prompting bool // only the prompt keys apply while typing
 }
 
 func newKeyMap() keyMap {
-	return keyMap{
-		up:   key.NewBinding(key.WithKeys("up")),
-		down: key.NewBinding(key.WithKeys("down")),
+	km := keyMap{
+		up:       key.NewBinding(key.WithKeys("up", "k")),
+		down:     key.NewBinding(key.WithKeys("down", "j")),
+		pageUp:   key.NewBinding(key.WithKeys("pgup")),
+		pageDown: key.NewBinding(key.WithKeys("pgdown")),
+		top:      key.NewBinding(key.WithKeys("home")),
+		bottom:   key.NewBinding(key.WithKeys("end")),
+		fold:     key.NewBinding(key.WithKeys("h")),
+		unfold:   key.NewBinding(key.WithKeys("l")),
 		action: key.NewBinding(
 			key.WithKeys(" "),
 			key.WithHelp("spc", "fold/pick"),
@@ -27,14 +60,117 @@ func newKeyMap() keyMap {
 			key.WithKeys("ctrl+a"),
 			key.WithHelp("^+a", "expand all"),
 		),
+		collapseAll: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "collapse all"),
+		),
+		pickSort: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "pick+sort"),
+		),
+		pickGroup: key.NewBinding(
+			key.WithKeys("g"),
+			key.WithHelp("g", "pick+group"),
+		),
+		detach: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "map keys"),
+		),
+		aggregate: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "join array"),
+		),
+		bookmark: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "bookmark"),
+		),
+		jumpMark: key.NewBinding(
+			key.WithKeys("'"),
+			key.WithHelp("'", "next bookmark"),
+		),
+		typeFilter: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "filter type"),
+		),
+		required: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "required only"),
+		),
+		pickable: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "pickable only"),
+		),
+		search: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "search"),
+		),
+		nextMatch: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n/N", "next/prev match"),
+		),
+		prevMatch: key.NewBinding(
+			key.WithKeys("N"),
+		),
+		endPrompt: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "apply"),
+		),
+		clearPrompt: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "clear"),
+		),
 	}
+	keybind.Rebind(keybind.Schema, map[string]*key.Binding{
+		"up":          &km.up,
+		"down":        &km.down,
+		"action":      &km.action,
+		"pageUp":      &km.pageUp,
+		"pageDown":    &km.pageDown,
+		"top":         &km.top,
+		"bottom":      &km.bottom,
+		"fold":        &km.fold,
+		"unfold":      &km.unfold,
+		"levelExpand": &km.levelExpand,
+		"allExpand":   &km.allExpand,
+		"collapseAll": &km.collapseAll,
+		"pickSort":    &km.pickSort,
+		"pickGroup":   &km.pickGroup,
+		"detach":      &km.detach,
+		"aggregate":   &km.aggregate,
+		"bookmark":    &km.bookmark,
+		"jumpMark":    &km.jumpMark,
+		"typeFilter":  &km.typeFilter,
+		"required":    &km.required,
+		"pickable":    &km.pickable,
+		"search":      &km.search,
+		"nextMatch":   &km.nextMatch,
+		"prevMatch":   &km.prevMatch,
+		"endPrompt":   &km.endPrompt,
+		"clearPrompt": &km.clearPrompt,
+	})
+	return km
 }
 
 func (k keyMap) ShortHelp() []key.Binding {
+	if k.prompting {
+		return []key.Binding{k.endPrompt, k.clearPrompt}
+	}
 	return []key.Binding{
 		k.action,
 		k.levelExpand,
 		k.allExpand,
+		k.collapseAll,
+		k.pickSort,
+		k.pickGroup,
+		k.detach,
+		k.aggregate,
+		k.bookmark,
+		k.jumpMark,
+		k.typeFilter,
+		k.required,
+		k.pickable,
+		k.search,
+		k.nextMatch,
 	}
 }
 
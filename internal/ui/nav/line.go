@@ -5,24 +5,29 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/flavono123/kattle/internal/kube"
 	"github.com/flavono123/kattle/internal/ui/theme"
 )
 
+const (
+	// enums longer than this are not inlined, they would overflow the schema pane anyway
+	ENUM_MAX_VALUES = 12
+	ENUM_MAX_WIDTH  = 60
+)
+
 // TODO: function args node(s) under ui should be line and get the node from getter
 type Line struct {
-	node *kube.Node
-	objs []*unstructured.Unstructured
+	node   *kube.Node
+	values *kube.ValueIndex
 
 	style lipgloss.Style
 	index int
 }
 
-func newLine(node *kube.Node, width int, index int, objs []*unstructured.Unstructured) *Line {
+func newLine(node *kube.Node, width int, index int, values *kube.ValueIndex) *Line {
 	style := lipgloss.NewStyle().MaxWidth(width)
-	return &Line{node: node, style: style, index: index, objs: objs}
+	return &Line{node: node, style: style, index: index, values: values}
 }
 
 func (l *Line) render(leftPadding int, cursored bool, maxWidth int, schemaBlurred bool) string {
@@ -33,6 +38,7 @@ func (l *Line) render(leftPadding int, cursored bool, maxWidth int, schemaBlurre
 		l.cursor(cursored, schemaBlurred),
 		l.action(),
 		l.renderNode(),
+		l.enum(cursored),
 	)
 
 	return lipgloss.NewStyle().MaxWidth(maxWidth).Render(line)
@@ -40,19 +46,52 @@ func (l *Line) render(leftPadding int, cursored bool, maxWidth int, schemaBlurre
 
 func (l *Line) renderNode() string {
 	name := lipgloss.NewStyle().Foreground(theme.Green())
+	if l.node.Required() {
+		name = name.Foreground(theme.Yellow()).Bold(true)
+	}
 	displayType := lipgloss.NewStyle().Foreground(theme.Peach())
 
 	if l.node.Type() == "" {
 		return name.Render(l.node.Name())
 	}
 
+	joined := ""
+	if l.node.Aggregated {
+		joined = lipgloss.NewStyle().Foreground(theme.Overlay1()).Render(" (joined)")
+	}
+
 	return lipgloss.JoinHorizontal(
 		lipgloss.Left,
 		name.Render(l.node.Name()),
 		displayType.Render(fmt.Sprintf("<%s>", l.node.Type())),
+		joined,
+		l.defaultValue(),
 	)
 }
 
+// defaultValue shows the schema default of a leaf none of the objects sets, e.g. = Always
+func (l *Line) defaultValue() string {
+	if !l.values.Defaulted(l.node) {
+		return ""
+	}
+	value, _ := l.node.Default()
+	return lipgloss.NewStyle().Foreground(theme.Overlay0()).Render(" = " + value)
+}
+
+// enum inlines the allowed values of the hovered leaf, e.g. [ClusterIP|NodePort]
+func (l *Line) enum(cursored bool) string {
+	values := l.node.Enum()
+	if !cursored || len(values) == 0 || len(values) > ENUM_MAX_VALUES || !l.values.Pickable(l.node) {
+		return ""
+	}
+
+	enum := "[" + strings.Join(values, "|") + "]"
+	if len(enum) > ENUM_MAX_WIDTH {
+		enum = enum[:ENUM_MAX_WIDTH-4] + "...]"
+	}
+	return lipgloss.NewStyle().Foreground(theme.Overlay1()).Render(" " + enum)
+}
+
 func (l *Line) number(leftPadding int) string {
 	number := lipgloss.NewStyle().Foreground(theme.Overlay0())
 	fmtStr := fmt.Sprintf("%%%dd ", leftPadding)
@@ -86,14 +125,14 @@ func (l *Line) action() string {
 			return action.Render("-")
 		}
 		return action.Render("+")
-	} else if l.node.Pickable(l.objs) {
+	} else if l.values.Pickable(l.node) {
 		if l.node.Selected {
 			return action.Render("◉")
 		}
 		return action.Render("○")
 	}
 
-	// HACK: this would not be rendered
+	// HACK: this would not be rendered, but for defaulted leaves
 	// see Model.buildLines
 	return action.Render(" ")
 }
@@ -1,6 +1,7 @@
 package nav
 
 import (
+	"fmt"
 	"log"
 	"reflect"
 	"sort"
@@ -17,6 +18,7 @@ import (
 	"github.com/flavono123/kattle/internal/ui/theme"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -35,6 +37,7 @@ type Model struct {
 	nodes  map[string]*kube.Node
 	fields map[string]*kube.Field // cache for objs changed
 	objs   []*unstructured.Unstructured
+	values *kube.ValueIndex // memoizes which nodes have values in objs, see setObjs
 
 	vp viewport.Model
 
@@ -44,15 +47,41 @@ type Model struct {
 	curLineNo int
 	prevNode  *kube.Node
 
-	gvk schema.GroupVersionKind
+	context       string
+	serverVersion string // of the context's API server, empty until fetched or when it failed
+	gvk           schema.GroupVersionKind
+	namespace     string // empty for all namespaces
+	clusterScoped bool   // the kind has no namespace to show
+	fieldsErr     error  // the schema of gvk failed to load, see renderFieldsErr
+
+	// node paths marked in the current GVK, kept across refreshes
+	bookmarks [][]string
+	nextMark  int
+
+	// typeInput narrows the tree to fields whose type contains its value
+	typeInput     textinput.Model
+	typeFiltering bool
+
+	// requiredOnly narrows the tree to required fields and their ancestors
+	requiredOnly bool
+
+	// pickableOnly narrows the tree to fields with a value in some object and their ancestors
+	pickableOnly bool
+
+	// searchInput fuzzy-finds fields by path, n/N cycle through the matches
+	searchInput textinput.Model
+	searching   bool
+	matches     [][]string
+	nextMatch   int
 
 	keys keyMap
 }
 
-func NewModel(gvk schema.GroupVersionKind, objs []*unstructured.Unstructured) *Model {
-	fields, err := kube.CreateFieldTree(gvk)
+// NewModel shows the schema of gvk served in the named context
+func NewModel(contextName string, gvk schema.GroupVersionKind, objs []*unstructured.Unstructured) *Model {
+	fields, err := kube.CreateFieldTreeForContext(contextName, gvk)
 	if err != nil {
-		log.Fatalf("failed to create field tree: %v", err)
+		log.Printf("failed to create field tree of %s: %v", gvk.Kind, err)
 	}
 	nodes := kube.CreateNodeTree(fields, objs, []string{})
 
@@ -62,17 +91,22 @@ func NewModel(gvk schema.GroupVersionKind, objs []*unstructured.Unstructured) *M
 
 	vp := viewport.New(0, 0)
 	m := &Model{
-		focus:    true, // HACK: required to be injected by root
-		nodes:    nodes,
-		fields:   fields,
-		objs:     objs,
-		vp:       vp,
-		style:    style,
-		cursor:   0,
-		gvk:      gvk,
-		curLines: []*Line{},
-		prevNode: nil,
-		keys:     newKeyMap(),
+		focus:       true, // HACK: required to be injected by root
+		nodes:       nodes,
+		fields:      fields,
+		objs:        objs,
+		values:      kube.NewValueIndex(objs),
+		vp:          vp,
+		style:       style,
+		cursor:      0,
+		context:     contextName,
+		gvk:         gvk,
+		fieldsErr:   err,
+		curLines:    []*Line{},
+		prevNode:    nil,
+		keys:        newKeyMap(),
+		typeInput:   newPromptInput("type: ", "string, [], map[", theme.Peach()),
+		searchInput: newPromptInput("/", "field name", theme.Green()),
 	}
 	m.curLines, m.curLineNo = m.buildLines(m.nodes, m.vp.Width, 0)
 	content := m.renderRecursive(m.curLines)
@@ -82,8 +116,25 @@ func NewModel(gvk schema.GroupVersionKind, objs []*unstructured.Unstructured) *M
 	return m
 }
 
+// Init reports the schema that failed to load, if any, and fetches the server version
 func (m *Model) Init() tea.Cmd {
-	return nil
+	return tea.Batch(m.fieldsErrStatus(), m.fetchServerVersion())
+}
+
+// fetchServerVersion looks the server version up once per context, leaving it empty on errors
+func (m *Model) fetchServerVersion() tea.Cmd {
+	if m.serverVersion != "" {
+		return nil
+	}
+	contextName := m.context
+	return func() tea.Msg {
+		version, err := kube.ServerVersionForContext(contextName)
+		if err != nil {
+			log.Printf("failed to get server version of %s: %v", contextName, err)
+			return nil
+		}
+		return serverVersionMsg{Version: version}
+	}
 }
 
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -94,15 +145,53 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case SetGVKMsg:
 		m.setObjs(msg.Objs)
 		m.setGVK(msg.GVK)
-		m.setNodes(msg.GVK)
+		retCmd = tea.Batch(m.setNodes(msg.GVK), m.fetchServerVersion())
+		m.clearBookmarks()
 		m.reset()
+	case serverVersionMsg:
+		m.serverVersion = msg.Version
+	case SetNamespaceMsg:
+		m.namespace = msg.Namespace
+		m.clusterScoped = msg.ClusterScoped
 	case UpdateObjsMsg:
+		m.setObjs(msg.Objs)
 		m.updateNodes()
+	case PickPathsMsg:
+		retCmd = m.pickPaths(msg.Name, msg.Paths)
 	case tea.WindowSizeMsg:
 		m.vp.Width = int(float64(msg.Width) * SCHEMA_WIDTH_RATIO)
 		m.vp.Height = msg.Height - SCHEMA_HEIGHT_BOTTOM_MARGIN
 	case tea.KeyMsg:
+		if m.typeFiltering {
+			return m, m.updateTypeFilter(msg)
+		}
+		if m.searching {
+			return m, m.updateSearch(msg)
+		}
+
 		switch {
+		case key.Matches(msg, m.keys.typeFilter):
+			m.typeFiltering = true
+			m.typeInput.PromptStyle = lipgloss.NewStyle().Bold(true).Foreground(theme.Peach())
+			retCmd = m.typeInput.Focus()
+		case key.Matches(msg, m.keys.required):
+			m.requiredOnly = !m.requiredOnly
+			m.vp.GotoTop()
+			m.reset()
+			retCmd = m.hover()
+		case key.Matches(msg, m.keys.pickable):
+			m.pickableOnly = !m.pickableOnly
+			m.vp.GotoTop()
+			m.reset()
+			retCmd = m.hover()
+		case key.Matches(msg, m.keys.search):
+			m.searching = true
+			m.searchInput.PromptStyle = lipgloss.NewStyle().Bold(true).Foreground(theme.Green())
+			retCmd = m.searchInput.Focus()
+		case key.Matches(msg, m.keys.nextMatch):
+			retCmd = m.jumpToMatch(1)
+		case key.Matches(msg, m.keys.prevMatch):
+			retCmd = m.jumpToMatch(-1)
 		case key.Matches(msg, m.keys.up):
 			if m.cursor > SCHEMA_CURSOR_TOP {
 				m.cursor--
@@ -110,15 +199,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.vp.ScrollUp(SCHEMA_SCROLL_STEP)
 			}
 
-			if m.curIsPickable() {
-				retCmd = func() tea.Msg {
-					return event.HoverFieldMsg{Candidate: m.curNode()}
-				}
-			} else {
-				retCmd = func() tea.Msg {
-					return result.SetTableCandidateMsg{Candidate: nil}
-				}
-			}
+			retCmd = m.hover()
 		case key.Matches(msg, m.keys.down):
 			if m.cursor < min(m.vp.Height-1, m.curLineNo-1) {
 				m.cursor++
@@ -126,14 +207,25 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.vp.ScrollDown(SCHEMA_SCROLL_STEP)
 			}
 
-			if m.curIsPickable() {
-				retCmd = func() tea.Msg {
-					return event.HoverFieldMsg{Candidate: m.curNode()}
-				}
-			} else {
-				retCmd = func() tea.Msg {
-					return result.SetTableCandidateMsg{Candidate: nil}
-				}
+			retCmd = m.hover()
+		case key.Matches(msg, m.keys.pageUp):
+			m.scrollBy(-m.pageLines())
+			retCmd = m.hover()
+		case key.Matches(msg, m.keys.pageDown):
+			m.scrollBy(m.pageLines())
+			retCmd = m.hover()
+		case key.Matches(msg, m.keys.top):
+			m.scrollBy(-m.curLineNo)
+			retCmd = m.hover()
+		case key.Matches(msg, m.keys.bottom):
+			m.scrollBy(m.curLineNo)
+			retCmd = m.hover()
+		case key.Matches(msg, m.keys.fold):
+			retCmd = m.fold()
+		case key.Matches(msg, m.keys.unfold):
+			if node := m.curNode(); node != nil && node.Foldable() && !node.Expanded {
+				node.SetExpanded(true)
+				m.curLines, m.curLineNo = m.buildLines(m.nodes, m.vp.Width, 0)
 			}
 		case key.Matches(msg, m.keys.action):
 			if m.curNode() == nil {
@@ -156,6 +248,19 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 				}
 			}
+		case key.Matches(msg, m.keys.pickSort):
+			retCmd = m.pickAndOrder(false)
+		case key.Matches(msg, m.keys.pickGroup):
+			retCmd = m.pickAndOrder(true)
+		case key.Matches(msg, m.keys.detach):
+			if node := m.curNode(); node != nil && node.IsMap() {
+				retCmd = func() tea.Msg {
+					return event.DetachFieldMsg{Node: node}
+				}
+			}
+
+		case key.Matches(msg, m.keys.aggregate):
+			retCmd = m.toggleAggregate()
 
 		// BUG: when viewport is adjusted by expland all/level then fold back, the cursor is not rendered
 		// reproduce - expand level of status in kind Pod(long enough) and fold
@@ -166,7 +271,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				prevNode := node
 				m.toggleExpandRecursive(m.nodes, toggledExpanded, false)
 				m.curLines, m.curLineNo = m.buildLines(m.nodes, m.vp.Width, 0)
-				m.setCursor(prevNode.FullPath())
+				m.setCursor(prevNode.NodeFullPath())
 			}
 		case key.Matches(msg, m.keys.allExpand):
 			node := m.curNode()
@@ -175,8 +280,17 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				prevNode := node
 				m.toggleExpandRecursive(m.nodes, toggledExpanded, true)
 				m.curLines, m.curLineNo = m.buildLines(m.nodes, m.vp.Width, 0)
-				m.setCursor(prevNode.FullPath())
+				m.setCursor(prevNode.NodeFullPath())
 			}
+		case key.Matches(msg, m.keys.bookmark):
+			retCmd = m.toggleBookmark()
+		case key.Matches(msg, m.keys.jumpMark):
+			if m.jumpToNextBookmark() {
+				retCmd = m.hover()
+			}
+		case key.Matches(msg, m.keys.collapseAll):
+			m.collapseAll()
+			retCmd = m.hover()
 		}
 	}
 
@@ -186,6 +300,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m *Model) View() string {
 	content := m.renderRecursive(m.curLines)
 	content = strings.TrimSuffix(content, "\n")
+	if m.fieldsErr != nil {
+		content = m.renderFieldsErr()
+	}
 	m.vp.SetContent(content)
 
 	return lipgloss.JoinVertical(lipgloss.Left,
@@ -195,27 +312,323 @@ func (m *Model) View() string {
 }
 
 func (m *Model) Keys() keyMap {
-	return m.keys
+	keys := m.keys
+	keys.prompting = m.typeFiltering || m.searching
+	return keys
+}
+
+// Fields returns the schema fields of the current GVK
+func (m *Model) Fields() map[string]*kube.Field {
+	return m.fields
+}
+
+// pickAndOrder picks the current leaf if needed and makes it
+// the table's sort column, grouping rows by its values when group is set
+func (m *Model) pickAndOrder(group bool) tea.Cmd {
+	node := m.curNode()
+	if node == nil || node.Foldable() {
+		return nil
+	}
+
+	orderCmd := func() tea.Msg {
+		return result.SetTableOrderMsg{Node: node, Group: group}
+	}
+	if node.Selected {
+		return orderCmd
+	}
+
+	node.Selected = true
+	return tea.Sequence(
+		func() tea.Msg {
+			return event.PickFieldMsg{Node: node}
+		},
+		orderCmd,
+	)
+}
+
+// pickPaths picks the leaves at paths, warning about the ones not in the tree instead of failing
+func (m *Model) pickPaths(name string, paths [][]string) tea.Cmd {
+	var cmds []tea.Cmd
+	var skipped []string
+	for _, path := range paths {
+		node := m.nodeAt(path)
+		if node == nil || node.Foldable() {
+			skipped = append(skipped, strings.Join(path, "."))
+			continue
+		}
+		if node.Selected {
+			continue
+		}
+		node.Selected = true
+		cmds = append(cmds, func() tea.Msg {
+			return event.PickFieldMsg{Node: node}
+		})
+	}
+
+	status := event.SetStatusMsg{Message: "applied " + name, Status: event.Info}
+	if len(skipped) > 0 {
+		status = event.SetStatusMsg{
+			Message: fmt.Sprintf("applied %s, skipped fields not found: %s", name, strings.Join(skipped, ", ")),
+			Status:  event.Warn,
+		}
+	}
+	cmds = append(cmds, func() tea.Msg { return status })
+	return tea.Sequence(cmds...)
+}
+
+// nodeAt returns the node at path, nil when the tree has none
+func (m *Model) nodeAt(path []string) *kube.Node {
+	var node *kube.Node
+	nodes := m.nodes
+	for _, name := range path {
+		if node = nodes[name]; node == nil {
+			return nil
+		}
+		nodes = node.Children()
+	}
+	return node
+}
+
+func newPromptInput(prompt, placeholder string, color lipgloss.Color) textinput.Model {
+	input := textinput.New()
+	input.Prompt = prompt
+	input.Placeholder = placeholder
+	input.Width = 16
+	input.PromptStyle = lipgloss.NewStyle().Foreground(theme.Overlay0())
+	input.PlaceholderStyle = lipgloss.NewStyle().Foreground(theme.Overlay0())
+	input.TextStyle = lipgloss.NewStyle().Foreground(color)
+	return input
+}
+
+// updateTypeFilter feeds the prompt, rebuilding the tree whenever the type fragment changes
+func (m *Model) updateTypeFilter(msg tea.KeyMsg) tea.Cmd {
+	prevQuery := m.typeQuery()
+
+	var cmd tea.Cmd
+	switch {
+	case key.Matches(msg, m.keys.endPrompt):
+		m.endTypeFilter()
+	case key.Matches(msg, m.keys.clearPrompt):
+		m.typeInput.Reset()
+		m.endTypeFilter()
+	default:
+		m.typeInput, cmd = m.typeInput.Update(msg)
+	}
+
+	if m.typeQuery() == prevQuery {
+		return cmd
+	}
+	m.vp.GotoTop()
+	m.reset()
+	return tea.Batch(cmd, m.hover())
+}
+
+func (m *Model) endTypeFilter() {
+	m.typeFiltering = false
+	m.typeInput.PromptStyle = lipgloss.NewStyle().Foreground(theme.Overlay0())
+	m.typeInput.Blur()
+}
+
+func (m *Model) typeQuery() string {
+	return strings.ToLower(strings.TrimSpace(m.typeInput.Value()))
+}
+
+// filteredOut reports whether the type, required and pickable filters hide the node
+func (m *Model) filteredOut(node *kube.Node) bool {
+	query := m.typeQuery()
+	if query == "" && !m.requiredOnly && !m.pickableOnly {
+		return false
+	}
+	var pickable func(*kube.Node) bool
+	if m.pickableOnly {
+		pickable = m.values.Pickable
+	}
+	return !matchesFilters(node, query, m.requiredOnly, pickable)
+}
+
+// matchesFilters reports whether the node or any of its descendants has a type containing query,
+// and is required too when required is set, and pickable too when pickable is given
+func matchesFilters(node *kube.Node, query string, required bool, pickable func(*kube.Node) bool) bool {
+	if strings.Contains(strings.ToLower(node.Type()), query) && (!required || node.Required()) &&
+		(pickable == nil || pickable(node)) {
+		return true
+	}
+	for _, child := range node.Children() {
+		if matchesFilters(child, query, required, pickable) {
+			return true
+		}
+	}
+	return false
+}
+
+// pageLines is how far a page moves, keeping the last line of the previous page in view
+func (m *Model) pageLines() int {
+	return max(m.vp.Height-1, 1)
+}
+
+// scrollBy moves the viewport and the cursor by lines, clamped to the tree
+func (m *Model) scrollBy(lines int) {
+	height := max(m.vp.Height, 1)
+	idx := min(max(m.cursor+m.vp.YOffset+lines, 0), max(m.curLineNo-1, 0))
+	m.vp.YOffset = min(max(m.vp.YOffset+lines, 0), max(m.curLineNo-height, 0))
+	if idx < m.vp.YOffset {
+		m.vp.YOffset = idx
+	} else if idx >= m.vp.YOffset+height {
+		m.vp.YOffset = idx - height + 1
+	}
+	m.cursor = idx - m.vp.YOffset
+}
+
+// fold collapses the expanded node under the cursor, otherwise moves the cursor to its parent
+func (m *Model) fold() tea.Cmd {
+	node := m.curNode()
+	if node == nil {
+		return nil
+	}
+
+	if node.Foldable() && node.Expanded {
+		node.SetExpanded(false)
+		m.curLines, m.curLineNo = m.buildLines(m.nodes, m.vp.Width, 0)
+		return nil
+	}
+
+	path := node.NodeFullPath()
+	if len(path) < 2 || !m.setCursor(path[:len(path)-1]) {
+		return nil
+	}
+	return m.hover()
+}
+
+// hover previews the field under the cursor as a table candidate
+func (m *Model) hover() tea.Cmd {
+	if m.curIsPickable() {
+		return func() tea.Msg {
+			return event.HoverFieldMsg{Candidate: m.curNode()}
+		}
+	}
+	return func() tea.Msg {
+		return result.SetTableCandidateMsg{Candidate: nil}
+	}
 }
 
 func (m *Model) isCursor(curLineNo int) bool {
 	return m.cursor == curLineNo-m.vp.YOffset
 }
 
-func (m *Model) setCursor(path []string) {
+// setCursor moves the cursor to the visible line of the node at path
+func (m *Model) setCursor(path []string) bool {
 	for _, line := range m.curLines {
-		if reflect.DeepEqual(line.node.FullPath(), path) {
+		if reflect.DeepEqual(line.node.NodeFullPath(), path) {
 			actualIndex := line.index
-			if actualIndex > m.vp.Height-1 {
+			switch {
+			case actualIndex >= m.vp.YOffset && actualIndex < m.vp.YOffset+m.vp.Height:
+				actualIndex -= m.vp.YOffset
+			case actualIndex > m.vp.Height-1:
 				m.vp.YOffset = actualIndex - SCHEMA_EXPAND_MULTI_MARGIN
 				actualIndex = SCHEMA_EXPAND_MULTI_MARGIN
+			default:
+				m.vp.YOffset = 0
 			}
 
 			m.cursor = actualIndex
 
-			return
+			return true
+		}
+	}
+	return false
+}
+
+// toggleBookmark marks or unmarks the node under the cursor
+func (m *Model) toggleBookmark() tea.Cmd {
+	node := m.curNode()
+	if node == nil {
+		return nil
+	}
+
+	path := node.NodeFullPath()
+	message := "bookmarked " + strings.Join(path, ".")
+	marked := false
+	for i, mark := range m.bookmarks {
+		if reflect.DeepEqual(mark, path) {
+			m.bookmarks = append(m.bookmarks[:i], m.bookmarks[i+1:]...)
+			message = "unbookmarked " + strings.Join(path, ".")
+			marked = true
+			break
+		}
+	}
+	if !marked {
+		m.bookmarks = append(m.bookmarks, path)
+	}
+
+	return func() tea.Msg {
+		return event.SetStatusMsg{Message: message, Status: event.Info}
+	}
+}
+
+// jumpToNextBookmark cycles the cursor through bookmarks in the order they were marked,
+// unfolding the ancestors of the target and skipping paths that no longer exist
+func (m *Model) jumpToNextBookmark() bool {
+	for range m.bookmarks {
+		mark := m.bookmarks[m.nextMark%len(m.bookmarks)]
+		m.nextMark = (m.nextMark + 1) % len(m.bookmarks)
+
+		if m.jumpTo(mark) {
+			return true
+		}
+	}
+	return false
+}
+
+// jumpTo unfolds the ancestors of the node at path and moves the cursor onto it
+func (m *Model) jumpTo(path []string) bool {
+	if !m.expandTo(path) {
+		return false
+	}
+	m.curLines, m.curLineNo = m.buildLines(m.nodes, m.vp.Width, 0)
+	return m.setCursor(path)
+}
+
+// expandTo unfolds every ancestor of the node at path, reporting whether the node exists
+func (m *Model) expandTo(path []string) bool {
+	if len(path) == 0 {
+		return false
+	}
+
+	nodes := m.nodes
+	for _, name := range path[:len(path)-1] {
+		node, ok := nodes[name]
+		if !ok {
+			return false
+		}
+		node.SetExpanded(true)
+		nodes = node.Children()
+	}
+	_, ok := nodes[path[len(path)-1]]
+	return ok
+}
+
+func (m *Model) clearBookmarks() {
+	m.bookmarks = nil
+	m.nextMark = 0
+}
+
+// toggleAggregate joins the values of the array under the cursor in a cell, or splits them back
+func (m *Model) toggleAggregate() tea.Cmd {
+	node := m.curNode()
+	if node == nil || !node.ToggleAggregate() {
+		return nil
+	}
+	m.curLines, m.curLineNo = m.buildLines(m.nodes, m.vp.Width, 0)
+
+	if node.Selected && !node.Aggregated { // no longer a leaf to be a column
+		node.Selected = false
+		return func() tea.Msg {
+			return event.UnpickFieldMsg{Node: node}
 		}
 	}
+	return func() tea.Msg {
+		return event.AggregateFieldMsg{Node: node}
+	}
 }
 
 func (m *Model) toggleCurrentNodeFolder() {
@@ -224,6 +637,20 @@ func (m *Model) toggleCurrentNodeFolder() {
 	}
 }
 
+// collapseAll folds every node back to the top level and moves the cursor to the top
+func (m *Model) collapseAll() {
+	collapseRecursive(m.nodes)
+	m.vp.GotoTop()
+	m.reset()
+}
+
+func collapseRecursive(nodes map[string]*kube.Node) {
+	for _, n := range nodes {
+		n.SetExpanded(false)
+		collapseRecursive(n.Children())
+	}
+}
+
 func (m *Model) toggleExpandRecursive(nodes map[string]*kube.Node, expand bool, all bool) {
 	node := m.curNode()
 	if node == nil {
@@ -254,11 +681,14 @@ func (m *Model) buildLines(nodes map[string]*kube.Node, width int, lineNo int) (
 		}
 
 		node := nodes[key]
-		if !node.Renderable(m.objs) {
+		if !m.values.Renderable(node) {
+			continue
+		}
+		if m.filteredOut(node) {
 			continue
 		}
 
-		line := newLine(node, width, lineNo, m.objs)
+		line := newLine(node, width, lineNo, m.values)
 		lineNo++
 		lines = append(lines, line)
 		if node.Expanded {
@@ -290,6 +720,7 @@ func (m *Model) reset() {
 
 func (m *Model) setObjs(objs []*unstructured.Unstructured) {
 	m.objs = objs
+	m.values = kube.NewValueIndex(objs)
 }
 
 func (m *Model) setGVK(gvk schema.GroupVersionKind) {
@@ -297,14 +728,32 @@ func (m *Model) setGVK(gvk schema.GroupVersionKind) {
 }
 
 // set nodes when gvk is changed
-// fields are also changed by gvk
-func (m *Model) setNodes(gvk schema.GroupVersionKind) {
-	fields, err := kube.CreateFieldTree(gvk)
-	m.fields = fields
+// fields are also changed by gvk, a schema failing to load leaves the tree empty
+func (m *Model) setNodes(gvk schema.GroupVersionKind) tea.Cmd {
+	fields, err := kube.CreateFieldTreeForContext(m.context, gvk)
 	if err != nil {
-		log.Fatalf("failed to create field tree: %v", err)
+		log.Printf("failed to create field tree of %s: %v", gvk.Kind, err)
 	}
+	m.fields = fields
+	m.fieldsErr = err
 	m.nodes = kube.CreateNodeTree(fields, m.objs, []string{})
+	return m.fieldsErrStatus()
+}
+
+func (m *Model) fieldsErrStatus() tea.Cmd {
+	if m.fieldsErr == nil {
+		return nil
+	}
+	message := fmt.Sprintf("failed to load the schema of %s: %v", m.gvk.Kind, m.fieldsErr)
+	return func() tea.Msg {
+		return event.SetStatusMsg{Message: message, Status: event.Error}
+	}
+}
+
+// renderFieldsErr fills the empty tree of a schema failing to load
+func (m *Model) renderFieldsErr() string {
+	return lipgloss.NewStyle().Foreground(theme.Red()).Width(m.vp.Width).Render(
+		fmt.Sprintf("failed to load the schema of %s\n%v\n\npick another kind with the kbar", m.gvk.Kind, m.fieldsErr))
 }
 
 // update nodes when objs is changed
@@ -332,24 +781,49 @@ func sortKeys(keys []string) {
 }
 
 func (m *Model) curNode() *kube.Node {
-	return m.curLines[m.cursor+m.vp.YOffset].node
+	index := m.cursor + m.vp.YOffset
+	if index < 0 || index >= len(m.curLines) { // e.g. a type filter matching nothing
+		return nil
+	}
+	return m.curLines[index].node
 }
 
 func (m *Model) curIsPickable() bool {
-	return m.curNode() != nil && m.curNode().Pickable(m.objs) && !m.curNode().Selected
+	return m.curNode() != nil && m.values.Pickable(m.curNode()) && !m.curNode().Selected
 }
 
 func (m *Model) renderTopBar() string {
-	ctx, err := kube.CurrentContext()
-	if err != nil {
-		log.Fatalf("failed to get current context: %v", err)
+	context := m.context
+	if context == "" { // the current context failed to look up
+		context = "<unknown>"
 	}
-	ctx = lipgloss.NewStyle().Margin(0, 1).Render(ctx)
+	ctx := lipgloss.NewStyle().Margin(0, 1).Render(context)
 	kind := lipgloss.NewStyle().Foreground(theme.Blue()).Render(m.gvk.Kind)
-	return lipgloss.JoinHorizontal(lipgloss.Left,
-		ctx,
-		kind,
-	)
+	parts := []string{ctx}
+	if m.serverVersion != "" {
+		parts = append(parts, lipgloss.NewStyle().Margin(0, 1, 0, 0).Foreground(theme.Overlay0()).Render(m.serverVersion))
+	}
+	parts = append(parts, kind)
+	if !m.clusterScoped {
+		namespace := m.namespace
+		if namespace == "" {
+			namespace = "all namespaces"
+		}
+		parts = append(parts, lipgloss.NewStyle().Margin(0, 0, 0, 1).Foreground(theme.Subtext0()).Render(namespace))
+	}
+	if m.typeFiltering || m.typeQuery() != "" {
+		parts = append(parts, lipgloss.NewStyle().Margin(0, 1).Render(m.typeInput.View()))
+	}
+	if m.requiredOnly {
+		parts = append(parts, lipgloss.NewStyle().Margin(0, 0, 0, 1).Foreground(theme.Yellow()).Render("required"))
+	}
+	if m.pickableOnly {
+		parts = append(parts, lipgloss.NewStyle().Margin(0, 0, 0, 1).Foreground(theme.Green()).Render("pickable"))
+	}
+	if m.searching || len(m.matches) > 0 {
+		parts = append(parts, lipgloss.NewStyle().Margin(0, 1).Render(m.searchInput.View()))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Left, parts...)
 }
 
 func (m *Model) Focus() tea.Cmd {
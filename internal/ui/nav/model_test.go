@@ -0,0 +1,202 @@
+package nav
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/flavono123/kattle/internal/kube"
+	"github.com/flavono123/kattle/internal/ui/event"
+)
+
+func TestMatchesFilters(t *testing.T) {
+	fields := map[string]*kube.Field{
+		"spec": {Name: "spec", Type: "DeploymentSpec", Children: map[string]*kube.Field{
+			"selector": {Name: "selector", Prefix: []string{"spec"}, Type: "LabelSelector", Required: true, Children: map[string]*kube.Field{}},
+			"replicas": {Name: "replicas", Prefix: []string{"spec"}, Type: "integer"},
+		}},
+		"status": {Name: "status", Type: "DeploymentStatus", Children: map[string]*kube.Field{
+			"replicas": {Name: "replicas", Prefix: []string{"status"}, Type: "integer"},
+		}},
+	}
+	nodes := kube.CreateNodeTree(fields, nil, []string{})
+
+	t.Run("keeps the ancestors of required fields", func(t *testing.T) {
+		assert.True(t, matchesFilters(nodes["spec"], "", true, nil))
+		assert.True(t, matchesFilters(nodes["spec"].Children()["selector"], "", true, nil))
+		assert.False(t, matchesFilters(nodes["spec"].Children()["replicas"], "", true, nil))
+		assert.False(t, matchesFilters(nodes["status"], "", true, nil))
+	})
+
+	t.Run("requires both the type and required", func(t *testing.T) {
+		assert.True(t, matchesFilters(nodes["status"], "int", false, nil))
+		assert.False(t, matchesFilters(nodes["spec"], "int", true, nil))
+		assert.True(t, matchesFilters(nodes["spec"], "label", true, nil))
+	})
+}
+
+func TestPickPaths(t *testing.T) {
+	fields := map[string]*kube.Field{
+		"spec": {Name: "spec", Type: "DeploymentSpec", Children: map[string]*kube.Field{
+			"replicas": {Name: "replicas", Prefix: []string{"spec"}, Type: "integer"},
+			"paused":   {Name: "paused", Prefix: []string{"spec"}, Type: "boolean"},
+		}},
+	}
+	nodes := kube.CreateNodeTree(fields, nil, []string{})
+	m := &Model{nodes: nodes}
+
+	t.Run("finds nodes by their full path", func(t *testing.T) {
+		assert.Equal(t, nodes["spec"].Children()["replicas"], m.nodeAt([]string{"spec", "replicas"}))
+		assert.Nil(t, m.nodeAt([]string{"spec", "removed"}))
+		assert.Nil(t, m.nodeAt([]string{"status", "replicas"}))
+	})
+
+	t.Run("picks the leaves it has, skipping the rest", func(t *testing.T) {
+		cmd := m.pickPaths("scale", [][]string{{"spec", "replicas"}, {"spec"}, {"spec", "removed"}})
+		assert.NotNil(t, cmd)
+		assert.True(t, nodes["spec"].Children()["replicas"].Selected)
+		assert.False(t, nodes["spec"].Selected)
+		assert.False(t, nodes["spec"].Children()["paused"].Selected)
+	})
+}
+
+func TestSchemaFailingToLoad(t *testing.T) {
+	t.Setenv("KUBECONFIG", filepath.Join(t.TempDir(), "missing"))
+	kube.InvalidateKubeconfigCache()
+	t.Cleanup(kube.InvalidateKubeconfigCache)
+	gvk := schema.GroupVersionKind{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "PodMetrics"}
+
+	m := NewModel("unreachable", gvk, nil)
+	m.Update(tea.WindowSizeMsg{Width: 200, Height: 20})
+
+	assert.Empty(t, m.nodes)
+	assert.Contains(t, m.View(), "failed to load the schema of PodMetrics")
+	batch, ok := m.Init()().(tea.BatchMsg)
+	require.True(t, ok)
+	var statuses []event.Status
+	for _, cmd := range batch {
+		if status, ok := cmd().(event.SetStatusMsg); ok {
+			statuses = append(statuses, status.Status)
+		}
+	}
+	assert.Equal(t, []event.Status{event.Error}, statuses, "the server version failing to fetch is not reported")
+
+	var cmd tea.Cmd
+
+	_, cmd = m.Update(SetGVKMsg{GVK: schema.GroupVersionKind{Version: "v1", Kind: "Pod"}})
+	require.NotNil(t, cmd, "another kind failing to load is reported as well")
+	assert.Contains(t, m.View(), "failed to load the schema of Pod")
+}
+
+func TestRenderTopBar(t *testing.T) {
+	gvk := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+
+	t.Run("shows the cached context", func(t *testing.T) {
+		m := &Model{context: "kind-a", gvk: gvk}
+		assert.Contains(t, m.renderTopBar(), "kind-a")
+	})
+
+	t.Run("renders a context failing to look up as unknown", func(t *testing.T) {
+		m := &Model{gvk: gvk}
+		var bar string
+		require.NotPanics(t, func() { bar = m.renderTopBar() })
+		assert.Contains(t, bar, "<unknown>")
+		assert.Contains(t, bar, "Pod")
+	})
+
+	t.Run("shows the fetched server version", func(t *testing.T) {
+		m := &Model{context: "kind-a", gvk: gvk}
+		assert.NotContains(t, m.renderTopBar(), "v1.31.2")
+
+		m.Update(serverVersionMsg{Version: "v1.31.2"})
+		assert.Contains(t, m.renderTopBar(), "kind-a v1.31.2 Pod")
+		assert.Nil(t, m.fetchServerVersion(), "fetched once")
+	})
+}
+
+func TestPickableOnly(t *testing.T) {
+	fields := map[string]*kube.Field{
+		"spec": {Name: "spec", Type: "DeploymentSpec", Children: map[string]*kube.Field{
+			"replicas": {Name: "replicas", Prefix: []string{"spec"}, Type: "integer"},
+			"paused":   {Name: "paused", Prefix: []string{"spec"}, Type: "boolean", Default: false},
+		}},
+		"status": {Name: "status", Type: "DeploymentStatus", Children: map[string]*kube.Field{
+			"replicas": {Name: "replicas", Prefix: []string{"status"}, Type: "integer", Default: int64(0)},
+		}},
+	}
+	deploy := func(status map[string]interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec":   map[string]interface{}{"replicas": int64(2)},
+			"status": status,
+		}}
+	}
+	objs := []*unstructured.Unstructured{deploy(nil)}
+	m := &Model{fields: fields, nodes: kube.CreateNodeTree(fields, objs, []string{}), keys: newKeyMap()}
+	m.setObjs(objs)
+	m.nodes["spec"].SetExpanded(true)
+	m.nodes["status"].SetExpanded(true)
+	paths := func() []string {
+		m.reset()
+		var paths []string
+		for _, line := range m.curLines {
+			paths = append(paths, strings.Join(line.node.NodeFullPath(), "."))
+		}
+		return paths
+	}
+	assert.Equal(t, []string{"spec", "spec.paused", "spec.replicas", "status", "status.replicas"}, paths(), "defaults are shown")
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	assert.True(t, m.pickableOnly)
+	assert.Equal(t, []string{"spec", "spec.replicas"}, paths())
+	assert.Contains(t, m.renderTopBar(), "pickable")
+
+	m.Update(UpdateObjsMsg{Objs: []*unstructured.Unstructured{deploy(map[string]interface{}{"replicas": int64(2)})}})
+	assert.Equal(t, []string{"spec", "spec.replicas", "status", "status.replicas"}, paths(), "recomputed with the objects")
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	assert.Equal(t, []string{"spec", "spec.paused", "spec.replicas", "status", "status.replicas"}, paths())
+}
+
+func TestPaging(t *testing.T) {
+	children := map[string]*kube.Field{}
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		children[name] = &kube.Field{Name: name, Prefix: []string{"spec"}, Type: "string"}
+	}
+	fields := map[string]*kube.Field{"spec": {Name: "spec", Type: "Spec", Children: children}}
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"a": "1", "b": "2", "c": "3", "d": "4", "e": "5"},
+	}}
+	objs := []*unstructured.Unstructured{obj}
+	m := &Model{fields: fields, nodes: kube.CreateNodeTree(fields, objs, []string{}), keys: newKeyMap()}
+	m.setObjs(objs)
+	m.nodes["spec"].SetExpanded(true)
+	m.vp.Height = 3
+	m.reset()
+	hovered := func(cmd tea.Cmd) string {
+		msg, ok := cmd().(event.HoverFieldMsg)
+		require.True(t, ok, "the candidate follows the cursor")
+		return strings.Join(msg.Candidate.NodeFullPath(), ".")
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	assert.Equal(t, 2, m.cursor+m.vp.YOffset, "a page is the height less one")
+	assert.Equal(t, "spec.b", hovered(cmd))
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	assert.Equal(t, 4, m.cursor+m.vp.YOffset)
+	assert.Equal(t, "spec.d", hovered(cmd))
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnd})
+	assert.Equal(t, 5, m.cursor+m.vp.YOffset)
+	assert.Equal(t, 3, m.vp.YOffset)
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyPgUp})
+	assert.Equal(t, 3, m.cursor+m.vp.YOffset)
+	assert.Equal(t, "spec.c", hovered(cmd))
+}
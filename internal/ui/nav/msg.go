@@ -12,6 +12,17 @@ type SetGVKMsg struct {
 	Objs []*unstructured.Unstructured
 }
 
+// serverVersionMsg shows the fetched server version next to the context
+type serverVersionMsg struct {
+	Version string
+}
+
+// SetNamespaceMsg shows the informed namespace next to the kind, an empty Namespace is all namespaces
+type SetNamespaceMsg struct {
+	Namespace     string
+	ClusterScoped bool
+}
+
 type SetNodesMsg struct {
 	Nodes []*kube.Node
 }
@@ -19,3 +30,9 @@ type SetNodesMsg struct {
 type UpdateObjsMsg struct {
 	Objs []*unstructured.Unstructured
 }
+
+// PickPathsMsg picks the nodes at Paths in order, e.g. the fields of the favorite view Name
+type PickPathsMsg struct {
+	Name  string
+	Paths [][]string
+}
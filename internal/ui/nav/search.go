@@ -0,0 +1,116 @@
+package nav
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+
+	"github.com/flavono123/kattle/internal/kube"
+	"github.com/flavono123/kattle/internal/ui/event"
+	"github.com/flavono123/kattle/internal/ui/theme"
+)
+
+// updateSearch feeds the search prompt, jumping to the best match on enter
+func (m *Model) updateSearch(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.endPrompt):
+		m.endSearch()
+		m.matches = fuzzyPaths(m.searchablePaths(), m.searchInput.Value())
+		m.nextMatch = 0
+		if len(m.matches) == 0 {
+			query := m.searchInput.Value()
+			return func() tea.Msg {
+				return event.SetStatusMsg{
+					Message: fmt.Sprintf("no field matches %q", query),
+					Status:  event.Warn,
+				}
+			}
+		}
+		return m.jumpToMatch(0)
+	case key.Matches(msg, m.keys.clearPrompt):
+		m.searchInput.Reset()
+		m.matches = nil
+		m.endSearch()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.searchInput, cmd = m.searchInput.Update(msg)
+	return cmd
+}
+
+func (m *Model) endSearch() {
+	m.searching = false
+	m.searchInput.PromptStyle = lipgloss.NewStyle().Foreground(theme.Overlay0())
+	m.searchInput.Blur()
+}
+
+// jumpToMatch moves step matches away from the last one and puts the cursor on it
+func (m *Model) jumpToMatch(step int) tea.Cmd {
+	if len(m.matches) == 0 {
+		return nil
+	}
+
+	m.nextMatch = ((m.nextMatch+step)%len(m.matches) + len(m.matches)) % len(m.matches)
+	if !m.jumpTo(m.matches[m.nextMatch]) {
+		return nil
+	}
+
+	message := fmt.Sprintf("match %d/%d", m.nextMatch+1, len(m.matches))
+	return tea.Batch(m.hover(), func() tea.Msg {
+		return event.SetStatusMsg{Message: message, Status: event.Info}
+	})
+}
+
+// searchablePaths lists the paths of every node the tree could show, wildcards before indexes
+func (m *Model) searchablePaths() [][]string {
+	var paths [][]string
+	var walk func(nodes map[string]*kube.Node)
+	walk = func(nodes map[string]*kube.Node) {
+		names := make([]string, 0, len(nodes))
+		for name := range nodes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			node := nodes[name]
+			if name == "apiVersion" || name == "kind" { // hidden by buildLines too
+				continue
+			}
+			if !m.values.Renderable(node) {
+				continue
+			}
+			if m.filteredOut(node) {
+				continue
+			}
+			paths = append(paths, node.NodeFullPath())
+			walk(node.Children())
+		}
+	}
+	walk(m.nodes)
+	return paths
+}
+
+// fuzzyPaths matches query against the dotted form of paths, best match first
+func fuzzyPaths(paths [][]string, query string) [][]string {
+	if strings.TrimSpace(query) == "" {
+		return nil
+	}
+
+	dotted := make([]string, len(paths))
+	for i, path := range paths {
+		dotted[i] = strings.Join(path, ".")
+	}
+
+	var result [][]string
+	for _, match := range fuzzy.Find(query, dotted) {
+		result = append(result, paths[match.Index])
+	}
+	return result
+}
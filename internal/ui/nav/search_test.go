@@ -0,0 +1,30 @@
+package nav
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuzzyPaths(t *testing.T) {
+	paths := [][]string{
+		{"metadata", "name"},
+		{"spec", "replicas"},
+		{"spec", "template", "spec", "containers", "*", "image"},
+		{"spec", "template", "spec", "containers", "*", "imagePullPolicy"},
+	}
+
+	t.Run("finds deep fields by name", func(t *testing.T) {
+		matches := fuzzyPaths(paths, "image")
+		assert.Equal(t, []string{"spec", "template", "spec", "containers", "*", "image"}, matches[0])
+		assert.Len(t, matches, 2)
+	})
+
+	t.Run("blank query matches nothing", func(t *testing.T) {
+		assert.Empty(t, fuzzyPaths(paths, " "))
+	})
+
+	t.Run("unmatched query matches nothing", func(t *testing.T) {
+		assert.Empty(t, fuzzyPaths(paths, "xyz"))
+	})
+}
@@ -0,0 +1,53 @@
+package result
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+
+	"github.com/flavono123/kattle/internal/ui/keybind"
+)
+
+type keyMap struct {
+	filter       key.Binding
+	endFilter    key.Binding
+	regexMode    key.Binding
+	filterColumn key.Binding
+	table        []key.Binding
+}
+
+func newKeyMap() keyMap {
+	km := keyMap{
+		filter: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "filter"),
+		),
+		endFilter: key.NewBinding(
+			key.WithKeys("esc", "enter"),
+			key.WithHelp("esc/enter", "done filtering"),
+		),
+		regexMode: key.NewBinding(
+			key.WithKeys("ctrl+r"),
+			key.WithHelp("^+r", "regexp filter"),
+		),
+		filterColumn: key.NewBinding(
+			key.WithKeys("ctrl+l"),
+			key.WithHelp("^+l", "filter column"),
+		),
+	}
+	keybind.Rebind(keybind.Result, map[string]*key.Binding{
+		"filter":       &km.filter,
+		"endFilter":    &km.endFilter,
+		"regexMode":    &km.regexMode,
+		"filterColumn": &km.filterColumn,
+	})
+	return km
+}
+
+func (k keyMap) ShortHelp() []key.Binding {
+	return append([]key.Binding{k.filter, k.endFilter, k.regexMode, k.filterColumn}, k.table...)
+}
+
+func (k keyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{}, // only render short help
+	}
+}
@@ -1,8 +1,12 @@
 package result
 
 import (
+	"fmt"
 	"math"
+	"regexp"
+	"slices"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
@@ -22,37 +26,46 @@ const (
 )
 
 type Model struct {
-	focus  bool
-	table  *table.Model
-	filter textinput.Model
+	focus     bool
+	filtering bool // keys go to the filter instead of table actions
+	keys      keyMap
+	table     *table.Model
+	filter    textinput.Model
+	regexMode bool   // the keyword is a regexp instead of a fuzzy pattern
+	applied   string // the last keyword tried, a bad regexp keeps the table's one
+	keyword   string // the global keyword, kept while the filter edits a column's
+	nodes     []*kube.Node
+	filterCol *kube.Node // the column the filter edits, nil for the global keyword
 
-	width      int
-	widthLimPB progress.Model
+	width        int
+	widthLimPB   progress.Model
+	themeVersion int // of the palette the filter and progress bar are styled with
 }
 
 func NewModel(objs []*unstructured.Unstructured) *Model {
 	nodes := []*kube.Node{}
 	filter := textinput.New()
-	filter.Placeholder = "Filter"
+	filter.Placeholder = "/ to filter"
 	filter.SetCursor(0)
 	filter.Width = 20
-	filter.Cursor.Style = lipgloss.NewStyle().Foreground(theme.Blue())
 	filter.Prompt = "|"
-	filter.PlaceholderStyle = lipgloss.NewStyle().Foreground(theme.Overlay0()).Background(theme.Mantle())
-	filter.TextStyle = lipgloss.NewStyle().Foreground(theme.Blue()).Background(theme.Mantle())
 
 	t := table.NewModel(nodes, objs)
-	return &Model{
+	m := &Model{
 		focus: false,
+		keys:  newKeyMap(),
 		table: t,
 		width: 0,
 		widthLimPB: progress.New(
-			progress.WithGradient(theme.LatteYellow, theme.LatteBlue),
+			progress.WithGradient(theme.GradientStart(), theme.GradientEnd()),
 			progress.WithoutPercentage(),
 			progress.WithSpringOptions(RESULT_PROGRESS_BAR_INIT_FREQ, RESULT_PROGRESS_BAR_CRITICAL_DAMP),
 		),
-		filter: filter,
+		filter:       filter,
+		themeVersion: -1, // styled below
 	}
+	m.restyle()
+	return m
 }
 
 func (m *Model) Init() tea.Cmd {
@@ -75,29 +88,49 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, m.setCandidate(nil))
 		}
 
-		if msg.Picked && m.table.WillOverWidth(msg.PickedNode) {
-			return m, func() tea.Msg {
-				return event.CancelPickMsg{
-					Canceled: true,
-					Node:     msg.PickedNode,
-				}
-			}
-		}
-
-		cmds = append(cmds, m.setTable(msg.Nodes, msg.Objs))
+		cmds = append(cmds, m.setTable(msg.Nodes, msg.Objs, msg.Picked))
+		m.setNodes(msg.Nodes)
 	case SetTableCandidateMsg:
 		cmds = append(cmds, m.setCandidate(msg.Candidate))
+	case SetTableOrderMsg:
+		cmds = append(cmds, m.setOrder(msg.Node, msg.Group))
+	case SetTableScopeMsg:
+		cmds = append(cmds, m.setScope(msg.Kind, msg.Scope))
 	case tea.WindowSizeMsg:
 		m.setViewSize(msg)
 	}
 
-	if m.focus {
+	keyMsg, isKey := msg.(tea.KeyMsg)
+	if m.focus && isKey && !m.table.Inspecting() { // the inspect pane takes every key
+		switch {
+		case m.filtering && key.Matches(keyMsg, m.keys.endFilter):
+			m.endFiltering()
+			return m, tea.Batch(cmds...)
+		case !m.filtering && key.Matches(keyMsg, m.keys.filter):
+			return m, tea.Batch(append(cmds, m.startFiltering())...)
+		case key.Matches(keyMsg, m.keys.regexMode):
+			return m, tea.Batch(append(cmds, m.toggleRegexMode())...)
+		case key.Matches(keyMsg, m.keys.filterColumn):
+			cmds = append(cmds, m.cycleFilterColumn())
+			if !m.filtering {
+				cmds = append(cmds, m.startFiltering())
+			}
+			return m, tea.Batch(cmds...)
+		}
+	}
+
+	if m.filtering {
 		fm, fCmd := m.filter.Update(msg)
 		m.filter = fm
-		if m.filter.Value() != m.table.Keyword() {
-			cmds = append(cmds, m.setKeyword(m.filter.Value()))
+		if m.filter.Value() != m.applied {
+			cmds = append(cmds, m.applyFilter())
 		}
 		cmds = append(cmds, fCmd)
+
+		// only navigation reaches the table while typing
+		if isKey && keyMsg.Type != tea.KeyUp && keyMsg.Type != tea.KeyDown {
+			return m, tea.Batch(cmds...)
+		}
 	}
 
 	tm, tCmd := m.table.Update(msg)
@@ -108,6 +141,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) View() string {
+	m.restyle()
 	return lipgloss.JoinVertical(lipgloss.Left,
 		m.renderTopBar(),
 		m.table.View(),
@@ -116,18 +150,64 @@ func (m *Model) View() string {
 
 func (m *Model) Focus() tea.Cmd {
 	m.focus = true
-	m.filter.PromptStyle = lipgloss.NewStyle().Bold(true).Foreground(theme.Blue())
-
-	return tea.Batch(m.filter.Focus(), m.table.Focus())
+	return m.table.Focus()
 }
 
 func (m *Model) Blur() {
 	m.focus = false
-	m.filter.PromptStyle = lipgloss.NewStyle().Foreground(theme.Overlay0())
-	m.filter.Blur()
+	m.endFiltering()
 	m.table.Blur()
 }
 
+// VisibleObjs returns the objects as the table shows them
+func (m *Model) VisibleObjs() []*unstructured.Unstructured {
+	return m.table.VisibleObjs()
+}
+
+// Keys returns the bindings of the result pane including the table's
+func (m *Model) Keys() keyMap {
+	if m.table.Inspecting() {
+		return keyMap{table: m.table.Keys().ShortHelp()} // no filtering over the inspect pane
+	}
+	keys := m.keys
+	keys.table = m.table.Keys().ShortHelp()
+	return keys
+}
+
+func (m *Model) startFiltering() tea.Cmd {
+	m.filtering = true
+	m.stylePrompt()
+	return m.filter.Focus()
+}
+
+// endFiltering keeps the keyword applied and gives keys back to the table
+func (m *Model) endFiltering() {
+	m.filtering = false
+	m.stylePrompt()
+	m.filter.Blur()
+}
+
+func (m *Model) stylePrompt() {
+	if m.filtering {
+		m.filter.PromptStyle = lipgloss.NewStyle().Bold(true).Foreground(theme.Blue())
+	} else {
+		m.filter.PromptStyle = lipgloss.NewStyle().Foreground(theme.Overlay0())
+	}
+}
+
+// restyle rebuilds the filter and progress bar colors when the palette changed since
+func (m *Model) restyle() {
+	if m.themeVersion == theme.Version() {
+		return
+	}
+	m.filter.Cursor.Style = lipgloss.NewStyle().Foreground(theme.Blue())
+	m.filter.PlaceholderStyle = lipgloss.NewStyle().Foreground(theme.Overlay0()).Background(theme.Mantle())
+	m.filter.TextStyle = lipgloss.NewStyle().Foreground(theme.Blue()).Background(theme.Mantle())
+	m.stylePrompt()
+	progress.WithGradient(theme.GradientStart(), theme.GradientEnd())(&m.widthLimPB)
+	m.themeVersion = theme.Version()
+}
+
 func (m *Model) setViewSize(msg tea.WindowSizeMsg) {
 	m.width = int(float64(msg.Width) * RESULT_WIDTH_RATIO)
 }
@@ -140,19 +220,135 @@ func (m *Model) setCandidate(candidate *kube.Node) tea.Cmd {
 	}
 }
 
-func (m *Model) setKeyword(keyword string) tea.Cmd {
+// toggleRegexMode switches the global keyword between fuzzy and regexp and reapplies it
+func (m *Model) toggleRegexMode() tea.Cmd {
+	m.regexMode = !m.regexMode
+	m.setPrompt()
+	return m.applyKeyword(m.keyword)
+}
+
+// cycleFilterColumn moves the filter to the next picked column, then back to the global keyword
+func (m *Model) cycleFilterColumn() tea.Cmd {
+	if len(m.nodes) == 0 {
+		return func() tea.Msg {
+			return event.SetStatusMsg{
+				Message: "no columns to filter",
+				Status:  event.Warn,
+			}
+		}
+	}
+
+	next, value := 0, ""
+	if m.filterCol != nil {
+		// the column keyword carries over to compare columns
+		next, value = slices.Index(m.nodes, m.filterCol)+1, m.filter.Value()
+	}
+	if next < len(m.nodes) {
+		m.filterCol = m.nodes[next]
+		m.filter.SetValue(value)
+	} else {
+		m.filterCol = nil
+		m.filter.SetValue(m.keyword)
+		value = ""
+	}
+	m.applied = m.filter.Value()
+	m.setPrompt()
+	return m.setColumnFilter(m.filterCol, value)
+}
+
+// setNodes drops the column filter when its column is unpicked
+func (m *Model) setNodes(nodes []*kube.Node) {
+	m.nodes = nodes
+	if m.filterCol != nil && !slices.Contains(nodes, m.filterCol) {
+		m.filterCol = nil
+		m.filter.SetValue(m.keyword)
+		m.applied = m.keyword
+		m.setPrompt()
+	}
+}
+
+func (m *Model) setPrompt() {
+	switch {
+	case m.filterCol != nil:
+		m.filter.Prompt = m.filterCol.HeaderName() + "|"
+	case m.regexMode:
+		m.filter.Prompt = "re|"
+	default:
+		m.filter.Prompt = "|"
+	}
+}
+
+// applyFilter sends the typed keyword to the table, for the filtered column or globally
+func (m *Model) applyFilter() tea.Cmd {
+	m.applied = m.filter.Value()
+	if m.filterCol != nil {
+		return m.setColumnFilter(m.filterCol, m.applied)
+	}
+	return m.applyKeyword(m.applied)
+}
+
+// applyKeyword sets the global keyword, or warns and keeps the last good filter on a bad regexp
+func (m *Model) applyKeyword(keyword string) tea.Cmd {
+	m.keyword = keyword
+	if !m.regexMode || keyword == "" {
+		return m.setKeyword(keyword, nil)
+	}
+
+	pattern, err := regexp.Compile(keyword)
+	if err != nil {
+		return func() tea.Msg {
+			return event.SetStatusMsg{
+				Message: fmt.Sprintf("invalid regexp: %v", err),
+				Status:  event.Warn,
+			}
+		}
+	}
+	return m.setKeyword(keyword, pattern)
+}
+
+func (m *Model) setColumnFilter(node *kube.Node, keyword string) tea.Cmd {
+	return func() tea.Msg {
+		return table.SetColumnFilterMsg{
+			Node:    node,
+			Keyword: keyword,
+		}
+	}
+}
+
+func (m *Model) setKeyword(keyword string, pattern *regexp.Regexp) tea.Cmd {
 	return func() tea.Msg {
 		return table.SetKeywordMsg{
 			Keyword: keyword,
+			Pattern: pattern,
 		}
 	}
 }
 
-func (m *Model) setTable(nodes []*kube.Node, objs []*unstructured.Unstructured) tea.Cmd {
+func (m *Model) setOrder(node *kube.Node, group bool) tea.Cmd {
+	return func() tea.Msg {
+		return table.SetOrderMsg{
+			Node:  node,
+			Group: group,
+		}
+	}
+}
+
+func (m *Model) setScope(kind, scope string) tea.Cmd {
+	return func() tea.Msg {
+		return table.SetScopeMsg{
+			Kind:  kind,
+			Scope: scope,
+		}
+	}
+}
+
+// setTable scrolls to the last column when it is just picked, it may be out of view
+func (m *Model) setTable(nodes []*kube.Node, objs []*unstructured.Unstructured, picked bool) tea.Cmd {
 	return func() tea.Msg {
 		return table.SetTableMsg{
-			Nodes: nodes,
-			Objs:  objs,
+			Nodes:      nodes,
+			Objs:       objs,
+			ShowLatest: picked,
 		}
 	}
 }
@@ -166,15 +362,25 @@ func (m *Model) renderTopBar() string {
 
 	return topBarStyle.Render(
 		lipgloss.JoinHorizontal(lipgloss.Left,
+			m.renderCounts(),
 			m.filter.View(),
 			m.widthLimPB.View(),
 		),
 	)
 }
 
+// renderCounts shows how selective the filter is, e.g. 12/480 objects match
+func (m *Model) renderCounts() string {
+	visible, total := m.table.Counts()
+	return lipgloss.NewStyle().
+		Foreground(theme.Subtext0()).
+		Margin(0, 1, 0, 0).
+		Render(fmt.Sprintf("%d/%d", visible, total))
+}
+
 func (m *Model) setWidthLimitRatio(tableWidth int) tea.Cmd {
 	var cmd tea.Cmd
-	ratio := float64(tableWidth) / float64(m.width)
+	ratio := min(float64(tableWidth)/float64(m.width), 1) // wider tables scroll horizontally
 	freq := RESULT_PROGRESS_BAR_INIT_FREQ * math.Log1p(1.0-ratio)
 	m.widthLimPB.SetSpringOptions(freq, RESULT_PROGRESS_BAR_CRITICAL_DAMP)
 	cmd = m.widthLimPB.SetPercent(ratio)
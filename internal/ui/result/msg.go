@@ -16,3 +16,14 @@ type SetResultMsg struct {
 type SetTableCandidateMsg struct {
 	Candidate *kube.Node
 }
+
+type SetTableOrderMsg struct {
+	Node  *kube.Node
+	Group bool
+}
+
+// SetTableScopeMsg names the kind and where it is informed for the empty table
+type SetTableScopeMsg struct {
+	Kind  string
+	Scope string
+}
@@ -0,0 +1,70 @@
+package table
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"sigs.k8s.io/yaml"
+
+	"github.com/flavono123/kattle/internal/ui/event"
+	"github.com/flavono123/kattle/internal/ui/theme"
+)
+
+// inspect opens the YAML of the object under the cursor in place of the rows, as kubectl get -o yaml;
+// the viewport renders only the lines scrolled to however large the object is
+func (m *Model) inspect() tea.Cmd {
+	lines := m.buildLines()
+	idx := m.cursor + m.rowsView.YOffset
+	if idx < 0 || idx >= len(lines) || lines[idx].row == nil {
+		return nil
+	}
+
+	obj := lines[idx].row.obj
+	out, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return func() tea.Msg {
+			return event.SetStatusMsg{
+				Message: fmt.Sprintf("failed to inspect %s: %v", obj.GetName(), err),
+				Status:  event.Error,
+			}
+		}
+	}
+	m.inspecting = true
+	m.inspectTitle = m.kind + " " + rowKey(obj)
+	if obj.GetNamespace() == "" {
+		m.inspectTitle = m.kind + " " + obj.GetName()
+	}
+	m.inspectView.SetContent(string(out))
+	m.inspectView.GotoTop()
+	return nil
+}
+
+// Inspecting reports whether the inspect pane takes the keys, e.g. esc closes it
+func (m *Model) Inspecting() bool {
+	return m.inspecting
+}
+
+func (m *Model) updateInspect(msg tea.KeyMsg) tea.Cmd {
+	if key.Matches(msg, m.keys.closeInspect) {
+		m.inspecting = false
+		m.inspectView.SetContent("")
+		return nil
+	}
+	var cmd tea.Cmd
+	m.inspectView, cmd = m.inspectView.Update(msg)
+	return cmd
+}
+
+// renderInspect titles the pane with the object in place of the header line
+func (m *Model) renderInspect() string {
+	title := lipgloss.NewStyle().Margin(0, 0, 0, 1).Bold(true).Foreground(theme.Peach()).Render(m.inspectTitle)
+	scroll := lipgloss.NewStyle().Margin(0, 0, 0, 1).Foreground(theme.Overlay0()).
+		Render(fmt.Sprintf("%3.f%%", m.inspectView.ScrollPercent()*100))
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		lipgloss.JoinHorizontal(lipgloss.Left, title, scroll),
+		m.inspectView.View(),
+	)
+}
@@ -1,15 +1,123 @@
 package table
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"github.com/charmbracelet/bubbles/key"
+
+	"github.com/flavono123/kattle/internal/ui/keybind"
+)
 
 type keyMap struct {
-	up   key.Binding
-	down key.Binding
+	up           key.Binding
+	down         key.Binding
+	pageUp       key.Binding
+	pageDown     key.Binding
+	top          key.Binding
+	bottom       key.Binding
+	sort         key.Binding
+	reverse      key.Binding
+	namespace    key.Binding
+	left         key.Binding
+	right        key.Binding
+	copy         key.Binding
+	selectRow    key.Binding
+	onlySelected key.Binding
+	colorValues  key.Binding
+	inspect      key.Binding
+	closeInspect key.Binding
+	inspecting   bool // only the inspect pane's bindings are helped
 }
 
 func newKeyMap() keyMap {
-	return keyMap{
-		up:   key.NewBinding(key.WithKeys("up")),
-		down: key.NewBinding(key.WithKeys("down")),
+	km := keyMap{
+		up:       key.NewBinding(key.WithKeys("up", "k")),
+		down:     key.NewBinding(key.WithKeys("down", "j")),
+		pageUp:   key.NewBinding(key.WithKeys("pgup")),
+		pageDown: key.NewBinding(key.WithKeys("pgdown")),
+		top:      key.NewBinding(key.WithKeys("home")),
+		bottom:   key.NewBinding(key.WithKeys("end")),
+		sort: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "sort column"),
+		),
+		reverse: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "reverse"),
+		),
+		namespace: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "namespace"),
+		),
+		left: key.NewBinding(
+			key.WithKeys("left", "h"),
+			key.WithHelp("←/→", "column"),
+		),
+		right: key.NewBinding(key.WithKeys("right", "l")),
+		copy: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "copy cell"),
+		),
+		selectRow: key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp("spc", "select row"),
+		),
+		onlySelected: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "selected only"),
+		),
+		colorValues: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "color values"),
+		),
+		inspect: key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("i", "inspect"),
+		),
+		closeInspect: key.NewBinding(
+			key.WithKeys("esc", "i"),
+			key.WithHelp("esc", "close inspect"),
+		),
+	}
+	keybind.Rebind(keybind.Table, map[string]*key.Binding{
+		"up":           &km.up,
+		"down":         &km.down,
+		"pageUp":       &km.pageUp,
+		"pageDown":     &km.pageDown,
+		"top":          &km.top,
+		"bottom":       &km.bottom,
+		"sort":         &km.sort,
+		"reverse":      &km.reverse,
+		"namespace":    &km.namespace,
+		"left":         &km.left,
+		"right":        &km.right,
+		"copy":         &km.copy,
+		"selectRow":    &km.selectRow,
+		"onlySelected": &km.onlySelected,
+		"colorValues":  &km.colorValues,
+		"inspect":      &km.inspect,
+		"closeInspect": &km.closeInspect,
+	})
+	return km
+}
+
+func (k keyMap) ShortHelp() []key.Binding {
+	if k.inspecting {
+		return []key.Binding{k.closeInspect}
+	}
+	return []key.Binding{
+		k.sort,
+		k.reverse,
+		k.namespace,
+		k.left,
+		k.copy,
+		k.selectRow,
+		k.onlySelected,
+		k.colorValues,
+		k.inspect,
+	}
+}
+
+func (k keyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{}, // only render short help
 	}
 }
@@ -1,10 +1,15 @@
 package table
 
 import (
+	"cmp"
 	"fmt"
+	"regexp"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
@@ -18,61 +23,114 @@ import (
 )
 
 const (
-	TABLE_WIDTH_RATIO = 0.7
-	TABLE_SCROLL_STEP = 1
-	MAX_COLUMN_WIDTH  = 50
+	TABLE_WIDTH_RATIO  = 0.7
+	TABLE_SCROLL_STEP  = 1
+	MAX_COLUMN_WIDTH   = 50
+	TABLE_WIDTH_MARGIN = 9 // the borders and paddings around the rows
 )
 
 type fuzzyMatchedRow struct {
+	obj      *unstructured.Unstructured
 	cells    []string
+	sortKey  string // the raw value of the order column, see sortValue
 	matches  map[int]fuzzy.Match
 	scoreSum int
 }
 
+// order sorts rows by NAME (nil node) or a picked column,
+// optionally grouping identical values
+type order struct {
+	active bool
+	node   *kube.Node
+	desc   bool
+	group  bool
+}
+
+// less orders rows by their sort keys, missing values last in either direction
+// and falling back to the name for equal values
+func (o order) less(a, b *fuzzyMatchedRow) bool {
+	missing := kube.MissingValue()
+	aMissing, bMissing := a.sortKey == missing, b.sortKey == missing
+	if aMissing != bMissing {
+		return bMissing
+	}
+
+	c := compareValues(a.sortKey, b.sortKey)
+	if c == 0 {
+		c = strings.Compare(a.cells[0], b.cells[0])
+	}
+	if o.desc {
+		return c > 0
+	}
+	return c < 0
+}
+
 type tableStyles struct {
 	selected  lipgloss.Style
 	candidate lipgloss.Style
 	debug     lipgloss.Style
+	group     lipgloss.Style
+	marked    lipgloss.Style // NAME of the selected rows
+}
+
+func newTableStyles() tableStyles {
+	return tableStyles{
+		selected:  lipgloss.NewStyle().Background(theme.Surface0()),
+		candidate: lipgloss.NewStyle().Margin(0, 0, 0, 1).Foreground(theme.Surface2()),
+		debug:     lipgloss.NewStyle().Italic(true).Foreground(theme.Surface1()),
+		group:     lipgloss.NewStyle().Margin(0, 0, 0, 1).Bold(true).Foreground(theme.Peach()),
+		marked:    lipgloss.NewStyle().Bold(true).Foreground(theme.Mauve()),
+	}
 }
 
 type Model struct {
 	focus         bool // same with result model, sync by msg
 	keys          keyMap
 	cursor        int
+	col           int // highlighted column, 0 is NAME
+	colOffset     int // node columns scrolled out on the left, NAME is pinned
 	nodes         []*kube.Node
 	objs          []*unstructured.Unstructured
 	rowsView      viewport.Model
+	inspecting    bool           // the YAML of an object is shown in place of the rows
+	inspectTitle  string         // names the inspected object
+	inspectView   viewport.Model // scrolls the inspected YAML
 	nameMaxWidth  int
 	nodeMaxWidths []int
 	candidate     *kube.Node
 	styles        tableStyles
+	themeVersion  int // of the palette the styles are built from
 	keyword       string
+	pattern       *regexp.Regexp // the keyword compiled in regexp mode, nil for fuzzy
+	colFilter     *kube.Node     // the column colKeyword filters on, ANDed with the keyword
+	colKeyword    string
+	order         order
+	lineCount     int // rendered lines including group headers
+	showNamespace bool
+	selectedRows  map[string]bool // by rowKey, to compare a subset of objects
+	onlySelected  bool
+	colorValues   bool // status-like values in color, see valueColor
+	kind          string
+	scope         string     // where the kind is informed, named when there are no objects
+	cells         cellValues // of the render pass, see valStr
 }
 
 func NewModel(nodes []*kube.Node, objs []*unstructured.Unstructured) *Model {
-	// TODO: should 0 when no objs, impl with no resources view
-	nameMaxWidth := 4 // Name
-	for _, obj := range objs {
-		if len(displayName(obj)) > nameMaxWidth {
-			nameMaxWidth = len(displayName(obj))
-		}
-	}
-
 	m := &Model{
 		keys:          newKeyMap(),
 		cursor:        0,
 		nodes:         nodes,
 		objs:          objs,
 		rowsView:      viewport.New(0, 0),
-		nameMaxWidth:  nameMaxWidth,
+		inspectView:   viewport.New(0, 0),
 		nodeMaxWidths: []int{},
-		styles: tableStyles{
-			selected:  lipgloss.NewStyle().Background(theme.Surface0()),
-			candidate: lipgloss.NewStyle().Margin(0, 0, 0, 1).Foreground(theme.Surface2()),
-			debug:     lipgloss.NewStyle().Italic(true).Foreground(theme.Surface1()),
-		},
-		keyword: "",
+		selectedRows:  map[string]bool{},
+		cells:         newCellValues(),
+		styles:        newTableStyles(),
+		themeVersion:  theme.Version(),
+		keyword:       "",
 	}
+	m.setNameMaxWidth()
 	return m
 }
 
@@ -82,6 +140,7 @@ func (m *Model) Init() tea.Cmd {
 
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
+	m.resetValues() // a pass is an update and the view following it
 
 	switch msg := msg.(type) {
 	case SetCandidateMsg:
@@ -93,15 +152,33 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		m.setCandidate(msg.Candidate)
 	case SetKeywordMsg:
-		m.setKeyword(msg.Keyword)
+		m.setKeyword(msg.Keyword, msg.Pattern)
+	case SetColumnFilterMsg:
+		m.setColumnFilter(msg.Node, msg.Keyword)
+	case SetOrderMsg:
+		cmd = m.setOrder(msg.Node, msg.Group)
+	case SetScopeMsg:
+		m.kind = msg.Kind
+		m.scope = msg.Scope
 	case SetTableMsg:
 		m.setNodes(msg.Nodes)
 		m.setObjs(msg.Objs)
+		if msg.ShowLatest {
+			m.col = m.cols() - 1
+		}
+		m.scrollToCol()
 		cmd = m.tableUpdated()
 	case tea.WindowSizeMsg:
 		m.setViewSize(msg)
 	case tea.KeyMsg:
+		if m.inspecting {
+			cmd = m.updateInspect(msg)
+			break
+		}
 		switch {
+		case len(m.objs) == 0 && key.Matches(msg, m.keys.up, m.keys.down, m.keys.pageUp, m.keys.pageDown,
+			m.keys.top, m.keys.bottom, m.keys.left, m.keys.right):
+			// nothing to move over
 		case key.Matches(msg, m.keys.up):
 			if m.isCursorTop() {
 				m.cursor--
@@ -114,6 +191,40 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			} else {
 				m.rowsView.ScrollDown(TABLE_SCROLL_STEP)
 			}
+		case key.Matches(msg, m.keys.pageUp):
+			m.scrollBy(-m.pageLines())
+		case key.Matches(msg, m.keys.pageDown):
+			m.scrollBy(m.pageLines())
+		case key.Matches(msg, m.keys.top):
+			m.scrollBy(-len(m.buildLines()))
+		case key.Matches(msg, m.keys.bottom):
+			m.scrollBy(len(m.buildLines()))
+		case key.Matches(msg, m.keys.sort):
+			cmd = m.cycleSortColumn()
+		case key.Matches(msg, m.keys.reverse):
+			cmd = m.reverseSort()
+		case key.Matches(msg, m.keys.namespace):
+			cmd = m.toggleNamespace()
+		case key.Matches(msg, m.keys.left):
+			if m.col > 0 {
+				m.col--
+				m.scrollToCol()
+			}
+		case key.Matches(msg, m.keys.right):
+			if m.col < m.cols()-1 {
+				m.col++
+				m.scrollToCol()
+			}
+		case key.Matches(msg, m.keys.copy):
+			cmd = m.copyCell()
+		case key.Matches(msg, m.keys.selectRow):
+			cmd = m.toggleRow()
+		case key.Matches(msg, m.keys.onlySelected):
+			cmd = m.toggleOnlySelected()
+		case key.Matches(msg, m.keys.colorValues):
+			cmd = m.toggleValueColors()
+		case key.Matches(msg, m.keys.inspect):
+			cmd = m.inspect()
 		}
 	}
 
@@ -121,6 +232,13 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) View() string {
+	m.restyle()
+	if m.inspecting {
+		return m.renderInspect()
+	}
+	if len(m.objs) == 0 {
+		return m.renderEmpty()
+	}
 	content := m.renderRow()
 	m.rowsView.SetContent(content)
 	return lipgloss.JoinVertical(
@@ -130,6 +248,59 @@ func (m *Model) View() string {
 	)
 }
 
+// restyle rebuilds the styles when the palette changed since they were built
+func (m *Model) restyle() {
+	if m.themeVersion == theme.Version() {
+		return
+	}
+	m.styles = newTableStyles()
+	m.themeVersion = theme.Version()
+}
+
+// renderEmpty centers a message in place of the header and rows when the kind has no objects
+func (m *Model) renderEmpty() string {
+	m.lineCount = 0
+	msg := "no resources found"
+	if m.kind != "" {
+		msg = fmt.Sprintf("no %s found", m.kind)
+	}
+	if m.scope != "" {
+		msg += " in " + m.scope
+	}
+	return lipgloss.Place(
+		m.rowsView.Width, m.rowsView.Height+1, // and the header line
+		lipgloss.Center, lipgloss.Center,
+		lipgloss.NewStyle().Foreground(theme.Overlay0()).Render(msg),
+	)
+}
+
+func (m *Model) Keys() keyMap {
+	keys := m.keys
+	keys.inspecting = m.inspecting
+	return keys
+}
+
+// VisibleObjs returns the objects as shown, filtered by the keyword and in row order
+func (m *Model) VisibleObjs() []*unstructured.Unstructured {
+	var objs []*unstructured.Unstructured
+	for _, line := range m.buildLines() {
+		if line.row != nil {
+			objs = append(objs, line.row.obj)
+		}
+	}
+	return objs
+}
+
+// Counts returns how many objects the rows show, after the keyword and row selection, of all
+func (m *Model) Counts() (visible, total int) {
+	for _, line := range m.buildLines() {
+		if line.row != nil {
+			visible++
+		}
+	}
+	return visible, len(m.objs)
+}
+
 func (m *Model) Keyword() string {
 	return m.keyword
 }
@@ -164,9 +335,12 @@ func (m *Model) renderHeader() string {
 	var render strings.Builder
 	// headers
 	if len(m.objs) > 0 {
-		render.WriteString(m.cellStyle(0).Render("NAME"))
-		for i, node := range m.nodes {
-			render.WriteString(m.cellStyle(i + 1).Render(node.HeaderName()))
+		for _, col := range m.visibleCols() {
+			header := "NAME"
+			if col > 0 {
+				header = m.nodes[col-1].HeaderName()
+			}
+			render.WriteString(m.highlightedCellStyle(col, m.focus).Render(header))
 		}
 	}
 
@@ -181,48 +355,121 @@ func (m *Model) renderHeader() string {
 	return m.headerStyle().Render(render.String())
 }
 
-func (m *Model) renderRow() string {
+// tableLine is a rendered line, either an object row or a group header
+type tableLine struct {
+	row    *fuzzyMatchedRow
+	header string
+}
+
+// buildLines filters and orders the rows, inserting group headers when grouping
+func (m *Model) buildLines() []tableLine {
+	orderCol, ordered := m.orderCol()
 	rows := []fuzzyMatchedRow{}
 	// 모든 행에 대해 cells 준비
 	for _, obj := range m.objs {
+		if m.onlySelected && !m.selectedRows[rowKey(obj)] {
+			continue
+		}
 		cells := []string{}
-		cells = append(cells, displayName(obj))
+		cells = append(cells, m.displayName(obj))
 		for _, node := range m.nodes {
-			cells = append(cells, kube.ValStr(node, obj))
+			cells = append(cells, m.valStr(node, obj))
 		}
 		// 후보 노드가 있으면 cells에 추가
 		if m.candidate != nil {
-			cells = append(cells, kube.ValStr(m.candidate, obj))
+			cells = append(cells, m.valStr(m.candidate, obj))
 		}
 
 		matches := map[int]fuzzy.Match{}
 		scoreSum := 0
-		if m.keyword != "" {
+		if m.pattern != nil {
+			matches = regexpFind(m.pattern, cells)
+		} else if m.keyword != "" {
 			// 키워드가 있을 때만 퍼지 매치 수행
 			for _, match := range fuzzy.Find(m.keyword, cells) {
 				matches[match.Index] = match
 				scoreSum += match.Score
 			}
 		}
-		rows = append(rows, fuzzyMatchedRow{cells: cells, matches: matches, scoreSum: scoreSum})
+		if m.keyword != "" && len(matches) == 0 {
+			continue
+		}
+		if col, ok := m.colFilterCol(); ok {
+			colMatches := fuzzy.Find(m.colKeyword, []string{cells[col]})
+			if len(colMatches) == 0 {
+				continue
+			}
+			// the filtered column highlights why the row matched it
+			colMatches[0].Index = col
+			matches[col] = colMatches[0]
+		}
+		row := fuzzyMatchedRow{obj: obj, cells: cells, matches: matches, scoreSum: scoreSum}
+		if ordered {
+			row.sortKey = cells[0]
+			if orderCol > 0 {
+				row.sortKey = m.sortValue(m.nodes[orderCol-1], obj)
+			}
+		}
+		rows = append(rows, row)
 	}
 
-	lines := make([]string, 0, len(rows))
-	var builder strings.Builder
-
-	if m.keyword != "" {
-		sort.Slice(rows, func(i, j int) bool {
+	if ordered {
+		sort.SliceStable(rows, func(i, j int) bool {
+			return m.order.less(&rows[i], &rows[j])
+		})
+	} else if m.keyword != "" && m.pattern == nil {
+		// regexp matches keep the row order, they are not scored,
+		// nor do equally scored rows jump around between renders
+		sort.SliceStable(rows, func(i, j int) bool {
 			return rows[i].scoreSum > rows[j].scoreSum
 		})
 	}
 
-	for i, row := range rows {
-		if m.keyword != "" && len(row.matches) == 0 {
+	groupCounts := map[string]int{}
+	if ordered && m.order.group {
+		for _, row := range rows {
+			groupCounts[row.cells[orderCol]]++
+		}
+	}
+
+	lines := make([]tableLine, 0, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		if len(groupCounts) > 0 && (i == 0 || rows[i-1].cells[orderCol] != row.cells[orderCol]) {
+			value := row.cells[orderCol]
+			lines = append(lines, tableLine{
+				header: fmt.Sprintf("%s: %s (%d)", m.orderHeaderName(), value, groupCounts[value]),
+			})
+		}
+		lines = append(lines, tableLine{row: row})
+	}
+
+	return lines
+}
+
+func (m *Model) renderRow() string {
+	tableLines := m.buildLines()
+	lines := make([]string, 0, len(tableLines))
+	var builder strings.Builder
+
+	for i, tl := range tableLines {
+		if tl.row == nil {
+			header := m.styles.group.Render(tl.header)
+			if m.isCursor(i) {
+				header = m.styles.selected.Render(header)
+			}
+			lines = append(lines, header)
 			continue
 		}
 
+		row := tl.row
 		builder.Reset()
-		for j, cell := range row.cells {
+		cols := m.visibleCols()
+		if m.candidate != nil {
+			cols = append(cols, len(row.cells)-1)
+		}
+		for _, j := range cols {
+			cell := row.cells[j]
 			var renderedCell string
 			if j == len(row.cells)-1 && m.candidate != nil {
 				if match, ok := row.matches[j]; ok {
@@ -231,10 +478,19 @@ func (m *Model) renderRow() string {
 					renderedCell = m.styles.candidate.Render(cell)
 				}
 			} else {
+				style := m.highlightedCellStyle(j, m.focus && m.isCursor(i))
+				if j == 0 && m.selectedRows[rowKey(row.obj)] {
+					style = style.Inherit(m.styles.marked)
+				}
+				unmatchedStyle := lipgloss.NewStyle().Foreground(theme.Text())
+				if color, ok := valueColor(cell); ok && m.colorValues && j > 0 {
+					style = style.Foreground(color)
+					unmatchedStyle = unmatchedStyle.Foreground(color)
+				}
 				if match, ok := row.matches[j]; ok {
-					renderedCell = m.cellStyle(j).Render(highlight(truncate(cell, m.colMaxWidth(j)), match, lipgloss.NewStyle().Foreground(theme.Text())))
+					renderedCell = style.Render(truncateHighlight(cell, m.colMaxWidth(j), match, unmatchedStyle))
 				} else {
-					renderedCell = m.cellStyle(j).Render(truncate(cell, m.colMaxWidth(j)))
+					renderedCell = style.Render(truncate(cell, m.colMaxWidth(j)))
 				}
 			}
 			builder.WriteString(renderedCell)
@@ -247,6 +503,7 @@ func (m *Model) renderRow() string {
 		lines = append(lines, line)
 	}
 
+	m.lineCount = len(lines)
 	return strings.Join(lines, "\n")
 }
 
@@ -254,24 +511,30 @@ func (m *Model) isCursor(index int) bool {
 	return index == m.cursor+m.rowsView.YOffset
 }
 
-func (m *Model) setNodeMaxWidths(nodes []*kube.Node) {
-	// name
-	nameMaxWidth := 4
+func (m *Model) setNameMaxWidth() {
+	if len(m.objs) == 0 {
+		m.nameMaxWidth = 0 // no header is rendered, see renderEmpty
+		return
+	}
+	nameMaxWidth := 4 // Name
 	for _, obj := range m.objs {
-		if len(displayName(obj)) > nameMaxWidth {
-			nameMaxWidth = len(displayName(obj))
+		if w := lipgloss.Width(m.displayName(obj)); w > nameMaxWidth {
+			nameMaxWidth = w
 		}
 	}
 	m.nameMaxWidth = nameMaxWidth
+}
+
+func (m *Model) setNodeMaxWidths(nodes []*kube.Node) {
+	m.setNameMaxWidth()
 
 	var nodeMaxWidths []int
 
 	for _, node := range nodes {
-		max := len(node.HeaderName())
+		max := lipgloss.Width(node.HeaderName())
 		for _, obj := range m.objs {
-			val := kube.ValStr(node, obj)
-			if len(val) > max {
-				max = len(val)
+			if w := lipgloss.Width(m.valStr(node, obj)); w > max {
+				max = w
 			}
 		}
 		if max > MAX_COLUMN_WIDTH {
@@ -287,13 +550,204 @@ func (m *Model) cellStyle(col int) lipgloss.Style {
 	return lipgloss.NewStyle().Margin(0, 0, 0, 1).Width(m.colMaxWidth(col))
 }
 
+// highlightedCellStyle underlines the highlighted column when highlight is set
+func (m *Model) highlightedCellStyle(col int, highlight bool) lipgloss.Style {
+	return m.cellStyle(col).Underline(highlight && col == m.col)
+}
+
+// cellValue returns the raw value of the highlighted column in the cursor row, not as rendered,
+// found is false when the object has no value there and ok when there is no such cell
+func (m *Model) cellValue() (value string, found, ok bool) {
+	lines := m.buildLines()
+	idx := m.cursor + m.rowsView.YOffset
+	if idx < 0 || idx >= len(lines) || lines[idx].row == nil || m.col > len(m.nodes) {
+		return "", false, false
+	}
+	obj := lines[idx].row.obj
+	if m.col == 0 {
+		return obj.GetName(), true, true
+	}
+	value, found = kube.LookupValStr(m.nodes[m.col-1], obj)
+	return value, found, true
+}
+
+// copyCell yanks the cell value to the clipboard, showing it instead when the clipboard is unavailable
+func (m *Model) copyCell() tea.Cmd {
+	value, found, ok := m.cellValue()
+	if !ok {
+		return nil
+	}
+	if !found {
+		return func() tea.Msg {
+			return event.SetStatusMsg{
+				Message: "no value to copy",
+				Status:  event.Warn,
+			}
+		}
+	}
+	return func() tea.Msg {
+		if err := clipboard.WriteAll(value); err != nil {
+			return event.SetStatusMsg{
+				Message: value,
+				Status:  event.Warn,
+			}
+		}
+		return event.SetStatusMsg{
+			Message: "copied: " + value,
+			Status:  event.Info,
+		}
+	}
+}
+
 func (m *Model) setNodes(nodes []*kube.Node) {
 	m.setNodeMaxWidths(nodes)
 	m.nodes = nodes
+	m.col = min(m.col, m.cols()-1) // the column may be unpicked
+	if _, ok := m.orderCol(); m.order.active && !ok {
+		m.order = order{} // the ordering column was unpicked
+	}
+	if !slices.Contains(nodes, m.colFilter) {
+		m.setColumnFilter(nil, "")
+	}
+}
+
+// setOrder sorts (and groups) rows by node, toggling off when repeated
+func (m *Model) setOrder(node *kube.Node, group bool) tea.Cmd {
+	if node == nil || (m.order.active && m.order.node == node && m.order.group == group) {
+		m.keepCursor(func() { m.order = order{} })
+		return nil
+	}
+
+	m.keepCursor(func() { m.order = order{active: true, node: node, group: group} })
+	action := "sorted"
+	if group {
+		action = "grouped"
+	}
+	return m.orderStatus(fmt.Sprintf("%s by `%s'", action, strings.Join(node.NodeFullPath(), ".")))
+}
+
+// cycleSortColumn moves the sort to the next column: NAME, each picked column, then none
+func (m *Model) cycleSortColumn() tea.Cmd {
+	col, ok := m.orderCol()
+	switch {
+	case !ok:
+		m.keepCursor(func() { m.order = order{active: true} })
+	case col < len(m.nodes):
+		m.keepCursor(func() { m.order = order{active: true, node: m.nodes[col], desc: m.order.desc} })
+	default:
+		m.keepCursor(func() { m.order = order{} })
+		return m.orderStatus("unsorted")
+	}
+	return m.orderStatus(m.sortDescription())
+}
+
+// reverseSort flips the direction, sorting by NAME when unsorted
+func (m *Model) reverseSort() tea.Cmd {
+	m.keepCursor(func() {
+		if !m.order.active {
+			m.order = order{active: true}
+		}
+		m.order.desc = !m.order.desc
+	})
+	return m.orderStatus(m.sortDescription())
+}
+
+func (m *Model) sortDescription() string {
+	direction := "ascending"
+	if m.order.desc {
+		direction = "descending"
+	}
+	return fmt.Sprintf("sorted by %s, %s", m.orderHeaderName(), direction)
+}
+
+func (m *Model) orderStatus(message string) tea.Cmd {
+	return func() tea.Msg {
+		return event.SetStatusMsg{
+			Message: message,
+			Status:  event.Info,
+		}
+	}
+}
+
+func (m *Model) orderHeaderName() string {
+	if m.order.node == nil {
+		return "NAME"
+	}
+	return m.order.node.HeaderName()
+}
+
+// orderCol returns the cell index of the ordering column,
+// false when there is no ordering or its node is not picked
+func (m *Model) orderCol() (int, bool) {
+	if !m.order.active {
+		return 0, false
+	}
+	if m.order.node == nil {
+		return 0, true
+	}
+	for i, node := range m.nodes {
+		if node == m.order.node {
+			return i + 1, true
+		}
+	}
+	return 0, false
+}
+
+// keepCursor applies change to the row order, keeping the cursor on the same object
+func (m *Model) keepCursor(change func()) {
+	lines := m.buildLines()
+	var obj *unstructured.Unstructured
+	if idx := m.cursor + m.rowsView.YOffset; idx < len(lines) && lines[idx].row != nil {
+		obj = lines[idx].row.obj
+	}
+
+	change()
+
+	if obj == nil {
+		return
+	}
+	for idx, line := range m.buildLines() {
+		if line.row != nil && line.row.obj == obj {
+			m.moveCursorTo(idx)
+			return
+		}
+	}
+}
+
+// moveCursorTo points the cursor at line idx, scrolling only when it is out of view
+func (m *Model) moveCursorTo(idx int) {
+	last := max(m.rowsView.Height-2, 0) // the root status bar
+	if idx < m.rowsView.YOffset || idx > m.rowsView.YOffset+last {
+		m.rowsView.YOffset = max(idx-last, 0)
+	}
+	m.cursor = idx - m.rowsView.YOffset
+}
+
+// visibleLines is the number of rows shown, the root status bar covers the last one
+func (m *Model) visibleLines() int {
+	return max(m.rowsView.Height-1, 1)
+}
+
+// pageLines is how far a page moves, the height of the view less one as in the nav
+func (m *Model) pageLines() int {
+	return max(m.rowsView.Height-1, 1)
+}
+
+// scrollBy moves the viewport and the cursor by lines, clamped to the rendered lines
+func (m *Model) scrollBy(lines int) {
+	count := len(m.buildLines())
+	height := m.visibleLines()
+	idx := min(max(m.cursor+m.rowsView.YOffset+lines, 0), max(count-1, 0))
+	m.rowsView.YOffset = min(max(m.rowsView.YOffset+lines, 0), max(count-height, 0))
+	m.moveCursorTo(idx)
 }
 
 func (m *Model) setObjs(objs []*unstructured.Unstructured) {
 	m.objs = objs
+	m.resetValues()             // the objects may be refreshed under the same keys
+	m.setNodeMaxWidths(m.nodes) // e.g. the first objects of a kind that had none
+	m.pruneSelectedRows()
+	m.clampCursor() // e.g. the last row deleted under the cursor
 }
 
 func (m *Model) colMaxWidth(idxPlusOne int) int {
@@ -315,28 +769,69 @@ func (m *Model) isCursorTop() bool {
 }
 
 func (m *Model) isCursorBottom() bool {
-	// objs size as an index(-1) and the root status bar(-1)
-	return m.cursor < min(len(m.objs)-1, m.rowsView.Height-2)
+	// rendered lines as an index(-1) and the root status bar(-1)
+	return m.cursor < min(m.lineCount-1, m.rowsView.Height-2)
 }
 
 func (m *Model) setViewSize(msg tea.WindowSizeMsg) {
 	m.rowsView.Width = int(float64(msg.Width) * TABLE_WIDTH_RATIO)
 	m.rowsView.Height = msg.Height - 2 // HACK: (topbar 1 + header 1) + root status bar + 1
+	m.inspectView.Width = m.rowsView.Width
+	m.inspectView.Height = m.rowsView.Height // the title takes the header line
 }
 
+// WillOverWidth reports whether the candidate column would not fit next to the visible columns
 func (m *Model) WillOverWidth(node *kube.Node) bool {
 	if node == nil {
 		return false
 	}
 
-	return m.TableWidth()+m.maxWidth(node) > m.rowsView.Width-9 // magic num again, safty margin
+	return m.visibleWidth()+m.maxWidth(node) > m.rowsView.Width-TABLE_WIDTH_MARGIN
+}
+
+// visibleCols lists the rendered columns: NAME pinned, then the node columns from colOffset
+// as many as fit in the view, at least one
+func (m *Model) visibleCols() []int {
+	cols := []int{0}
+	width := m.colMaxWidth(0) + 1
+	for col := m.colOffset + 1; col < m.cols(); col++ {
+		width += m.colMaxWidth(col) + 1
+		if width > m.rowsView.Width-TABLE_WIDTH_MARGIN && len(cols) > 1 {
+			break
+		}
+		cols = append(cols, col)
+	}
+	return cols
+}
+
+func (m *Model) visibleWidth() int {
+	width := 0
+	for _, col := range m.visibleCols() {
+		width += m.colMaxWidth(col) + 1
+	}
+	return width
+}
+
+// scrollToCol shifts the node columns until the highlighted one is visible
+func (m *Model) scrollToCol() {
+	m.colOffset = min(m.colOffset, max(len(m.nodes)-1, 0)) // columns may be unpicked
+	if m.col == 0 {
+		return
+	}
+	if m.col-1 < m.colOffset {
+		m.colOffset = m.col - 1
+		return
+	}
+	for !slices.Contains(m.visibleCols(), m.col) {
+		m.colOffset++
+	}
 }
 
 func (m *Model) maxWidth(node *kube.Node) int {
-	max := len(node.Name())
+	max := lipgloss.Width(node.Name())
 	for _, obj := range m.objs {
-		if len(kube.ValStr(node, obj)) > max {
-			max = len(kube.ValStr(node, obj))
+		if w := lipgloss.Width(m.valStr(node, obj)); w > max {
+			max = w
 		}
 	}
 	if max > MAX_COLUMN_WIDTH {
@@ -357,18 +852,52 @@ func (m *Model) cols() int {
 	return len(m.nodes) + 1 // name + nodes
 }
 
-func (m *Model) setKeyword(keyword string) {
+func (m *Model) setKeyword(keyword string, pattern *regexp.Regexp) {
 	m.keyword = keyword
+	m.pattern = pattern
+}
+
+func (m *Model) setColumnFilter(node *kube.Node, keyword string) {
+	m.colFilter = node
+	m.colKeyword = keyword
+}
+
+// colFilterCol returns the cell index of the filtered column, false when there is no column filter
+func (m *Model) colFilterCol() (int, bool) {
+	if m.colFilter == nil || m.colKeyword == "" {
+		return 0, false
+	}
+	i := slices.Index(m.nodes, m.colFilter)
+	return i + 1, i >= 0
 }
 
 // helpers
+
+// regexpFind marks the first match of the pattern in each cell as rune indexes, like fuzzy.Find
+func regexpFind(pattern *regexp.Regexp, cells []string) map[int]fuzzy.Match {
+	matches := map[int]fuzzy.Match{}
+	for i, cell := range cells {
+		loc := pattern.FindStringIndex(cell)
+		if loc == nil {
+			continue
+		}
+		// the byte offsets of the matched runes, as fuzzy.Find and highlight
+		var indexes []int
+		for j := range cell[loc[0]:loc[1]] {
+			indexes = append(indexes, loc[0]+j)
+		}
+		matches[i] = fuzzy.Match{Str: cell, Index: i, MatchedIndexes: indexes}
+	}
+	return matches
+}
+
 func highlight(s string, match fuzzy.Match, unmatchedStyle lipgloss.Style) string {
 	highlightStyle := lipgloss.NewStyle().Foreground(theme.Blue())
 
-	runes := []rune(s)
-	result := make([]rune, 0, len(runes))
+	result := make([]rune, 0, len(s))
 
-	for i, r := range runes {
+	// matched indexes are byte offsets
+	for i, r := range s {
 		if contains(match.MatchedIndexes, i) {
 			result = append(result, []rune(highlightStyle.Render(string(r)))...)
 		} else {
@@ -379,6 +908,24 @@ func highlight(s string, match fuzzy.Match, unmatchedStyle lipgloss.Style) strin
 	return string(result)
 }
 
+// truncateHighlight truncates s as truncate does, highlighting only the matches left visible
+func truncateHighlight(s string, max int, match fuzzy.Match, unmatchedStyle lipgloss.Style) string {
+	truncated := truncate(s, max)
+	if truncated == s {
+		return highlight(s, match, unmatchedStyle)
+	}
+
+	// the kept prefix shares the byte offsets of s, the ellipsis is never highlighted
+	visible := len(strings.TrimSuffix(truncated, "..."))
+	var indexes []int
+	for _, i := range match.MatchedIndexes {
+		if i < visible {
+			indexes = append(indexes, i)
+		}
+	}
+	return highlight(truncated, fuzzy.Match{MatchedIndexes: indexes}, unmatchedStyle)
+}
+
 func contains(slice []int, item int) bool {
 	for _, v := range slice {
 		if v == item {
@@ -388,24 +935,64 @@ func contains(slice []int, item int) bool {
 	return false
 }
 
-func displayName(obj *unstructured.Unstructured) string {
-	// TODO: gonna be namespace toggling feature
-	// HACK: to reduce the width of table before viewport supporting horizontal scroll
-	// if obj.GetNamespace() != "" {
-	// 	return fmt.Sprintf("%s/%s", obj.GetNamespace(), obj.GetName())
-	// }
+// displayName prefixes the namespace when toggled on, off by default to keep the table narrow;
+// cluster-scoped objects always show the bare name
+func (m *Model) displayName(obj *unstructured.Unstructured) string {
+	if m.showNamespace && obj.GetNamespace() != "" {
+		return fmt.Sprintf("%s/%s", obj.GetNamespace(), obj.GetName())
+	}
 	return obj.GetName()
 }
 
+func (m *Model) toggleNamespace() tea.Cmd {
+	m.keepCursor(func() {
+		m.showNamespace = !m.showNamespace
+		m.setNameMaxWidth()
+	})
+	return m.tableUpdated()
+}
+
 func (m *Model) tableUpdated() tea.Cmd {
 	return func() tea.Msg {
 		return event.TableUpdatedMsg{Width: m.TableWidth()}
 	}
 }
 
+// compareValues orders numbers and quantities (500m < 1) numerically and anything else lexically,
+// numbers before strings
+func compareValues(a, b string) int {
+	af, aOk := sortKey(a)
+	bf, bOk := sortKey(b)
+	switch {
+	case aOk && bOk:
+		return cmp.Compare(af, bf)
+	case aOk:
+		return -1
+	case bOk:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+func sortKey(s string) (float64, bool) {
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f, true
+	}
+	return kube.QuantitySortKey(s)
+}
+
 func truncate(s string, max int) string {
-	if len(s) > max {
-		return s[:max-3] + "..."
+	if lipgloss.Width(s) <= max {
+		return s
+	}
+	// cut by display width, wide glyphs take two cells
+	width := 0
+	for i, r := range s {
+		width += lipgloss.Width(string(r))
+		if width > max-3 {
+			return s[:i] + "..."
+		}
 	}
 	return s
 }
@@ -1,11 +1,25 @@
 package table
 
 import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	catppuccin "github.com/catppuccin/go"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/sahilm/fuzzy"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/flavono123/kattle/internal/kube"
+	"github.com/flavono123/kattle/internal/ui/event"
+	"github.com/flavono123/kattle/internal/ui/theme"
 )
 
 var _ = Describe("Table", func() {
@@ -27,6 +41,59 @@ var _ = Describe("Table", func() {
 		})
 	})
 
+	Describe("TruncateHighlight", func() {
+		highlighted := func(s string) string {
+			return lipgloss.NewStyle().Foreground(theme.Blue()).Render(s)
+		}
+		plain := lipgloss.NewStyle()
+		ansiRe := regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+		BeforeEach(func() {
+			profile := lipgloss.ColorProfile()
+			lipgloss.SetColorProfile(termenv.TrueColor)
+			DeferCleanup(func() { lipgloss.SetColorProfile(profile) })
+		})
+
+		It("should not highlight the ellipsis for matches past the truncation point", func() {
+			s := "abcdefghijklmnop"
+			match := fuzzy.Find("bfg", []string{s})[0]
+
+			out := truncateHighlight(s, 8, match, plain)
+			Expect(ansiRe.ReplaceAllString(out, "")).To(Equal("abcde..."))
+			Expect(out).To(ContainSubstring(highlighted("b")))
+			Expect(out).NotTo(ContainSubstring(highlighted(".")))
+		})
+
+		It("should highlight the matched characters of multibyte values", func() {
+			s := "héllo wörld"
+			match := fuzzy.Find("wö", []string{s})[0]
+
+			out := truncateHighlight(s, 20, match, plain)
+			Expect(ansiRe.ReplaceAllString(out, "")).To(Equal(s))
+			Expect(out).To(ContainSubstring(highlighted("w")))
+			Expect(out).To(ContainSubstring(highlighted("ö")))
+			Expect(out).NotTo(ContainSubstring(highlighted("r")))
+		})
+
+		It("should highlight the regexp matches of multibyte values", func() {
+			s := "é-running"
+			match := regexpFind(regexp.MustCompile("run"), []string{s})[0]
+
+			out := truncateHighlight(s, 20, match, plain)
+			Expect(ansiRe.ReplaceAllString(out, "")).To(Equal(s))
+			Expect(out).To(ContainSubstring(highlighted("r") + highlighted("u") + highlighted("n")))
+			Expect(out).NotTo(ContainSubstring(highlighted("-")))
+		})
+
+		It("should truncate multibyte values by characters", func() {
+			Expect(truncate("ääääääää", 6)).To(Equal("äää..."))
+		})
+
+		It("should truncate wide values by display width", func() {
+			Expect(truncate("日本語日本語", 7)).To(Equal("日本..."))
+		})
+	})
+
 	Describe("WillOverWidth", func() {
 		It("should cap max width and return false if within limit", func() {
 			longStr := ""
@@ -58,4 +125,717 @@ var _ = Describe("Table", func() {
 			Expect(m.maxWidth(longNode)).To(Equal(MAX_COLUMN_WIDTH))
 		})
 	})
+
+	Describe("Aggregated arrays", func() {
+		It("should measure the column by the joined values", func() {
+			objs := []*unstructured.Unstructured{
+				{Object: map[string]interface{}{"args": []interface{}{"--port", "8080"}}},
+			}
+			fieldTree := map[string]*kube.Field{
+				"args": {Name: "args", Type: "[]string"},
+			}
+			args := kube.CreateNodeTree(fieldTree, objs, nil)["args"]
+			args.ToggleAggregate()
+
+			m := NewModel(nil, objs)
+			m.setNodes([]*kube.Node{args})
+			Expect(m.nodeMaxWidths).To(Equal([]int{len("--port, 8080")}))
+		})
+	})
+
+	Describe("compareValues", func() {
+		It("should compare numbers numerically", func() {
+			Expect(compareValues("9", "10")).To(Equal(-1))
+			Expect(compareValues("1.5", "1.5")).To(Equal(0))
+		})
+
+		It("should compare quantities by their amount", func() {
+			Expect(compareValues("500m", "1")).To(Equal(-1))
+			Expect(compareValues("1Gi", "512Mi")).To(Equal(1))
+			Expect(compareValues("1000m", "1")).To(Equal(0))
+		})
+
+		It("should compare strings lexically after numbers", func() {
+			Expect(compareValues("b", "a")).To(Equal(1))
+			Expect(compareValues("10", "a")).To(Equal(-1))
+			Expect(compareValues("-", "3")).To(Equal(1))
+		})
+	})
+
+	Describe("Order", func() {
+		var (
+			m     *Model
+			phase *kube.Node
+		)
+
+		newPod := func(name, phase string) *unstructured.Unstructured {
+			return &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"metadata": map[string]interface{}{"name": name},
+					"status":   map[string]interface{}{"phase": phase},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			objs := []*unstructured.Unstructured{
+				newPod("a", "Running"),
+				newPod("b", "Pending"),
+				newPod("c", "Running"),
+			}
+			fieldTree := map[string]*kube.Field{
+				"status": {
+					Name: "status",
+					Type: "PodStatus",
+					Children: map[string]*kube.Field{
+						"phase": {Name: "phase", Type: "string", Prefix: []string{"status"}},
+					},
+				},
+			}
+			phase = kube.CreateNodeTree(fieldTree, objs, nil)["status"].Children()["phase"]
+
+			m = NewModel(nil, objs)
+			m.setNodes([]*kube.Node{phase})
+		})
+
+		It("should sort rows by the column", func() {
+			m.setOrder(phase, false)
+			lines := strings.Split(m.renderRow(), "\n")
+
+			Expect(lines).To(HaveLen(3))
+			Expect(lines[0]).To(ContainSubstring("b"))
+			Expect(lines[1]).To(ContainSubstring("a"))
+			Expect(lines[2]).To(ContainSubstring("c"))
+		})
+
+		It("should insert a header for each group", func() {
+			m.setOrder(phase, true)
+			lines := strings.Split(m.renderRow(), "\n")
+
+			Expect(lines).To(HaveLen(5))
+			Expect(lines[0]).To(ContainSubstring("PHASE: Pending (1)"))
+			Expect(lines[2]).To(ContainSubstring("PHASE: Running (2)"))
+			Expect(m.lineCount).To(Equal(5))
+		})
+
+		It("should toggle off when set again", func() {
+			m.setOrder(phase, true)
+			m.setOrder(phase, true)
+			Expect(m.order.node).To(BeNil())
+		})
+
+		It("should clear when the column is unpicked", func() {
+			m.setOrder(phase, false)
+			m.setNodes([]*kube.Node{})
+			Expect(m.order.node).To(BeNil())
+		})
+	})
+
+	Describe("Sort", func() {
+		var (
+			m        *Model
+			replicas *kube.Node
+		)
+
+		newDeploy := func(name string, replicas interface{}) *unstructured.Unstructured {
+			spec := map[string]interface{}{}
+			if replicas != nil {
+				spec["replicas"] = replicas
+			}
+			return &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"metadata": map[string]interface{}{"name": name},
+					"spec":     spec,
+				},
+			}
+		}
+
+		names := func() []string {
+			var result []string
+			for _, line := range m.buildLines() {
+				result = append(result, line.row.cells[0])
+			}
+			return result
+		}
+
+		BeforeEach(func() {
+			objs := []*unstructured.Unstructured{
+				newDeploy("a", int64(10)),
+				newDeploy("b", nil),
+				newDeploy("c", int64(9)),
+				newDeploy("d", int64(10)),
+			}
+			fieldTree := map[string]*kube.Field{
+				"spec": {
+					Name: "spec",
+					Type: "DeploymentSpec",
+					Children: map[string]*kube.Field{
+						"replicas": {Name: "replicas", Type: "integer", Prefix: []string{"spec"}},
+					},
+				},
+			}
+			replicas = kube.CreateNodeTree(fieldTree, objs, nil)["spec"].Children()["replicas"]
+
+			m = NewModel(nil, objs)
+			m.rowsView.Height = 10
+			m.setNodes([]*kube.Node{replicas})
+		})
+
+		It("should cycle through NAME, picked columns and none", func() {
+			m.cycleSortColumn()
+			Expect(m.order.active).To(BeTrue())
+			Expect(m.order.node).To(BeNil())
+
+			m.cycleSortColumn()
+			Expect(m.order.node).To(Equal(replicas))
+
+			m.cycleSortColumn()
+			Expect(m.order.active).To(BeFalse())
+		})
+
+		It("should sort numerically with missing values last and ties by name", func() {
+			m.cycleSortColumn()
+			m.cycleSortColumn()
+			Expect(names()).To(Equal([]string{"c", "a", "d", "b"}))
+
+			m.reverseSort()
+			Expect(names()).To(Equal([]string{"d", "a", "c", "b"}))
+		})
+
+		It("should reverse by NAME when unsorted", func() {
+			m.reverseSort()
+			Expect(names()).To(Equal([]string{"d", "c", "b", "a"}))
+		})
+
+		It("should return visible objects filtered and in row order", func() {
+			m.reverseSort()
+			m.setKeyword("a", nil)
+			objs := m.VisibleObjs()
+			Expect(objs).To(HaveLen(1))
+			Expect(objs[0].GetName()).To(Equal("a"))
+
+			m.setKeyword("", nil)
+			Expect(m.VisibleObjs()[0].GetName()).To(Equal("d"))
+		})
+
+		It("should count the matching objects of all", func() {
+			m.setKeyword("a", nil)
+			visible, total := m.Counts()
+			Expect(visible).To(Equal(1))
+			Expect(total).To(Equal(len(m.objs)))
+
+			m.setCandidate(m.nodes[0])
+			visible, _ = m.Counts()
+			Expect(visible).To(Equal(1), "candidates add no rows")
+
+			m.setKeyword("", nil)
+			visible, _ = m.Counts()
+			Expect(visible).To(Equal(total))
+		})
+
+		It("should keep the row order of equally scored fuzzy matches", func() {
+			var objs []*unstructured.Unstructured
+			var exact, longer []string
+			for i := range 26 {
+				name := fmt.Sprintf("deploy-%c", 'a'+i)
+				if i%2 == 0 {
+					objs = append(objs, newDeploy(name, int64(10)))
+					exact = append(exact, name)
+				} else {
+					objs = append(objs, newDeploy(name, int64(1000)))
+					longer = append(longer, name)
+				}
+			}
+			m.setObjs(objs)
+			m.setKeyword("10", nil)
+			Expect(names()).To(Equal(append(exact, longer...)))
+		})
+
+		It("should filter by a regexp on any cell in row order", func() {
+			m.setKeyword("^10$|^c", regexp.MustCompile("^10$|^c"))
+			Expect(names()).To(Equal([]string{"a", "c", "d"}))
+
+			lines := m.buildLines()
+			Expect(lines[0].row.matches).To(HaveKey(1))
+			Expect(lines[0].row.matches[1].MatchedIndexes).To(Equal([]int{0, 1}))
+			Expect(lines[1].row.matches[0].MatchedIndexes).To(Equal([]int{0}))
+		})
+
+		It("should filter on the column ANDed with the keyword", func() {
+			m.setColumnFilter(replicas, "10")
+			Expect(names()).To(Equal([]string{"a", "d"}))
+
+			m.setKeyword("d", nil)
+			lines := m.buildLines()
+			Expect(names()).To(Equal([]string{"d"}))
+			Expect(lines[0].row.matches[1].MatchedIndexes).To(Equal([]int{0, 1}))
+
+			m.setNodes([]*kube.Node{})
+			Expect(m.colFilter).To(BeNil())
+		})
+
+		It("should read the raw value of the highlighted cell", func() {
+			m.cursor = 2 // c
+			value, found, ok := m.cellValue()
+			Expect(ok).To(BeTrue())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("c"))
+
+			m.Update(tea.KeyMsg{Type: tea.KeyRight})
+			m.Update(tea.KeyMsg{Type: tea.KeyRight}) // stays on the last column
+			Expect(m.col).To(Equal(1))
+			value, _, _ = m.cellValue()
+			Expect(value).To(Equal("9"))
+
+			m.cursor = 1 // b, without replicas
+			_, found, ok = m.cellValue()
+			Expect(ok).To(BeTrue())
+			Expect(found).To(BeFalse())
+			msg := m.copyCell()()
+			Expect(msg).To(HaveField("Status", event.Warn))
+			Expect(msg).NotTo(HaveField("Message", ContainSubstring(kube.MissingValue())))
+		})
+
+		It("should move with vim keys", func() {
+			m.View() // counts the rendered lines
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+			Expect(m.cursor).To(Equal(1))
+			Expect(m.col).To(Equal(1))
+
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
+			Expect(m.cursor).To(Equal(0))
+			Expect(m.col).To(Equal(0))
+		})
+
+		It("should page and jump within the rows", func() {
+			m.rowsView.Height = 3 // two rows above the root status bar
+			m.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+			Expect(m.rowsView.YOffset).To(Equal(2))
+			Expect(m.cursor).To(Equal(0))
+
+			m.Update(tea.KeyMsg{Type: tea.KeyEnd})
+			Expect(m.rowsView.YOffset).To(Equal(2))
+			Expect(m.cursor + m.rowsView.YOffset).To(Equal(3))
+
+			m.Update(tea.KeyMsg{Type: tea.KeyPgUp})
+			Expect(m.cursor + m.rowsView.YOffset).To(Equal(1))
+
+			m.Update(tea.KeyMsg{Type: tea.KeyHome})
+			Expect(m.rowsView.YOffset).To(Equal(0))
+			Expect(m.cursor).To(Equal(0))
+		})
+
+		It("should move the highlighted column back when it is unpicked", func() {
+			m.col = 1
+			m.setNodes(nil)
+			Expect(m.col).To(Equal(0))
+		})
+
+		It("should drop a row deleted after it was added", func() {
+			objs := m.objs
+			before := len(m.buildLines())
+
+			added := append(slices.Clone(objs), newDeploy("e", int64(1)))
+			m.Update(SetTableMsg{Nodes: []*kube.Node{replicas}, Objs: added})
+			Expect(m.buildLines()).To(HaveLen(before + 1))
+			m.Update(tea.KeyMsg{Type: tea.KeyEnd})
+			Expect(names()[m.cursor+m.rowsView.YOffset]).To(Equal("e"))
+
+			m.Update(SetTableMsg{Nodes: []*kube.Node{replicas}, Objs: objs})
+			Expect(m.buildLines()).To(HaveLen(before))
+			Expect(names()).NotTo(ContainElement("e"))
+			Expect(m.cursor + m.rowsView.YOffset).To(Equal(before - 1))
+		})
+
+		It("should keep the cursor on the same object after a re-sort", func() {
+			m.cursor = 1 // b
+			m.reverseSort()
+			Expect(names()[m.cursor+m.rowsView.YOffset]).To(Equal("b"))
+		})
+	})
+
+	Describe("Cell values", func() {
+		It("should render refreshed objects of the same UID", func() {
+			pod := func(phase string) *unstructured.Unstructured {
+				return &unstructured.Unstructured{Object: map[string]interface{}{
+					"metadata": map[string]interface{}{"name": "web", "uid": "uid-web"},
+					"status":   map[string]interface{}{"phase": phase},
+				}}
+			}
+			fieldTree := map[string]*kube.Field{
+				"status": {Name: "status", Type: "PodStatus", Children: map[string]*kube.Field{
+					"phase": {Name: "phase", Type: "string", Prefix: []string{"status"}},
+				}},
+			}
+			objs := []*unstructured.Unstructured{pod("Pending")}
+			phase := kube.CreateNodeTree(fieldTree, objs, nil)["status"].Children()["phase"]
+
+			m := NewModel(nil, nil)
+			m.Update(tea.WindowSizeMsg{Width: 200, Height: 20})
+			m.Update(SetTableMsg{Nodes: []*kube.Node{phase}, Objs: objs})
+			Expect(m.View()).To(ContainSubstring("Pending"))
+
+			m.Update(SetTableMsg{Nodes: []*kube.Node{phase}, Objs: []*unstructured.Unstructured{pod("Running")}})
+			Expect(m.View()).To(ContainSubstring("Running"))
+			Expect(m.View()).NotTo(ContainSubstring("Pending"))
+		})
+
+		It("should show timestamps as ages but sort by the timestamps", func() {
+			pod := func(name string, age time.Duration) *unstructured.Unstructured {
+				created := time.Now().Add(-age).UTC().Format(time.RFC3339)
+				return &unstructured.Unstructured{Object: map[string]interface{}{
+					"metadata": map[string]interface{}{"name": name, "creationTimestamp": created},
+				}}
+			}
+			fieldTree := map[string]*kube.Field{
+				"metadata": {Name: "metadata", Type: "ObjectMeta", Children: map[string]*kube.Field{
+					"creationTimestamp": {Name: "creationTimestamp", Type: "string", Prefix: []string{"metadata"}},
+				}},
+			}
+			objs := []*unstructured.Unstructured{pod("young", 10*time.Hour), pod("old", 72*time.Hour)}
+			created := kube.CreateNodeTree(fieldTree, objs, nil)["metadata"].Children()["creationTimestamp"]
+
+			m := NewModel(nil, objs)
+			m.rowsView.Height = 10
+			m.setNodes([]*kube.Node{created})
+			m.setOrder(created, false)
+			lines := strings.Split(m.renderRow(), "\n")
+
+			Expect(lines).To(HaveLen(2))
+			Expect(lines[0]).To(ContainSubstring("old"))
+			Expect(lines[0]).To(ContainSubstring("3d"))
+			Expect(lines[1]).To(ContainSubstring("young"))
+			Expect(lines[1]).To(ContainSubstring("10h"))
+
+			m.cursor, m.col = 0, 1 // old
+			value, _, _ := m.cellValue()
+			Expect(value).To(Equal(objs[1].GetCreationTimestamp().UTC().Format(time.RFC3339)))
+		})
+	})
+
+	Describe("Horizontal scroll", func() {
+		var m *Model
+
+		BeforeEach(func() {
+			value := strings.Repeat("v", 20)
+			objs := []*unstructured.Unstructured{
+				{Object: map[string]interface{}{"a": value, "b": value, "c": value}},
+			}
+			objs[0].SetName("pod")
+			fieldTree := map[string]*kube.Field{
+				"a": {Name: "a", Type: "string"},
+				"b": {Name: "b", Type: "string"},
+				"c": {Name: "c", Type: "string"},
+			}
+			nodes := kube.CreateNodeTree(fieldTree, objs, nil)
+
+			m = NewModel(nil, objs)
+			m.rowsView.Width = 60 // NAME and two columns
+			m.rowsView.Height = 10
+			m.setNodes([]*kube.Node{nodes["a"], nodes["b"], nodes["c"]})
+		})
+
+		It("should pin NAME and shift the node columns to the highlighted one", func() {
+			Expect(m.visibleCols()).To(Equal([]int{0, 1, 2}))
+
+			for range 3 {
+				m.Update(tea.KeyMsg{Type: tea.KeyRight})
+			}
+			Expect(m.col).To(Equal(3))
+			Expect(m.visibleCols()).To(Equal([]int{0, 2, 3}))
+			Expect(m.renderHeader()).To(ContainSubstring("NAME"))
+			Expect(m.renderHeader()).NotTo(ContainSubstring("A "))
+
+			m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+			m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+			Expect(m.visibleCols()).To(Equal([]int{0, 1, 2}))
+		})
+
+		It("should show the column just picked", func() {
+			m.Update(SetTableMsg{Nodes: m.nodes, Objs: m.objs, ShowLatest: true})
+			Expect(m.col).To(Equal(3))
+			Expect(m.visibleCols()).To(ContainElement(3))
+		})
+
+		It("should keep the offset within the picked columns", func() {
+			m.colOffset = 2
+			m.Update(SetTableMsg{Nodes: m.nodes[:1], Objs: m.objs})
+			Expect(m.colOffset).To(Equal(0))
+			Expect(m.visibleCols()).To(Equal([]int{0, 1}))
+		})
+	})
+
+	Describe("Row selection", func() {
+		var m *Model
+
+		newObj := func(name string) *unstructured.Unstructured {
+			obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			obj.SetNamespace("default")
+			obj.SetName(name)
+			return obj
+		}
+
+		names := func() []string {
+			var result []string
+			for _, line := range m.buildLines() {
+				result = append(result, line.row.cells[0])
+			}
+			return result
+		}
+
+		BeforeEach(func() {
+			m = NewModel(nil, []*unstructured.Unstructured{newObj("a"), newObj("b"), newObj("c")})
+			m.rowsView.Height = 10
+		})
+
+		It("should show only the selected rows", func() {
+			m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+			m.cursor = 2
+			m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+			Expect(names()).To(Equal([]string{"a", "c"}))
+			Expect(m.cursor).To(Equal(1)) // still on c
+
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+			Expect(names()).To(Equal([]string{"a", "b", "c"}))
+		})
+
+		It("should keep the selection across refreshes by object identity", func() {
+			m.cursor = 1
+			m.toggleRow()
+			m.toggleOnlySelected()
+
+			m.Update(SetTableMsg{Objs: []*unstructured.Unstructured{newObj("0"), newObj("a"), newObj("b")}})
+			Expect(names()).To(Equal([]string{"b"}))
+		})
+
+		It("should show all rows again when the selected objects are gone", func() {
+			m.toggleRow()
+			m.toggleOnlySelected()
+
+			m.setObjs([]*unstructured.Unstructured{newObj("b")})
+			Expect(m.onlySelected).To(BeFalse())
+			Expect(names()).To(Equal([]string{"b"}))
+		})
+
+		It("should not hide every row without a selection", func() {
+			m.toggleOnlySelected()
+			Expect(m.onlySelected).To(BeFalse())
+		})
+	})
+
+	Describe("Display width", func() {
+		It("should align the columns of wide values", func() {
+			objs := []*unstructured.Unstructured{
+				{Object: map[string]interface{}{"desc": "日本語"}},
+				{Object: map[string]interface{}{"desc": "abc"}},
+			}
+			objs[0].SetName("웹서버")
+			objs[1].SetName("web")
+			fieldTree := map[string]*kube.Field{
+				"desc": {Name: "desc", Type: "string"},
+			}
+			desc := kube.CreateNodeTree(fieldTree, objs, nil)["desc"]
+
+			m := NewModel(nil, objs)
+			m.rowsView.Width = 60
+			m.rowsView.Height = 10
+			m.setNodes([]*kube.Node{desc})
+			Expect(m.nameMaxWidth).To(Equal(6))
+			Expect(m.nodeMaxWidths).To(Equal([]int{6}))
+			Expect(m.maxWidth(desc)).To(Equal(6))
+
+			width := lipgloss.Width(m.renderHeader())
+			for _, line := range strings.Split(m.renderRow(), "\n") {
+				Expect(lipgloss.Width(line)).To(Equal(width))
+			}
+		})
+	})
+
+	Describe("Inspect", func() {
+		var m *Model
+
+		BeforeEach(func() {
+			var objs []*unstructured.Unstructured
+			for _, name := range []string{"a", "b"} {
+				obj := &unstructured.Unstructured{Object: map[string]interface{}{
+					"spec": map[string]interface{}{"replicas": int64(3)},
+				}}
+				obj.SetNamespace("default")
+				obj.SetName(name)
+				objs = append(objs, obj)
+			}
+			m = NewModel(nil, objs)
+			m.Update(SetScopeMsg{Kind: "Deployment", Scope: "ctx"})
+			m.Update(tea.WindowSizeMsg{Width: 200, Height: 6})
+		})
+
+		press := func(r rune) {
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+
+		It("should show the YAML of the object under the cursor", func() {
+			m.View()
+			press('j')
+			press('i')
+			Expect(m.Inspecting()).To(BeTrue())
+			view := m.View()
+			Expect(view).To(ContainSubstring("Deployment default/b"))
+			Expect(view).To(ContainSubstring("metadata:"))
+			Expect(view).NotTo(ContainSubstring("replicas"), "only the lines scrolled to are rendered")
+			Expect(m.Keys().ShortHelp()).To(HaveLen(1))
+		})
+
+		It("should scroll the YAML instead of the rows and close on esc", func() {
+			press('i')
+			press('j')
+			Expect(m.inspectView.YOffset).To(Equal(1))
+			Expect(m.cursor).To(Equal(0))
+
+			m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+			Expect(m.Inspecting()).To(BeFalse())
+			Expect(m.View()).To(ContainSubstring("NAME"))
+		})
+	})
+
+	Describe("Namespace toggle", func() {
+		newObj := func(namespace, name string) *unstructured.Unstructured {
+			obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			obj.SetNamespace(namespace)
+			obj.SetName(name)
+			return obj
+		}
+
+		It("should prefix namespaces and widen the NAME column", func() {
+			m := NewModel(nil, []*unstructured.Unstructured{
+				newObj("kube-system", "coredns"),
+				newObj("", "node-1"),
+			})
+			Expect(m.nameMaxWidth).To(Equal(len("coredns")))
+
+			m.toggleNamespace()
+			Expect(m.displayName(m.objs[0])).To(Equal("kube-system/coredns"))
+			Expect(m.displayName(m.objs[1])).To(Equal("node-1"))
+			Expect(m.nameMaxWidth).To(Equal(len("kube-system/coredns")))
+
+			m.toggleNamespace()
+			Expect(m.displayName(m.objs[0])).To(Equal("coredns"))
+			Expect(m.nameMaxWidth).To(Equal(len("coredns")))
+		})
+	})
+
+	Describe("Empty state", func() {
+		It("should name the kind and scope without moving the cursor", func() {
+			m := NewModel(nil, nil)
+			m.rowsView.Width, m.rowsView.Height = 60, 5
+			m.Update(SetScopeMsg{Kind: "Pod", Scope: "kind-kind/default"})
+			Expect(m.nameMaxWidth).To(BeZero())
+
+			m.Update(tea.KeyMsg{Type: tea.KeyDown})
+			Expect(m.cursor).To(BeZero())
+			Expect(m.View()).To(ContainSubstring("no Pod found in kind-kind/default"))
+		})
+
+		It("should render the rows once the kind has objects", func() {
+			m := NewModel(nil, nil)
+			m.rowsView.Width, m.rowsView.Height = 60, 5
+			m.Update(SetTableMsg{Objs: []*unstructured.Unstructured{
+				{Object: map[string]interface{}{"metadata": map[string]interface{}{"name": "coredns"}}},
+			}})
+
+			Expect(m.nameMaxWidth).To(Equal(len("coredns")))
+			Expect(m.View()).To(ContainSubstring("coredns"))
+			Expect(m.View()).NotTo(ContainSubstring("no resources found"))
+		})
+	})
+
+	Describe("Theme", func() {
+		It("should restyle when the flavour changes", func() {
+			DeferCleanup(func() { Expect(theme.SetFlavour("mocha")).To(Succeed()) })
+			m := NewModel(nil, nil)
+			Expect(m.styles.selected.GetBackground()).To(Equal(theme.Surface0()))
+
+			Expect(theme.SetFlavour("latte")).To(Succeed())
+			m.View()
+			Expect(m.styles.selected.GetBackground()).To(Equal(lipgloss.Color(catppuccin.Latte.Surface0().Hex)))
+		})
+	})
+
+	Describe("Value colors", func() {
+		It("should classify status values regardless of case", func() {
+			good, ok := valueColor("Running")
+			Expect(ok).To(BeTrue())
+			bad, _ := valueColor("CrashLoopBackOff")
+			pending, _ := valueColor("pending")
+			Expect(bad).NotTo(Equal(good))
+			Expect(pending).NotTo(Equal(good))
+			Expect(pending).NotTo(Equal(bad))
+
+			t, _ := valueColor("true")
+			Expect(t).To(Equal(good))
+			f, _ := valueColor("false")
+			Expect(f).To(Equal(bad))
+
+			_, ok = valueColor("nginx")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should be off until toggled", func() {
+			m := NewModel(nil, nil)
+			Expect(m.colorValues).To(BeFalse())
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+			Expect(m.colorValues).To(BeTrue())
+		})
+	})
 })
+
+// BenchmarkRender refreshes and renders a table of 50 columns over 500 rows, as on a watch event
+func BenchmarkRender(b *testing.B) {
+	leaves := map[string]*kube.Field{}
+	var names []string
+	for i := range 50 {
+		name := fmt.Sprintf("field%02d", i)
+		names = append(names, name)
+		leaves[name] = &kube.Field{Name: name, Type: "string", Prefix: []string{"spec", "template", "spec"}}
+	}
+	fields := map[string]*kube.Field{
+		"spec": {Name: "spec", Type: "Object", Children: map[string]*kube.Field{
+			"template": {Name: "template", Type: "Object", Prefix: []string{"spec"}, Children: map[string]*kube.Field{
+				"spec": {Name: "spec", Type: "Object", Prefix: []string{"spec", "template"}, Children: leaves},
+			}},
+		}},
+	}
+
+	objs := make([]*unstructured.Unstructured, 500)
+	for i := range objs {
+		values := map[string]interface{}{}
+		for _, name := range names {
+			values[name] = fmt.Sprintf("%s-value-%d", name, i)
+		}
+		objs[i] = &unstructured.Unstructured{Object: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name":      fmt.Sprintf("obj-%d", i),
+				"namespace": "default",
+				"uid":       fmt.Sprintf("uid-%d", i),
+			},
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{"spec": values},
+			},
+		}}
+	}
+	spec := kube.CreateNodeTree(fields, objs, nil)["spec"].Children()["template"].Children()["spec"]
+	var nodes []*kube.Node
+	for _, name := range names {
+		nodes = append(nodes, spec.Children()[name])
+	}
+
+	m := NewModel(nil, nil)
+	m.Update(tea.WindowSizeMsg{Width: 400, Height: 50})
+	for b.Loop() {
+		m.Update(SetTableMsg{Nodes: nodes, Objs: objs})
+		m.View()
+	}
+}
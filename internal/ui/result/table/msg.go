@@ -1,6 +1,8 @@
 package table
 
 import (
+	"regexp"
+
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/flavono123/kattle/internal/kube"
@@ -10,11 +12,33 @@ type SetCandidateMsg struct {
 	Candidate *kube.Node
 }
 
+// SetKeywordMsg filters rows by the keyword, as a regexp when Pattern is set
 type SetKeywordMsg struct {
 	Keyword string
+	Pattern *regexp.Regexp
+}
+
+// SetColumnFilterMsg filters rows by the keyword on the node's column only, a nil node clears it
+type SetColumnFilterMsg struct {
+	Node    *kube.Node
+	Keyword string
 }
 
 type SetTableMsg struct {
 	Nodes []*kube.Node
 	Objs  []*unstructured.Unstructured
+	// ShowLatest scrolls to the last column, e.g. the one just picked
+	ShowLatest bool
+}
+
+// SetOrderMsg sorts rows by the node's column, with group headers when Group is set
+type SetOrderMsg struct {
+	Node  *kube.Node
+	Group bool
+}
+
+// SetScopeMsg names the kind and where it is informed, e.g. context/namespace, for the empty table
+type SetScopeMsg struct {
+	Kind  string
+	Scope string
 }
@@ -0,0 +1,90 @@
+package table
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/flavono123/kattle/internal/ui/event"
+)
+
+// rowKey identifies the object of a row, stable across re-sorts and watch refreshes
+func rowKey(obj *unstructured.Unstructured) string {
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
+
+// toggleRow selects or unselects the object under the cursor
+func (m *Model) toggleRow() tea.Cmd {
+	lines := m.buildLines()
+	idx := m.cursor + m.rowsView.YOffset
+	if idx < 0 || idx >= len(lines) || lines[idx].row == nil {
+		return nil
+	}
+
+	key := rowKey(lines[idx].row.obj)
+	if m.selectedRows[key] {
+		delete(m.selectedRows, key)
+	} else {
+		m.selectedRows[key] = true
+	}
+
+	if m.onlySelected && len(m.selectedRows) == 0 {
+		m.onlySelected = false // nothing left to compare
+	}
+	m.clampCursor()
+	return m.tableUpdated()
+}
+
+// toggleOnlySelected hides the rows not selected, or shows them back
+func (m *Model) toggleOnlySelected() tea.Cmd {
+	if !m.onlySelected && len(m.selectedRows) == 0 {
+		return func() tea.Msg {
+			return event.SetStatusMsg{
+				Message: "no rows selected",
+				Status:  event.Warn,
+			}
+		}
+	}
+
+	m.keepCursor(func() { m.onlySelected = !m.onlySelected })
+	m.clampCursor()
+
+	message := "showing all rows"
+	if m.onlySelected {
+		message = fmt.Sprintf("showing %d selected rows", len(m.selectedRows))
+	}
+	return tea.Batch(m.tableUpdated(), func() tea.Msg {
+		return event.SetStatusMsg{
+			Message: message,
+			Status:  event.Info,
+		}
+	})
+}
+
+// pruneSelectedRows forgets the selected objects that are gone
+func (m *Model) pruneSelectedRows() {
+	if len(m.selectedRows) == 0 {
+		return
+	}
+
+	alive := make(map[string]bool, len(m.objs))
+	for _, obj := range m.objs {
+		alive[rowKey(obj)] = true
+	}
+	for key := range m.selectedRows {
+		if !alive[key] {
+			delete(m.selectedRows, key)
+		}
+	}
+	if len(m.selectedRows) == 0 {
+		m.onlySelected = false
+	}
+}
+
+// clampCursor keeps the cursor within the rendered lines after rows are hidden
+func (m *Model) clampCursor() {
+	if count := len(m.buildLines()); m.cursor+m.rowsView.YOffset >= count {
+		m.moveCursorTo(max(count-1, 0))
+	}
+}
@@ -0,0 +1,118 @@
+package table
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/flavono123/kattle/internal/kube"
+	"github.com/flavono123/kattle/internal/ui/event"
+	"github.com/flavono123/kattle/internal/ui/theme"
+)
+
+// valueColor classifies well-known status values, e.g. Running is good and CrashLoopBackOff bad
+func valueColor(value string) (lipgloss.Color, bool) {
+	switch strings.ToLower(value) {
+	case "true", "running", "ready", "succeeded", "active", "bound", "available":
+		return theme.Green(), true
+	case "false", "failed", "error", "crashloopbackoff", "imagepullbackoff", "errimagepull", "oomkilled", "evicted", "lost":
+		return theme.Red(), true
+	case "pending", "unknown", "terminating", "containercreating":
+		return theme.Yellow(), true
+	}
+	return "", false
+}
+
+// toggleValueColors colors the cells by valueColor, off by default for colorblind users
+func (m *Model) toggleValueColors() tea.Cmd {
+	m.colorValues = !m.colorValues
+
+	message := "value colors off"
+	if m.colorValues {
+		message = "value colors on"
+	}
+	return func() tea.Msg {
+		return event.SetStatusMsg{
+			Message: message,
+			Status:  event.Info,
+		}
+	}
+}
+
+// cellKey identifies a cell value within a render pass, see valStr
+type cellKey struct {
+	path       string
+	aggregated bool
+	obj        string
+	raw        bool // unformatted, see sortValue
+}
+
+// cellValues memoizes kube.FormatValStr and kube.ValStr for a render pass, with the parts of the keys
+// so they are not rebuilt for every cell
+type cellValues struct {
+	values map[cellKey]string
+	paths  map[*kube.Node]string
+	objs   map[*unstructured.Unstructured]string
+}
+
+func newCellValues() cellValues {
+	return cellValues{
+		values: map[cellKey]string{},
+		paths:  map[*kube.Node]string{},
+		objs:   map[*unstructured.Unstructured]string{},
+	}
+}
+
+// valStr is kube.FormatValStr computed once per cell until resetValues,
+// the widths, filters and rows of a pass all read the same cells
+func (m *Model) valStr(node *kube.Node, obj *unstructured.Unstructured) string {
+	return m.cachedValStr(node, obj, false)
+}
+
+// sortValue is the raw kube.ValStr of a cell, so e.g. ages sort by their timestamps
+func (m *Model) sortValue(node *kube.Node, obj *unstructured.Unstructured) string {
+	return m.cachedValStr(node, obj, true)
+}
+
+func (m *Model) cachedValStr(node *kube.Node, obj *unstructured.Unstructured, raw bool) string {
+	path, ok := m.cells.paths[node]
+	if !ok {
+		path = strings.Join(node.NodeFullPath(), ".")
+		m.cells.paths[node] = path
+	}
+	objKey, ok := m.cells.objs[obj]
+	if !ok {
+		objKey = cellObjKey(obj)
+		m.cells.objs[obj] = objKey
+	}
+
+	k := cellKey{path: path, aggregated: node.Aggregated, obj: objKey, raw: raw}
+	if v, ok := m.cells.values[k]; ok {
+		return v
+	}
+	var v string
+	if raw {
+		v = kube.ValStr(node, obj)
+	} else {
+		v = kube.FormatValStr(node, obj)
+	}
+	m.cells.values[k] = v
+	return v
+}
+
+// resetValues starts a render pass, at every Update and whenever the objects change
+func (m *Model) resetValues() {
+	clear(m.cells.values)
+	clear(m.cells.paths)
+	clear(m.cells.objs)
+}
+
+// cellObjKey identifies an object by its UID, falling back to rowKey for objects without one
+func cellObjKey(obj *unstructured.Unstructured) string {
+	if uid := obj.GetUID(); uid != "" {
+		return string(uid)
+	}
+	return rowKey(obj)
+}
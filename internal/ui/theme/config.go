@@ -0,0 +1,73 @@
+package theme
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	catppuccin "github.com/catppuccin/go"
+
+	"github.com/flavono123/kattle/internal/config"
+)
+
+var flavours = map[string]catppuccin.Flavour{
+	"latte":     catppuccin.Latte,
+	"frappe":    catppuccin.Frappe,
+	"macchiato": catppuccin.Macchiato,
+	"mocha":     catppuccin.Mocha,
+}
+
+var roles = map[string]struct{}{
+	"rosewater": {}, "flamingo": {}, "pink": {}, "mauve": {}, "red": {}, "maroon": {},
+	"peach": {}, "yellow": {}, "green": {}, "teal": {}, "sky": {}, "sapphire": {},
+	"blue": {}, "lavender": {}, "text": {}, "subtext0": {}, "subtext1": {},
+	"overlay0": {}, "overlay1": {}, "overlay2": {}, "surface0": {}, "surface1": {},
+	"surface2": {}, "base": {}, "mantle": {}, "crust": {},
+}
+
+var hexColor = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Apply switches the palette to the configured flavour preset and role overrides.
+// On an invalid config the palette is left untouched (Mocha by default).
+func Apply(cfg config.ThemeConfig) error {
+	flavour := catppuccin.Mocha
+	if cfg.Flavour != "" {
+		f, err := lookupFlavour(cfg.Flavour)
+		if err != nil {
+			return err
+		}
+		flavour = f
+	}
+
+	colors := make(map[string]string, len(cfg.Colors))
+	for role, hex := range cfg.Colors {
+		role = strings.ToLower(role)
+		if _, ok := roles[role]; !ok {
+			return fmt.Errorf("unknown theme color role %q", role)
+		}
+		if !hexColor.MatchString(hex) {
+			return fmt.Errorf("invalid color %q for role %q, expected #rrggbb", hex, role)
+		}
+		colors[role] = hex
+	}
+
+	for _, hex := range []string{cfg.Gradient.Start, cfg.Gradient.End} {
+		if hex != "" && !hexColor.MatchString(hex) {
+			return fmt.Errorf("invalid gradient color %q, expected #rrggbb", hex)
+		}
+	}
+
+	theme = flavour
+	overrides = colors
+	gradientStart, gradientEnd = cfg.Gradient.Start, cfg.Gradient.End
+	version++
+	return nil
+}
+
+func lookupFlavour(name string) (catppuccin.Flavour, error) {
+	flavour, ok := flavours[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown theme flavour %q, use latte, frappe, macchiato or mocha", name)
+	}
+	return flavour, nil
+}
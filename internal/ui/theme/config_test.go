@@ -0,0 +1,64 @@
+package theme
+
+import (
+	"testing"
+
+	catppuccin "github.com/catppuccin/go"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flavono123/kattle/internal/config"
+)
+
+func TestApply(t *testing.T) {
+	t.Cleanup(func() { _ = Apply(config.ThemeConfig{}) })
+
+	t.Run("defaults to mocha", func(t *testing.T) {
+		assert.NoError(t, Apply(config.ThemeConfig{}))
+		assert.Equal(t, lipgloss.Color(catppuccin.Mocha.Blue().Hex), Blue())
+		assert.Equal(t, catppuccin.Mocha.Yellow().Hex, GradientStart())
+	})
+
+	t.Run("applies a preset with overrides", func(t *testing.T) {
+		err := Apply(config.ThemeConfig{
+			Flavour:  "Latte",
+			Colors:   map[string]string{"Blue": "#112233"},
+			Gradient: config.GradientConfig{End: "#445566"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, lipgloss.Color("#112233"), Blue())
+		assert.Equal(t, lipgloss.Color(catppuccin.Latte.Green().Hex), Green())
+		assert.Equal(t, "#445566", GradientEnd())
+	})
+
+	t.Run("rejects invalid config and keeps the palette", func(t *testing.T) {
+		assert.NoError(t, Apply(config.ThemeConfig{}))
+		for _, cfg := range []config.ThemeConfig{
+			{Flavour: "dracula"},
+			{Colors: map[string]string{"chartreuse": "#112233"}},
+			{Colors: map[string]string{"blue": "blue"}},
+			{Gradient: config.GradientConfig{Start: "#12"}},
+		} {
+			assert.Error(t, Apply(cfg))
+		}
+		assert.Equal(t, lipgloss.Color(catppuccin.Mocha.Blue().Hex), Blue())
+	})
+}
+
+func TestSetFlavour(t *testing.T) {
+	t.Cleanup(func() { _ = Apply(config.ThemeConfig{}) })
+	assert.NoError(t, Apply(config.ThemeConfig{Colors: map[string]string{"green": "#112233"}}))
+
+	v := Version()
+	assert.NoError(t, SetFlavour("LATTE"))
+	assert.NotEqual(t, v, Version())
+	assert.Equal(t, lipgloss.Color(catppuccin.Latte.Blue().Hex), Blue())
+	assert.Equal(t, lipgloss.Color("#112233"), Green(), "overrides are kept")
+	assert.Equal(t, catppuccin.Latte.Yellow().Hex, GradientStart())
+	assert.Equal(t, catppuccin.Latte.Blue().Hex, GradientEnd())
+
+	v = Version()
+	assert.Error(t, SetFlavour("solarized"))
+	assert.Equal(t, v, Version())
+	assert.Equal(t, lipgloss.Color(catppuccin.Latte.Blue().Hex), Blue())
+}
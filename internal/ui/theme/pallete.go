@@ -1,42 +1,72 @@
 package theme
 
 import (
+	"cmp"
+
 	catppuccin "github.com/catppuccin/go"
 	"github.com/charmbracelet/lipgloss"
 )
 
 var theme = catppuccin.Mocha
 
-var gradientFlavour = catppuccin.Latte
+// overrides maps a role name (e.g. "blue", "surface0") to a user hex color
+var overrides = map[string]string{}
 
-var (
-	LatteYellow string = gradientFlavour.Yellow().Hex // gradient start
-	LatteBlue   string = gradientFlavour.Blue().Hex   // gradient end
-)
+// gradientStart and gradientEnd are user hex colors, the flavour's yellow and blue when empty
+var gradientStart, gradientEnd string
+
+// version counts the palette changes, for styles built ahead of rendering to catch up
+var version int
+
+func color(role string, c catppuccin.Color) lipgloss.Color {
+	if hex, ok := overrides[role]; ok {
+		return lipgloss.Color(hex)
+	}
+	return lipgloss.Color(c.Hex)
+}
+
+func Rosewater() lipgloss.Color { return color("rosewater", theme.Rosewater()) }
+func Flamingo() lipgloss.Color  { return color("flamingo", theme.Flamingo()) }
+func Pink() lipgloss.Color      { return color("pink", theme.Pink()) }
+func Mauve() lipgloss.Color     { return color("mauve", theme.Mauve()) }
+func Red() lipgloss.Color       { return color("red", theme.Red()) }
+func Maroon() lipgloss.Color    { return color("maroon", theme.Maroon()) }
+func Peach() lipgloss.Color     { return color("peach", theme.Peach()) }
+func Yellow() lipgloss.Color    { return color("yellow", theme.Yellow()) }
+func Green() lipgloss.Color     { return color("green", theme.Green()) }
+func Teal() lipgloss.Color      { return color("teal", theme.Teal()) }
+func Sky() lipgloss.Color       { return color("sky", theme.Sky()) }
+func Sapphire() lipgloss.Color  { return color("sapphire", theme.Sapphire()) }
+func Blue() lipgloss.Color      { return color("blue", theme.Blue()) }
+func Lavender() lipgloss.Color  { return color("lavender", theme.Lavender()) }
+func Text() lipgloss.Color      { return color("text", theme.Text()) }
+func Subtext0() lipgloss.Color  { return color("subtext0", theme.Subtext0()) }
+func Subtext1() lipgloss.Color  { return color("subtext1", theme.Subtext1()) }
+func Overlay0() lipgloss.Color  { return color("overlay0", theme.Overlay0()) }
+func Overlay1() lipgloss.Color  { return color("overlay1", theme.Overlay1()) }
+func Overlay2() lipgloss.Color  { return color("overlay2", theme.Overlay2()) }
+func Surface0() lipgloss.Color  { return color("surface0", theme.Surface0()) }
+func Surface1() lipgloss.Color  { return color("surface1", theme.Surface1()) }
+func Surface2() lipgloss.Color  { return color("surface2", theme.Surface2()) }
+func Base() lipgloss.Color      { return color("base", theme.Base()) }
+func Mantle() lipgloss.Color    { return color("mantle", theme.Mantle()) }
+func Crust() lipgloss.Color     { return color("crust", theme.Crust()) }
+
+// GradientStart and GradientEnd are the endpoints of the width-limit progress bar
+func GradientStart() string { return cmp.Or(gradientStart, string(Yellow())) }
+func GradientEnd() string   { return cmp.Or(gradientEnd, string(Blue())) }
+
+// Version changes whenever the palette does, models holding styles rebuild them when it differs
+func Version() int { return version }
 
-func Rosewater() lipgloss.Color { return lipgloss.Color(theme.Rosewater().Hex) }
-func Flamingo() lipgloss.Color  { return lipgloss.Color(theme.Flamingo().Hex) }
-func Pink() lipgloss.Color      { return lipgloss.Color(theme.Pink().Hex) }
-func Mauve() lipgloss.Color     { return lipgloss.Color(theme.Mauve().Hex) }
-func Red() lipgloss.Color       { return lipgloss.Color(theme.Red().Hex) }
-func Maroon() lipgloss.Color    { return lipgloss.Color(theme.Maroon().Hex) }
-func Peach() lipgloss.Color     { return lipgloss.Color(theme.Peach().Hex) }
-func Yellow() lipgloss.Color    { return lipgloss.Color(theme.Yellow().Hex) }
-func Green() lipgloss.Color     { return lipgloss.Color(theme.Green().Hex) }
-func Teal() lipgloss.Color      { return lipgloss.Color(theme.Teal().Hex) }
-func Sky() lipgloss.Color       { return lipgloss.Color(theme.Sky().Hex) }
-func Sapphire() lipgloss.Color  { return lipgloss.Color(theme.Sapphire().Hex) }
-func Blue() lipgloss.Color      { return lipgloss.Color(theme.Blue().Hex) }
-func Lavender() lipgloss.Color  { return lipgloss.Color(theme.Lavender().Hex) }
-func Text() lipgloss.Color      { return lipgloss.Color(theme.Text().Hex) }
-func Subtext0() lipgloss.Color  { return lipgloss.Color(theme.Subtext0().Hex) }
-func Subtext1() lipgloss.Color  { return lipgloss.Color(theme.Subtext1().Hex) }
-func Overlay0() lipgloss.Color  { return lipgloss.Color(theme.Overlay0().Hex) }
-func Overlay1() lipgloss.Color  { return lipgloss.Color(theme.Overlay1().Hex) }
-func Overlay2() lipgloss.Color  { return lipgloss.Color(theme.Overlay2().Hex) }
-func Surface0() lipgloss.Color  { return lipgloss.Color(theme.Surface0().Hex) }
-func Surface1() lipgloss.Color  { return lipgloss.Color(theme.Surface1().Hex) }
-func Surface2() lipgloss.Color  { return lipgloss.Color(theme.Surface2().Hex) }
-func Base() lipgloss.Color      { return lipgloss.Color(theme.Base().Hex) }
-func Mantle() lipgloss.Color    { return lipgloss.Color(theme.Mantle().Hex) }
-func Crust() lipgloss.Color     { return lipgloss.Color(theme.Crust().Hex) }
+// SetFlavour switches the palette to a catppuccin flavour, e.g. latte for light terminals,
+// keeping the role overrides
+func SetFlavour(name string) error {
+	flavour, err := lookupFlavour(name)
+	if err != nil {
+		return err
+	}
+	theme = flavour
+	version++
+	return nil
+}